@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	arcade "github.com/homedepot/arcade/pkg"
@@ -24,6 +26,9 @@ import (
 const (
 	arcadeShortExpirationSeconds = 60
 	mysqlDefaultStringSize       = 256
+	defaultTaskWorkers           = 5
+	defaultReconcileInterval     = 5 * time.Minute
+	defaultHealthCheckInterval   = time.Minute
 )
 
 var (
@@ -87,6 +92,10 @@ func init() {
 	r.Use(gin.Recovery())
 
 	sqlClient := sql.NewClient(dialector())
+	if keyring := encryptionKeyring(); keyring != nil {
+		sqlClient.WithKeyring(keyring)
+	}
+
 	if err := sqlClient.Connect(); err != nil {
 		log.Fatal(err)
 	}
@@ -100,10 +109,16 @@ func init() {
 	ic := &internal.Controller{
 		ArcadeClient:                  arcadeClient,
 		ArtifactCredentialsController: artifactCredentialsController,
+		CredentialPolicy:              credentialPolicy(),
 		SQLClient:                     sqlClient,
 		FiatClient:                    fiatClient,
 		Front50Client:                 front50Client,
 		KubernetesController:          kubeController,
+		PreconfiguredJobs:             preconfiguredJobs(),
+	}
+
+	if os.Getenv("KUBERNETES_USE_INFORMER_CACHE") == "true" {
+		ic.KubernetesResourceCache = kubernetes.NewResourceCache()
 	}
 
 	server := api.NewServer(r)
@@ -113,6 +128,14 @@ func init() {
 		server.WithVerboseRequestLogging()
 	}
 
+	server.WithTaskWorkers(taskWorkers())
+	server.WithResourceReconcileInterval(resourceReconcileInterval())
+
+	if hi := accountHealthCheckInterval(); hi > 0 {
+		ic.KubernetesAccountHealth = kubernetes.NewAccountHealthStore()
+		server.WithAccountHealthCheckInterval(hi)
+	}
+
 	if os.Getenv("KUBERNETES_USE_DISK_CACHE") == "true" {
 		kubernetes.UseDiskCache()
 	}
@@ -180,6 +203,130 @@ func getArtifactsCredentialsController() artifact.CredentialsController {
 	return artifactCredentialsController
 }
 
+// preconfiguredJobs returns the run job templates defined in each file of the
+// directory defined by the PRECONFIGURED_JOBS_CONFIG_DIR environment variable.
+// Returns nil if it is not defined.
+func preconfiguredJobs() kubernetes.PreconfiguredJobs {
+	dir := os.Getenv("PRECONFIGURED_JOBS_CONFIG_DIR")
+	if dir == "" {
+		return nil
+	}
+
+	pjs, err := kubernetes.ReadPreconfiguredJobs(dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return pjs
+}
+
+// credentialPolicy returns the exec commands, exec environment variables and
+// kubeconfig directories providers are allowed to use, each defined by a comma
+// separated environment variable. Providers cannot use any when they are unset.
+func credentialPolicy() kubernetes.CredentialPolicy {
+	return kubernetes.CredentialPolicy{
+		ExecCommands:   splitList(os.Getenv("KUBERNETES_EXEC_COMMANDS")),
+		ExecEnv:        splitList(os.Getenv("KUBERNETES_EXEC_ENV")),
+		KubeconfigDirs: splitList(os.Getenv("KUBERNETES_KUBECONFIG_DIRS")),
+	}
+}
+
+// splitList splits a comma separated list, ignoring empty entries.
+func splitList(s string) []string {
+	list := []string{}
+
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+
+	return list
+}
+
+// taskWorkers returns the number of workers that execute kubernetes
+// operations, defined by the TASK_WORKERS environment variable.
+func taskWorkers() int {
+	workers := os.Getenv("TASK_WORKERS")
+	if workers == "" {
+		return defaultTaskWorkers
+	}
+
+	n, err := strconv.Atoi(workers)
+	if err != nil {
+		log.Printf("[CLOUDDRIVER] invalid TASK_WORKERS value %s; defaulting to %d\n", workers, defaultTaskWorkers)
+
+		return defaultTaskWorkers
+	}
+
+	return n
+}
+
+// resourceReconcileInterval returns the interval on which the resources table
+// is reconciled, defined in seconds by the RECONCILE_INTERVAL_SECONDS
+// environment variable. An interval of 0 disables reconciling.
+func resourceReconcileInterval() time.Duration {
+	seconds := os.Getenv("RECONCILE_INTERVAL_SECONDS")
+	if seconds == "" {
+		return defaultReconcileInterval
+	}
+
+	n, err := strconv.Atoi(seconds)
+	if err != nil {
+		log.Printf("[CLOUDDRIVER] invalid RECONCILE_INTERVAL_SECONDS value %s; defaulting to %v\n",
+			seconds, defaultReconcileInterval)
+
+		return defaultReconcileInterval
+	}
+
+	return time.Duration(n) * time.Second
+}
+
+// accountHealthCheckInterval returns the interval on which the cluster of each
+// account is health checked, defined in seconds by the HEALTH_CHECK_INTERVAL_SECONDS
+// environment variable. An interval of 0 disables health checks.
+func accountHealthCheckInterval() time.Duration {
+	seconds := os.Getenv("HEALTH_CHECK_INTERVAL_SECONDS")
+	if seconds == "" {
+		return defaultHealthCheckInterval
+	}
+
+	n, err := strconv.Atoi(seconds)
+	if err != nil {
+		log.Printf("[CLOUDDRIVER] invalid HEALTH_CHECK_INTERVAL_SECONDS value %s; defaulting to %v\n",
+			seconds, defaultHealthCheckInterval)
+
+		return defaultHealthCheckInterval
+	}
+
+	return time.Duration(n) * time.Second
+}
+
+// encryptionKeyring returns the keyring that encrypts sensitive provider
+// fields in the DB, defined by either the DB_ENCRYPTION_KEY_FILE environment
+// variable, the path of a file with one base64 encoded key per line, or the
+// DB_ENCRYPTION_KEYS environment variable, a comma separated list of base64
+// encoded keys. The first key encrypts, while the others only decrypt, so
+// that keys can be rotated. Returns nil if neither is defined.
+func encryptionKeyring() *sql.Keyring {
+	var (
+		keyring *sql.Keyring
+		err     error
+	)
+
+	if path := os.Getenv("DB_ENCRYPTION_KEY_FILE"); path != "" {
+		keyring, err = sql.ReadKeyringFile(path)
+	} else if keys := os.Getenv("DB_ENCRYPTION_KEYS"); keys != "" {
+		keyring, err = sql.ParseKeyring(keys)
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return keyring
+}
+
 // dialector defines the SQL dialector.
 //
 // Defaults to sqlite if env vars DB_HOST, DB_NAME, DB_PASS, and DB_USER
@@ -2,7 +2,6 @@ package core
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"net/http"
 	"sort"
@@ -13,6 +12,8 @@ import (
 	"unicode"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
@@ -29,7 +30,6 @@ const (
 )
 
 var (
-	errCancelJobNotImplemented  = errors.New("cancelJob is not implemented for the Kubernetes provider")
 	serverGroupManagerResources = []string{
 		"deployments",
 		"replicaSets",
@@ -1592,6 +1592,7 @@ type Job struct {
 	CreatedTime       int64                    `json:"createdTime"`
 	JobState          string                   `json:"jobState"`
 	Location          string                   `json:"location"`
+	MostRecentPodName string                   `json:"mostRecentPodName,omitempty"`
 	Name              string                   `json:"name"`
 	Pods              []map[string]interface{} `json:"pods"`
 	Provider          string                   `json:"provider"`
@@ -1605,7 +1606,7 @@ type JobCompletionDetails struct {
 }
 
 // GetJob retrieves a given Kubernetes job from a given cluster
-// given a namespace and name.
+// given a namespace and name, along with the pods the job created.
 func (cc *Controller) GetJob(c *gin.Context) {
 	account := c.Param("account")
 	// application := c.Param("application")
@@ -1634,30 +1635,263 @@ func (cc *Controller) GetJob(c *gin.Context) {
 
 	j := kubernetes.NewJob(result.Object)
 
-	// TODO fill in pod definitions.
+	pods, err := listJobPods(provider, j, location)
+	if err != nil {
+		clouddriver.Error(c, http.StatusInternalServerError, err)
+		return
+	}
+
 	job := Job{
-		Account: account,
-		CompletionDetails: JobCompletionDetails{
-			ExitCode: "",
-			Message:  "",
-			Reason:   "",
-			Signal:   "",
-		},
-		CreatedTime: result.GetCreationTimestamp().Unix() * 1000,
-		JobState:    j.State(),
-		Location:    location,
-		Name:        name,
-		Pods:        []map[string]interface{}{},
-		Provider:    typeKubernetes,
+		Account:           account,
+		CompletionDetails: newJobCompletionDetails(j, pods),
+		CreatedTime:       result.GetCreationTimestamp().Unix() * 1000,
+		JobState:          j.State(),
+		Location:          location,
+		Name:              name,
+		Pods:              []map[string]interface{}{},
+		Provider:          typeKubernetes,
+	}
+
+	for _, pod := range pods {
+		job.Pods = append(job.Pods, map[string]interface{}{
+			"name":   pod.Object().Name,
+			"status": pod.Object().Status,
+		})
+	}
+
+	if len(pods) > 0 {
+		job.MostRecentPodName = pods[len(pods)-1].Object().Name
 	}
 
 	c.JSON(http.StatusOK, job)
 }
 
-// DeleteJob is not implemented for the Kubernetes provider V2.
-// See https://github.com/spinnaker/spinnaker/issues/4644#issuecomment-627287782.
-func DeleteJob(c *gin.Context) {
-	clouddriver.Error(c, http.StatusInternalServerError, errCancelJobNotImplemented)
+// GetJobFileContents returns the properties output to the logs of a job's
+// container, named by the file name, by the most recent pod the job created.
+// This supports the "consume output" option of Spinnaker's Run Job stage.
+func (cc *Controller) GetJobFileContents(c *gin.Context) {
+	account := c.Param("account")
+	location := c.Param("location")
+	fileName := c.Param("fileName")
+	nameArray := strings.Split(c.Param("name"), " ")
+
+	if len(nameArray) != 2 {
+		clouddriver.Error(c, http.StatusBadRequest, fmt.Errorf("name parameter must be in the format of 'kind name', got: %s", c.Param("name")))
+		return
+	}
+
+	kind := nameArray[0]
+	name := nameArray[1]
+
+	provider, err := cc.KubernetesProviderWithTimeout(account, time.Second*internal.DefaultListTimeoutSeconds)
+	if err != nil {
+		clouddriver.Error(c, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := provider.Client.Get(kind, name, location)
+	if err != nil {
+		clouddriver.Error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	pods, err := listJobPods(provider, kubernetes.NewJob(result.Object), location)
+	if err != nil {
+		clouddriver.Error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if len(pods) == 0 {
+		clouddriver.Error(c, http.StatusNotFound, fmt.Errorf("no pods found for job %s", name))
+		return
+	}
+
+	pod := pods[len(pods)-1].Object()
+
+	logs, err := provider.Clientset.PodLogs(pod.Name, pod.Namespace, fileName)
+	if err != nil {
+		clouddriver.Error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	properties, err := kubernetes.ParseOutputProperties(logs)
+	if err != nil {
+		clouddriver.Error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, properties)
+}
+
+// listJobPods lists the pods created by a job, oldest first.
+func listJobPods(provider *kubernetes.Provider, j *kubernetes.Job, namespace string) ([]*kubernetes.Pod, error) {
+	lo := metav1.ListOptions{
+		LabelSelector: j.PodSelector(),
+	}
+
+	ul, err := provider.Client.ListResourcesByKindAndNamespace("pod", namespace, lo)
+	if err != nil {
+		return nil, fmt.Errorf("error listing pods of job %s: %w", j.Object().Name, err)
+	}
+
+	pods := []*kubernetes.Pod{}
+	if ul == nil {
+		return pods, nil
+	}
+
+	for _, u := range ul.Items {
+		pods = append(pods, kubernetes.NewPod(u.Object))
+	}
+
+	sort.SliceStable(pods, func(i, j int) bool {
+		return pods[i].Object().CreationTimestamp.Before(&pods[j].Object().CreationTimestamp)
+	})
+
+	return pods, nil
+}
+
+// newJobCompletionDetails returns why a failed job failed, from the terminated
+// state of the first failed container of its pods, or else its failed condition.
+// Pods that failed while the job is still running are retried, so they
+// are not reported.
+func newJobCompletionDetails(j *kubernetes.Job, pods []*kubernetes.Pod) JobCompletionDetails {
+	details := JobCompletionDetails{}
+
+	condition := j.FailedCondition()
+	if condition == nil || j.State() != "Failed" {
+		return details
+	}
+
+	details.Message = condition.Message
+	details.Reason = condition.Reason
+
+	for _, pod := range pods {
+		terminated := pod.FailedContainerState()
+		if terminated == nil {
+			continue
+		}
+
+		details.ExitCode = strconv.Itoa(int(terminated.ExitCode))
+		details.Signal = strconv.Itoa(int(terminated.Signal))
+
+		if terminated.Message != "" {
+			details.Message = terminated.Message
+		}
+
+		if terminated.Reason != "" {
+			details.Reason = terminated.Reason
+		}
+
+		break
+	}
+
+	return details
+}
+
+// DeleteJob cancels a given Kubernetes job by deleting it with foreground
+// propagation, so that its pods are deleted before the job is. Only jobs
+// of the application in the path can be deleted.
+//
+// The deletion is recorded as a delete task, which the Task API reports
+// as in progress until the job is gone.
+func (cc *Controller) DeleteJob(c *gin.Context) {
+	taskID := clouddriver.TaskIDFromContext(c)
+	account := c.Param("account")
+	application := c.Param("application")
+	location := c.Param("location")
+	nameArray := strings.Split(c.Param("name"), " ")
+
+	if len(nameArray) != 2 {
+		clouddriver.Error(c, http.StatusBadRequest, fmt.Errorf("name parameter must be in the format of 'kind name', got: %s", c.Param("name")))
+		return
+	}
+
+	kind := nameArray[0]
+	name := nameArray[1]
+
+	if !strings.EqualFold(kind, "job") {
+		clouddriver.Error(c, http.StatusBadRequest, fmt.Errorf("kind must be job, got: %s", kind))
+		return
+	}
+
+	provider, err := cc.KubernetesProviderWithTimeout(account, time.Second*internal.DefaultListTimeoutSeconds)
+	if err != nil {
+		clouddriver.Error(c, http.StatusBadRequest, err)
+		return
+	}
+
+	err = provider.ValidateNamespaceAccess(location)
+	if err != nil {
+		clouddriver.Error(c, http.StatusBadRequest, err)
+		return
+	}
+
+	u, err := provider.Client.Get(kind, name, location)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			clouddriver.Error(c, http.StatusNotFound, err)
+			return
+		}
+
+		clouddriver.Error(c, http.StatusInternalServerError, err)
+
+		return
+	}
+
+	// The application annotation sometimes has a double quote ('"')
+	// character prefix and suffix, as when deployed by the Spinnaker Operator.
+	app := strings.Trim(u.GetAnnotations()[kubernetes.AnnotationSpinnakerMonikerApplication], "\"")
+	if !strings.EqualFold(app, application) {
+		clouddriver.Error(c, http.StatusForbidden, fmt.Errorf("job %s is not in application %s", name, application))
+		return
+	}
+
+	gvr, err := provider.Client.GVRForKind(kind)
+	if err != nil {
+		clouddriver.Error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	propagationPolicy := metav1.DeletePropagationForeground
+	do := metav1.DeleteOptions{
+		PropagationPolicy: &propagationPolicy,
+	}
+
+	err = provider.Client.DeleteResourceByKindAndNameAndNamespace(kind, name, location, do)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			clouddriver.Error(c, http.StatusNotFound, err)
+			return
+		}
+
+		clouddriver.Error(c, http.StatusInternalServerError, err)
+
+		return
+	}
+
+	kr := kubernetes.Resource{
+		AccountName:  account,
+		ID:           uuid.New().String(),
+		TaskID:       taskID,
+		TaskType:     clouddriver.TaskTypeDelete,
+		Timestamp:    internal.CurrentTimeUTC(),
+		APIGroup:     gvr.Group,
+		Name:         name,
+		Namespace:    location,
+		Resource:     gvr.Resource,
+		Version:      gvr.Version,
+		Kind:         kind,
+		SpinnakerApp: application,
+		Cluster:      kubernetes.Cluster(kind, name),
+	}
+
+	err = cc.SQLClient.CreateKubernetesResource(kr)
+	if err != nil {
+		clouddriver.Error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"id": taskID, "resourceUri": "/task/" + taskID})
 }
 
 // listApplicationsResources lists all accounts for a given app, then concurrently lists
@@ -1717,15 +1951,15 @@ func (cc *Controller) listResources(wg *sync.WaitGroup, rs []string, rc chan res
 	_wg.Add(len(rs))
 	// List all required resources concurrently.
 	for _, r := range rs {
-		go list(_wg, rc, provider, r, applications)
+		go cc.list(_wg, rc, provider, r, applications)
 	}
 	// Wait for the calls to finish.
 	_wg.Wait()
 }
 
 // list lists a given resource and send to a channel of unstructured.Unstructured.
-// It uses a context with a timeout of 10 seconds.
-func list(wg *sync.WaitGroup, rc chan resource,
+// It uses a context with a timeout of 10 seconds, unless the resource is cached.
+func (cc *Controller) list(wg *sync.WaitGroup, rc chan resource,
 	provider *kubernetes.Provider, r string, applications []string) {
 	// Finish the wait group when we're done here.
 	defer wg.Done()
@@ -1741,16 +1975,25 @@ func list(wg *sync.WaitGroup, rc chan resource,
 	var items []unstructured.Unstructured
 
 	if len(provider.Namespaces) == 0 {
-		ul, err := provider.Client.ListResourceWithContext(ctx, r, lo)
-		if err != nil {
-			clouddriver.Log(err)
-			return
-		}
+		if cached, ok := cc.cachedList(provider, r, "", true); ok {
+			items = append(items, cached...)
+		} else {
+			ul, err := provider.Client.ListResourceWithContext(ctx, r, lo)
+			if err != nil {
+				clouddriver.Log(err)
+				return
+			}
 
-		items = append(items, ul.Items...)
+			items = append(items, ul.Items...)
+		}
 	}
 
 	for _, ns := range provider.Namespaces {
+		if cached, ok := cc.cachedList(provider, r, ns, true); ok {
+			items = append(items, cached...)
+			continue
+		}
+
 		ul, err := provider.Client.ListResourcesByKindAndNamespaceWithContext(ctx, r, ns, lo)
 		if err != nil {
 			clouddriver.Log(err)
@@ -6,10 +6,15 @@ import (
 	"log"
 	"net/http"
 
+	"github.com/homedepot/go-clouddriver/internal/fiat"
 	"github.com/homedepot/go-clouddriver/internal/kubernetes"
+	clouddriver "github.com/homedepot/go-clouddriver/pkg"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 var _ = Describe("Application", func() {
@@ -626,6 +631,75 @@ var _ = Describe("Application", func() {
 			})
 		})
 
+		When("the resource cache is enabled", func() {
+			BeforeEach(func() {
+				internalController.KubernetesResourceCache = fakeResourceCache
+				fakeKubeClient.GVRForKindStub = func(kind string) (schema.GroupVersionResource, error) {
+					return schema.GroupVersionResource{Resource: kind}, nil
+				}
+			})
+
+			When("the resources are not cached yet", func() {
+				It("lists the resources and starts watching them", func() {
+					Expect(res.StatusCode).To(Equal(http.StatusOK))
+					validateResponse(payloadServerGroupManagers)
+					Expect(fakeResourceCache.WatchCallCount()).To(Equal(2))
+					account, _, namespace, client := fakeResourceCache.WatchArgsForCall(0)
+					Expect(account).To(Equal("account1"))
+					Expect(namespace).To(BeEmpty())
+					Expect(client).To(Equal(fakeKubeClient))
+				})
+			})
+
+			When("the resources are already watched", func() {
+				BeforeEach(func() {
+					fakeResourceCache.WatchingReturns(true)
+				})
+
+				It("does not watch them again", func() {
+					Expect(res.StatusCode).To(Equal(http.StatusOK))
+					Expect(fakeResourceCache.WatchCallCount()).To(BeZero())
+				})
+			})
+
+			When("the resources are cached", func() {
+				BeforeEach(func() {
+					fakeResourceCache.ListStub = func(_ string, gvr schema.GroupVersionResource,
+						_ string) ([]unstructured.Unstructured, bool) {
+						if gvr.Resource != "deployments" {
+							return []unstructured.Unstructured{}, true
+						}
+
+						return []unstructured.Unstructured{
+							{
+								Object: map[string]interface{}{
+									"kind":       "Deployment",
+									"apiVersion": "apps/v1",
+									"metadata": map[string]interface{}{
+										"name":      "test-cached-deployment",
+										"namespace": "test-namespace1",
+										"annotations": map[string]interface{}{
+											"moniker.spinnaker.io/application": "test-application",
+										},
+										"labels": map[string]interface{}{
+											kubernetes.LabelKubernetesManagedBy: "spinnaker",
+										},
+									},
+								},
+							},
+						}, true
+					}
+				})
+
+				It("returns the cached resources", func() {
+					Expect(res.StatusCode).To(Equal(http.StatusOK))
+					Expect(fakeKubeClient.ListResourceWithContextCallCount()).To(BeZero())
+					b, _ := io.ReadAll(res.Body)
+					Expect(string(b)).To(ContainSubstring("test-cached-deployment"))
+				})
+			})
+		})
+
 		When("it succeeds", func() {
 			It("succeeds", func() {
 				Expect(res.StatusCode).To(Equal(http.StatusOK))
@@ -4062,6 +4136,46 @@ var _ = Describe("Application", func() {
 					},
 				},
 			}, nil)
+			fakeKubeClient.ListResourcesByKindAndNamespaceReturns(&unstructured.UnstructuredList{
+				Items: []unstructured.Unstructured{
+					{
+						Object: map[string]interface{}{
+							"metadata": map[string]interface{}{
+								"name":              "test-job1-def34",
+								"namespace":         "test-namespace",
+								"creationTimestamp": "2020-02-13T14:14:03Z",
+							},
+							"status": map[string]interface{}{
+								"phase": "Running",
+							},
+						},
+					},
+					{
+						Object: map[string]interface{}{
+							"metadata": map[string]interface{}{
+								"name":              "test-job1-abc12",
+								"namespace":         "test-namespace",
+								"creationTimestamp": "2020-02-13T14:12:03Z",
+							},
+							"status": map[string]interface{}{
+								"phase": "Failed",
+								"containerStatuses": []interface{}{
+									map[string]interface{}{
+										"name": "test-container",
+										"state": map[string]interface{}{
+											"terminated": map[string]interface{}{
+												"exitCode": int64(2),
+												"reason":   "Error",
+												"message":  "test-message",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}, nil)
 			log.SetOutput(io.Discard)
 		})
 
@@ -4116,19 +4230,222 @@ var _ = Describe("Application", func() {
 			})
 		})
 
-		When("it succeeds", func() {
+		When("listing the pods of the job returns an error", func() {
+			BeforeEach(func() {
+				fakeKubeClient.ListResourcesByKindAndNamespaceReturns(nil, errors.New("error listing pods"))
+			})
+
+			It("returns an error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
+				ce := getClouddriverError()
+				Expect(ce.Error).To(HavePrefix("Internal Server Error"))
+				Expect(ce.Message).To(Equal("error listing pods of job test-job1: error listing pods"))
+				Expect(ce.Status).To(Equal(http.StatusInternalServerError))
+			})
+		})
+
+		When("the job has no pods", func() {
+			BeforeEach(func() {
+				fakeKubeClient.ListResourcesByKindAndNamespaceReturns(&unstructured.UnstructuredList{}, nil)
+			})
+
 			It("succeeds", func() {
 				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				validateResponse(payloadGetJobNoPods)
+			})
+		})
+
+		When("the job failed", func() {
+			BeforeEach(func() {
+				fakeKubeClient.GetReturns(&unstructured.Unstructured{
+					Object: map[string]interface{}{
+						"kind":       "Job",
+						"apiVersion": "batch/v1",
+						"metadata": map[string]interface{}{
+							"name":              "test-job1",
+							"namespace":         "test-namespace",
+							"creationTimestamp": "2020-02-13T14:12:03Z",
+						},
+						"spec": map[string]interface{}{
+							"selector": map[string]interface{}{
+								"matchLabels": map[string]interface{}{
+									"controller-uid": "test-uid",
+								},
+							},
+						},
+						"status": map[string]interface{}{
+							"completionTime": "2020-02-13T14:16:03Z",
+							"conditions": []interface{}{
+								map[string]interface{}{
+									"type":    "Failed",
+									"status":  "True",
+									"reason":  "BackoffLimitExceeded",
+									"message": "Job has reached the specified backoff limit",
+								},
+							},
+						},
+					},
+				}, nil)
+			})
+
+			It("returns the completion details of the failed container", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				_, _, lo := fakeKubeClient.ListResourcesByKindAndNamespaceArgsForCall(0)
+				Expect(lo.LabelSelector).To(Equal("controller-uid=test-uid"))
+				validateResponse(payloadGetJobFailed)
+			})
+		})
+
+		When("it succeeds", func() {
+			It("lists the pods of the job and succeeds", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				kind, namespace, lo := fakeKubeClient.ListResourcesByKindAndNamespaceArgsForCall(0)
+				Expect(kind).To(Equal("pod"))
+				Expect(namespace).To(Equal("test-namespace"))
+				Expect(lo.LabelSelector).To(Equal("job-name=test-job1"))
 				validateResponse(payloadGetJob)
 			})
 		})
 	})
 
+	Describe("#GetJobFileContents", func() {
+		BeforeEach(func() {
+			setup()
+			uri = svr.URL + "/applications/test-application/jobs/test-account/test-namespace/job test-job1/test-container"
+			createRequest(http.MethodGet)
+			fakeKubeClient.ListResourcesByKindAndNamespaceReturns(&unstructured.UnstructuredList{
+				Items: []unstructured.Unstructured{
+					{
+						Object: map[string]interface{}{
+							"metadata": map[string]interface{}{
+								"name":              "test-job1-def34",
+								"namespace":         "test-namespace",
+								"creationTimestamp": "2020-02-13T14:14:03Z",
+							},
+						},
+					},
+					{
+						Object: map[string]interface{}{
+							"metadata": map[string]interface{}{
+								"name":              "test-job1-abc12",
+								"namespace":         "test-namespace",
+								"creationTimestamp": "2020-02-13T14:12:03Z",
+							},
+						},
+					},
+				},
+			}, nil)
+			fakeKubeClientset.PodLogsReturns("starting job\n"+
+				"SPINNAKER_PROPERTY_IMAGE_TAG=v1.2.3\n"+
+				"  SPINNAKER_CONFIG_JSON={\"replicas\": 3, \"image_tag\": \"v1.2.4\"}\n"+
+				"done\n", nil)
+			log.SetOutput(io.Discard)
+		})
+
+		AfterEach(func() {
+			teardown()
+		})
+
+		JustBeforeEach(func() {
+			doRequest()
+		})
+
+		When("name parameter is malformed", func() {
+			BeforeEach(func() {
+				uri = svr.URL + "/applications/test-application/jobs/test-account/test-namespace/invalid/test-container"
+				createRequest(http.MethodGet)
+			})
+
+			It("returns an error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+				ce := getClouddriverError()
+				Expect(ce.Error).To(HavePrefix("Bad Request"))
+				Expect(ce.Message).To(Equal("name parameter must be in the format of 'kind name', got: invalid"))
+			})
+		})
+
+		When("getting the resource returns an error", func() {
+			BeforeEach(func() {
+				fakeKubeClient.GetReturns(nil, errors.New("error getting resource"))
+			})
+
+			It("returns an error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
+				ce := getClouddriverError()
+				Expect(ce.Message).To(Equal("error getting resource"))
+			})
+		})
+
+		When("the job has no pods", func() {
+			BeforeEach(func() {
+				fakeKubeClient.ListResourcesByKindAndNamespaceReturns(&unstructured.UnstructuredList{}, nil)
+			})
+
+			It("returns status not found", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusNotFound))
+				ce := getClouddriverError()
+				Expect(ce.Message).To(Equal("no pods found for job test-job1"))
+			})
+		})
+
+		When("getting the logs returns an error", func() {
+			BeforeEach(func() {
+				fakeKubeClientset.PodLogsReturns("", errors.New("error getting logs"))
+			})
+
+			It("returns an error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
+				ce := getClouddriverError()
+				Expect(ce.Message).To(Equal("error getting logs"))
+			})
+		})
+
+		When("the config JSON is invalid", func() {
+			BeforeEach(func() {
+				fakeKubeClientset.PodLogsReturns("SPINNAKER_CONFIG_JSON={", nil)
+			})
+
+			It("returns an error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
+				ce := getClouddriverError()
+				Expect(ce.Message).To(HavePrefix("error parsing SPINNAKER_CONFIG_JSON: "))
+			})
+		})
+
+		When("it succeeds", func() {
+			It("returns the properties output by the container of the most recent pod", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				name, namespace, container := fakeKubeClientset.PodLogsArgsForCall(0)
+				Expect(name).To(Equal("test-job1-def34"))
+				Expect(namespace).To(Equal("test-namespace"))
+				Expect(container).To(Equal("test-container"))
+				validateResponse(`{"image_tag": "v1.2.4", "replicas": 3}`)
+			})
+		})
+	})
+
 	Describe("#DeleteJob", func() {
 		BeforeEach(func() {
 			setup()
 			uri = svr.URL + "/applications/test-application/jobs/test-account/test-namespace/job test-job1"
 			createRequest(http.MethodDelete)
+			fakeKubeClient.GVRForKindReturns(schema.GroupVersionResource{
+				Group:    "batch",
+				Version:  "v1",
+				Resource: "jobs",
+			}, nil)
+			fakeKubeClient.GetReturns(&unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"kind": "Job",
+					"metadata": map[string]interface{}{
+						"name":      "test-job1",
+						"namespace": "test-namespace",
+						"annotations": map[string]interface{}{
+							kubernetes.AnnotationSpinnakerMonikerApplication: "test-application",
+						},
+					},
+				},
+			}, nil)
 			log.SetOutput(io.Discard)
 		})
 
@@ -4140,12 +4457,232 @@ var _ = Describe("Application", func() {
 			doRequest()
 		})
 
-		It("returns an error", func() {
-			Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
-			ce := getClouddriverError()
-			Expect(ce.Error).To(HavePrefix("Internal Server Error"))
-			Expect(ce.Message).To(Equal("cancelJob is not implemented for the Kubernetes provider"))
-			Expect(ce.Status).To(Equal(http.StatusInternalServerError))
+		When("the user cannot write to the account", func() {
+			BeforeEach(func() {
+				fakeResp := fiat.Response{}
+				fakeResp.Accounts = []fiat.Account{
+					{
+						Name:           "test-account",
+						Authorizations: []string{"READ"},
+					},
+				}
+				fakeFiatClient.AuthorizeReturns(fakeResp, nil)
+				req.Header.Set("X-Spinnaker-User", "test-user")
+			})
+
+			It("returns status forbidden", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusForbidden))
+				ce := getClouddriverError()
+				Expect(ce.Message).To(Equal("access denied to account test-account - required authorization: WRITE"))
+				Expect(fakeKubeClient.DeleteResourceByKindAndNameAndNamespaceCallCount()).To(BeZero())
+			})
+		})
+
+		When("name parameter is malformed", func() {
+			BeforeEach(func() {
+				uri = svr.URL + "/applications/test-application/jobs/test-account/test-namespace/invalid"
+				createRequest(http.MethodDelete)
+			})
+
+			It("returns an error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+				ce := getClouddriverError()
+				Expect(ce.Error).To(HavePrefix("Bad Request"))
+				Expect(ce.Message).To(Equal("name parameter must be in the format of 'kind name', got: invalid"))
+			})
+		})
+
+		When("getting the provider returns an error", func() {
+			BeforeEach(func() {
+				fakeSQLClient.GetKubernetesProviderReturns(kubernetes.Provider{}, errors.New("error getting provider"))
+			})
+
+			It("returns an error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+				ce := getClouddriverError()
+				Expect(ce.Message).To(Equal("internal: error getting kubernetes provider test-account: error getting provider"))
+			})
+		})
+
+		When("the account cannot access the namespace", func() {
+			BeforeEach(func() {
+				fakeSQLClient.GetKubernetesProviderReturns(kubernetes.Provider{
+					Name:       "test-account",
+					Namespaces: []string{"other-namespace"},
+				}, nil)
+			})
+
+			It("returns an error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(fakeKubeClient.DeleteResourceByKindAndNameAndNamespaceCallCount()).To(BeZero())
+			})
+		})
+
+		When("the kind is not a job", func() {
+			BeforeEach(func() {
+				uri = svr.URL + "/applications/test-application/jobs/test-account/test-namespace/deployment test-deployment1"
+				createRequest(http.MethodDelete)
+			})
+
+			It("returns an error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+				ce := getClouddriverError()
+				Expect(ce.Message).To(Equal("kind must be job, got: deployment"))
+				Expect(fakeKubeClient.DeleteResourceByKindAndNameAndNamespaceCallCount()).To(BeZero())
+			})
+		})
+
+		When("getting the job returns not found", func() {
+			BeforeEach(func() {
+				fakeKubeClient.GetReturns(nil, k8serrors.NewNotFound(schema.GroupResource{
+					Group:    "batch",
+					Resource: "jobs",
+				}, "test-job1"))
+			})
+
+			It("returns status not found", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusNotFound))
+				Expect(fakeKubeClient.DeleteResourceByKindAndNameAndNamespaceCallCount()).To(BeZero())
+			})
+		})
+
+		When("getting the job returns an error", func() {
+			BeforeEach(func() {
+				fakeKubeClient.GetReturns(nil, errors.New("error getting job"))
+			})
+
+			It("returns an error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
+				ce := getClouddriverError()
+				Expect(ce.Message).To(Equal("error getting job"))
+				Expect(fakeKubeClient.DeleteResourceByKindAndNameAndNamespaceCallCount()).To(BeZero())
+			})
+		})
+
+		When("the job is in another application", func() {
+			BeforeEach(func() {
+				fakeKubeClient.GetReturns(&unstructured.Unstructured{
+					Object: map[string]interface{}{
+						"kind": "Job",
+						"metadata": map[string]interface{}{
+							"name": "test-job1",
+							"annotations": map[string]interface{}{
+								kubernetes.AnnotationSpinnakerMonikerApplication: "other-application",
+							},
+						},
+					},
+				}, nil)
+			})
+
+			It("returns status forbidden", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusForbidden))
+				ce := getClouddriverError()
+				Expect(ce.Message).To(Equal("job test-job1 is not in application test-application"))
+				Expect(fakeKubeClient.DeleteResourceByKindAndNameAndNamespaceCallCount()).To(BeZero())
+			})
+		})
+
+		When("the application annotation is quoted", func() {
+			BeforeEach(func() {
+				fakeKubeClient.GetReturns(&unstructured.Unstructured{
+					Object: map[string]interface{}{
+						"kind": "Job",
+						"metadata": map[string]interface{}{
+							"name": "test-job1",
+							"annotations": map[string]interface{}{
+								kubernetes.AnnotationSpinnakerMonikerApplication: `"test-application"`,
+							},
+						},
+					},
+				}, nil)
+			})
+
+			It("deletes the job", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				Expect(fakeKubeClient.DeleteResourceByKindAndNameAndNamespaceCallCount()).To(Equal(1))
+			})
+		})
+
+		When("getting the gvr returns an error", func() {
+			BeforeEach(func() {
+				fakeKubeClient.GVRForKindReturns(schema.GroupVersionResource{}, errors.New("error getting gvr"))
+			})
+
+			It("returns an error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
+				ce := getClouddriverError()
+				Expect(ce.Message).To(Equal("error getting gvr"))
+			})
+		})
+
+		When("the job is not found", func() {
+			BeforeEach(func() {
+				fakeKubeClient.DeleteResourceByKindAndNameAndNamespaceReturns(k8serrors.NewNotFound(schema.GroupResource{
+					Group:    "batch",
+					Resource: "jobs",
+				}, "test-job1"))
+			})
+
+			It("returns status not found", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusNotFound))
+				ce := getClouddriverError()
+				Expect(ce.Message).To(Equal(`jobs.batch "test-job1" not found`))
+				Expect(fakeSQLClient.CreateKubernetesResourceCallCount()).To(BeZero())
+			})
+		})
+
+		When("deleting the job returns an error", func() {
+			BeforeEach(func() {
+				fakeKubeClient.DeleteResourceByKindAndNameAndNamespaceReturns(errors.New("error deleting job"))
+			})
+
+			It("returns an error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
+				ce := getClouddriverError()
+				Expect(ce.Message).To(Equal("error deleting job"))
+			})
+		})
+
+		When("recording the resource returns an error", func() {
+			BeforeEach(func() {
+				fakeSQLClient.CreateKubernetesResourceReturns(errors.New("error creating resource"))
+			})
+
+			It("returns an error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
+				ce := getClouddriverError()
+				Expect(ce.Message).To(Equal("error creating resource"))
+			})
+		})
+
+		When("it succeeds", func() {
+			It("deletes the job with foreground propagation and records a delete task", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+				Expect(fakeKubeClient.GetCallCount()).To(Equal(1))
+
+				kind, name, namespace, do := fakeKubeClient.DeleteResourceByKindAndNameAndNamespaceArgsForCall(0)
+				Expect(kind).To(Equal("job"))
+				Expect(name).To(Equal("test-job1"))
+				Expect(namespace).To(Equal("test-namespace"))
+				Expect(*do.PropagationPolicy).To(Equal(metav1.DeletePropagationForeground))
+
+				Expect(fakeSQLClient.CreateKubernetesResourceCallCount()).To(Equal(1))
+				kr := fakeSQLClient.CreateKubernetesResourceArgsForCall(0)
+				Expect(kr.AccountName).To(Equal("test-account"))
+				Expect(kr.TaskID).ToNot(BeEmpty())
+				Expect(kr.TaskType).To(Equal(clouddriver.TaskTypeDelete))
+				Expect(kr.APIGroup).To(Equal("batch"))
+				Expect(kr.Resource).To(Equal("jobs"))
+				Expect(kr.Version).To(Equal("v1"))
+				Expect(kr.Kind).To(Equal("job"))
+				Expect(kr.Name).To(Equal("test-job1"))
+				Expect(kr.Namespace).To(Equal("test-namespace"))
+				Expect(kr.SpinnakerApp).To(Equal("test-application"))
+
+				b, _ := io.ReadAll(res.Body)
+				Expect(b).To(MatchJSON(`{"id": "` + kr.TaskID + `", "resourceUri": "/task/` + kr.TaskID + `"}`))
+			})
 		})
 	})
 })
@@ -0,0 +1,219 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/homedepot/go-clouddriver/internal"
+	"github.com/homedepot/go-clouddriver/internal/kubernetes"
+	clouddriver "github.com/homedepot/go-clouddriver/pkg"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// ManifestCacheRefreshRequest is the payload Orca sends to refresh
+// a manifest, such as 'deployment my-deployment', after operating on it.
+type ManifestCacheRefreshRequest struct {
+	Account  string `json:"account"`
+	Location string `json:"location"`
+	Name     string `json:"name"`
+}
+
+// RefreshManifestCache refreshes the cached state of a manifest. It invalidates
+// the account's cached API discovery, reads the live object and reconciles its
+// entries in the resources table, removing them if the object no longer exists.
+// Entries recorded by delete, cleanup and no-op tasks are left untouched.
+//
+// Refreshes are processed before responding (200 OK). A refresh that fails,
+// for example as the cluster cannot be reached, is reported as pending
+// (202 Accepted) like in OSS Clouddriver. As refreshes are never queued, Orca
+// finds no pending refreshes when polling for them and requests the refresh again.
+//
+// See https://github.com/spinnaker/clouddriver/blob/master/clouddriver-web/src/main/groovy/com/netflix/spinnaker/clouddriver/controllers/CacheController.groovy.
+func (cc *Controller) RefreshManifestCache(c *gin.Context) {
+	mcr := ManifestCacheRefreshRequest{}
+
+	// The body may have already been read by the account authorization middleware.
+	err := c.ShouldBindBodyWith(&mcr, binding.JSON)
+	if err != nil {
+		clouddriver.Error(c, http.StatusBadRequest, err)
+		return
+	}
+
+	a := strings.Split(mcr.Name, " ")
+	if len(a) != 2 {
+		clouddriver.Error(c, http.StatusBadRequest, errors.New("manifest name must be in format '{kind} {name}'"))
+		return
+	}
+
+	kind := a[0]
+	name := a[1]
+	namespace := mcr.Location
+
+	provider, err := cc.KubernetesProviderWithTimeout(mcr.Account, time.Second*internal.DefaultListTimeoutSeconds)
+	if err != nil {
+		pendingRefresh(c, mcr, err)
+		return
+	}
+
+	// Preserve backwards compatibility
+	if len(provider.Namespaces) == 1 {
+		namespace = provider.Namespaces[0]
+	}
+
+	// The resource caches are kept up to date by their watches,
+	// so only discovery can be stale, for example after a CRD is deployed.
+	provider.Client.InvalidateDiscovery()
+
+	gvr, err := provider.Client.GVRForKind(kind)
+	if err != nil {
+		pendingRefresh(c, mcr, err)
+		return
+	}
+
+	// Entries recorded after the object is read may be
+	// for an object created since, so they are never removed.
+	since := internal.CurrentTimeUTC()
+	live := []kubernetes.Resource{}
+	cachedIdentifiersByType := map[string][]string{}
+
+	u, err := provider.Client.Get(kind, name, namespace)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		pendingRefresh(c, mcr, err)
+		return
+	}
+
+	if err == nil && len(filterManagedBySpinnaker([]unstructured.Unstructured{*u})) == 1 {
+		live = append(live, kubernetes.NewResource(provider.Name, gvr, *u))
+		t := lowercaseFirst(u.GetKind())
+		cachedIdentifiersByType[t] = []string{
+			fmt.Sprintf("kubernetes.v2:infrastructure:%s:%s:%s:%s", t, provider.Name, u.GetNamespace(), u.GetName()),
+		}
+	}
+
+	recorded, err := cc.SQLClient.ListKubernetesResourcesByName(provider.Name, gvr.Resource, namespace, name)
+	if err != nil {
+		pendingRefresh(c, mcr, err)
+		return
+	}
+
+	_, err = cc.ReconcileKubernetesResources(provider.Name, recorded, live, since)
+	if err != nil {
+		pendingRefresh(c, mcr, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"cachedIdentifiersByType": cachedIdentifiersByType})
+}
+
+// pendingRefresh logs why a refresh failed and reports it as pending.
+func pendingRefresh(c *gin.Context, mcr ManifestCacheRefreshRequest, err error) {
+	clouddriver.Log(fmt.Errorf("error refreshing manifest %s in account %s: %w", mcr.Name, mcr.Account, err))
+	c.JSON(http.StatusAccepted, gin.H{"cachedIdentifiersByType": map[string][]string{}})
+}
+
+// ListPendingManifestCacheRefreshes lists the manifest refreshes that have not
+// been processed yet, which Orca polls after a refresh is reported as pending.
+// Refreshes are processed or fail before responding, so none are ever pending.
+func (cc *Controller) ListPendingManifestCacheRefreshes(c *gin.Context) {
+	c.JSON(http.StatusOK, []interface{}{})
+}
+
+// cachedList returns the objects of a resource in a provider's namespace, or in
+// all namespaces if the namespace is empty, from the resource cache. If watch is
+// true and the resource is not cached yet, it starts watching the resource.
+//
+// It returns false if there is no cache or it has not synced yet,
+// in which case the resource should be listed from the cluster.
+func (cc *Controller) cachedList(provider *kubernetes.Provider, resource, namespace string,
+	watch bool) ([]unstructured.Unstructured, bool) {
+	if cc.KubernetesResourceCache == nil {
+		return nil, false
+	}
+
+	gvr, err := provider.Client.GVRForKind(resource)
+	if err != nil {
+		return nil, false
+	}
+
+	if items, ok := cc.KubernetesResourceCache.List(provider.Name, gvr, namespace); ok {
+		return items, true
+	}
+
+	if watch && !cc.KubernetesResourceCache.Watching(provider.Name, gvr, namespace) {
+		// Watch using a client without a timeout, as the timeout
+		// of the provider's client would end each watch early.
+		p, err := cc.KubernetesProvider(provider.Name)
+		if err != nil {
+			clouddriver.Log(err)
+			return nil, false
+		}
+
+		cc.KubernetesResourceCache.Watch(provider.Name, gvr, namespace, p.Client)
+	}
+
+	return nil, false
+}
+
+// listManaged lists the objects of a kind managed by Spinnaker in a provider's
+// namespaces, reading from the resource cache if the kind is already cached
+// for the provider.
+func (cc *Controller) listManaged(provider *kubernetes.Provider, kind string) ([]unstructured.Unstructured, error) {
+	namespaces := provider.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{""}
+	}
+
+	items := []unstructured.Unstructured{}
+
+	for _, namespace := range namespaces {
+		if cached, ok := cc.cachedList(provider, kind, namespace, false); ok {
+			items = append(items, cached...)
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*internal.DefaultListTimeoutSeconds)
+		lo := metav1.ListOptions{
+			LabelSelector: kubernetes.DefaultLabelSelector(),
+		}
+
+		ul, err := provider.Client.ListResourcesByKindAndNamespaceWithContext(ctx, kind, namespace, lo)
+
+		cancel()
+
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, ul.Items...)
+	}
+
+	return items, nil
+}
+
+// filterManagedBySpinnaker returns the objects that match the default label selector,
+// for objects read by name, which the cluster does not filter by label.
+func filterManagedBySpinnaker(items []unstructured.Unstructured) []unstructured.Unstructured {
+	selector, err := labels.Parse(kubernetes.DefaultLabelSelector())
+	if err != nil {
+		return items
+	}
+
+	filtered := []unstructured.Unstructured{}
+
+	for _, u := range items {
+		if selector.Matches(labels.Set(u.GetLabels())) {
+			filtered = append(filtered, u)
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,237 @@
+package core_test
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/homedepot/go-clouddriver/internal/fiat"
+	"github.com/homedepot/go-clouddriver/internal/kubernetes"
+	clouddriver "github.com/homedepot/go-clouddriver/pkg"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var _ = Describe("Cache", func() {
+	Describe("#RefreshManifestCache", func() {
+		var deployment *unstructured.Unstructured
+
+		BeforeEach(func() {
+			setup()
+			uri = svr.URL + "/cache/kubernetes/manifest"
+			body.Write([]byte(`{"account":"test-account","location":"test-namespace","name":"deployment test-deployment"}`))
+			createRequest(http.MethodPost)
+
+			deployment = &unstructured.Unstructured{Object: map[string]interface{}{}}
+			deployment.SetKind("Deployment")
+			deployment.SetName("test-deployment")
+			deployment.SetNamespace("test-namespace")
+			deployment.SetLabels(map[string]string{kubernetes.LabelKubernetesManagedBy: "spinnaker"})
+			deployment.SetAnnotations(map[string]string{kubernetes.AnnotationSpinnakerMonikerApplication: "test-application"})
+
+			fakeKubeClient.GetReturns(deployment, nil)
+			fakeKubeClient.GVRForKindReturns(schema.GroupVersionResource{
+				Group:    "apps",
+				Version:  "v1",
+				Resource: "deployments",
+			}, nil)
+		})
+
+		AfterEach(func() {
+			teardown()
+		})
+
+		JustBeforeEach(func() {
+			doRequest()
+		})
+
+		When("the manifest name is invalid", func() {
+			BeforeEach(func() {
+				body.Reset()
+				body.Write([]byte(`{"account":"test-account","location":"test-namespace","name":"test-deployment"}`))
+				createRequest(http.MethodPost)
+			})
+
+			It("returns status bad request", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+				ce := getClouddriverError()
+				Expect(ce.Message).To(Equal("manifest name must be in format '{kind} {name}'"))
+			})
+		})
+
+		When("the user does not have read access to the account", func() {
+			BeforeEach(func() {
+				fakeResp := fiat.Response{}
+				fakeResp.Accounts = []fiat.Account{
+					{
+						Name:           "test-account",
+						Authorizations: []string{"WRITE"},
+					},
+				}
+				fakeFiatClient.AuthorizeReturns(fakeResp, nil)
+				req.Header.Set("X-Spinnaker-User", "test-user")
+			})
+
+			It("returns status forbidden", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusForbidden))
+				ce := getClouddriverError()
+				Expect(ce.Message).To(Equal("access denied to account test-account - required authorization: READ"))
+				Expect(fakeKubeClient.GetCallCount()).To(BeZero())
+			})
+		})
+
+		When("the user has read access to the account", func() {
+			BeforeEach(func() {
+				fakeResp := fiat.Response{}
+				fakeResp.Accounts = []fiat.Account{
+					{
+						Name:           "test-account",
+						Authorizations: []string{"READ"},
+					},
+				}
+				fakeFiatClient.AuthorizeReturns(fakeResp, nil)
+				req.Header.Set("X-Spinnaker-User", "test-user")
+			})
+
+			It("refreshes the manifest", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				Expect(fakeKubeClient.GetCallCount()).To(Equal(1))
+			})
+		})
+
+		When("getting the provider returns an error", func() {
+			BeforeEach(func() {
+				fakeSQLClient.GetKubernetesProviderReturns(kubernetes.Provider{}, errors.New("error getting provider"))
+			})
+
+			It("reports the refresh as pending", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusAccepted))
+				validateResponse(`{"cachedIdentifiersByType":{}}`)
+			})
+		})
+
+		When("getting the manifest returns an error", func() {
+			BeforeEach(func() {
+				fakeKubeClient.GetReturns(nil, errors.New("error getting manifest"))
+			})
+
+			It("reports the refresh as pending", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusAccepted))
+				validateResponse(`{"cachedIdentifiersByType":{}}`)
+				Expect(fakeSQLClient.ListKubernetesResourcesByNameCallCount()).To(BeZero())
+			})
+		})
+
+		When("listing the recorded resources returns an error", func() {
+			BeforeEach(func() {
+				fakeSQLClient.ListKubernetesResourcesByNameReturns(nil, errors.New("error listing resources"))
+			})
+
+			It("reports the refresh as pending", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusAccepted))
+				validateResponse(`{"cachedIdentifiersByType":{}}`)
+			})
+		})
+
+		When("a task recorded an entry for the manifest", func() {
+			BeforeEach(func() {
+				fakeKubeClient.GetReturns(nil, k8serrors.NewNotFound(schema.GroupResource{}, "test-deployment"))
+				fakeSQLClient.ListKubernetesResourcesByNameReturns([]kubernetes.Resource{
+					{ID: "test-id", Resource: "deployments", Namespace: "test-namespace", Name: "test-deployment",
+						TaskType: clouddriver.TaskTypeDelete, Timestamp: time.Now().Add(-time.Hour)},
+				}, nil)
+			})
+
+			It("keeps the entry", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				Expect(fakeSQLClient.DeleteKubernetesResourceCallCount()).To(BeZero())
+			})
+		})
+
+		When("the manifest no longer exists", func() {
+			BeforeEach(func() {
+				fakeKubeClient.GetReturns(nil, k8serrors.NewNotFound(schema.GroupResource{}, "test-deployment"))
+				fakeSQLClient.ListKubernetesResourcesByNameReturns([]kubernetes.Resource{
+					{ID: "test-id", Resource: "deployments", Namespace: "test-namespace", Name: "test-deployment",
+						Timestamp: time.Now().Add(-time.Hour)},
+				}, nil)
+			})
+
+			It("removes its recorded resources", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				Expect(fakeSQLClient.DeleteKubernetesResourceCallCount()).To(Equal(1))
+				Expect(fakeSQLClient.DeleteKubernetesResourceArgsForCall(0)).To(Equal("test-id"))
+				validateResponse(`{"cachedIdentifiersByType":{}}`)
+			})
+		})
+
+		When("the manifest is not recorded", func() {
+			It("records it", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				Expect(fakeSQLClient.CreateKubernetesResourceCallCount()).To(Equal(1))
+				kr := fakeSQLClient.CreateKubernetesResourceArgsForCall(0)
+				Expect(kr.AccountName).To(Equal("test-account"))
+				Expect(kr.Resource).To(Equal("deployments"))
+				Expect(kr.SpinnakerApp).To(Equal("test-application"))
+				Expect(kr.Cluster).To(Equal("deployment test-deployment"))
+			})
+		})
+
+		When("it succeeds", func() {
+			BeforeEach(func() {
+				fakeSQLClient.ListKubernetesResourcesByNameReturns([]kubernetes.Resource{
+					{ID: "test-id", Resource: "deployments", Namespace: "test-namespace", Name: "test-deployment",
+						SpinnakerApp: "test-application", Cluster: "deployment test-deployment"},
+				}, nil)
+			})
+
+			It("refreshes the manifest", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				Expect(fakeKubeClient.InvalidateDiscoveryCallCount()).To(Equal(1))
+				kind, name, namespace := fakeKubeClient.GetArgsForCall(0)
+				Expect(kind).To(Equal("deployment"))
+				Expect(name).To(Equal("test-deployment"))
+				Expect(namespace).To(Equal("test-namespace"))
+				account, resource, namespace, name := fakeSQLClient.ListKubernetesResourcesByNameArgsForCall(0)
+				Expect(account).To(Equal("test-account"))
+				Expect(resource).To(Equal("deployments"))
+				Expect(namespace).To(Equal("test-namespace"))
+				Expect(name).To(Equal("test-deployment"))
+				Expect(fakeSQLClient.CreateKubernetesResourceCallCount()).To(Equal(0))
+				Expect(fakeSQLClient.UpdateKubernetesResourceCallCount()).To(Equal(0))
+				Expect(fakeSQLClient.DeleteKubernetesResourceCallCount()).To(Equal(0))
+				validateResponse(`{
+					"cachedIdentifiersByType": {
+						"deployment": [
+							"kubernetes.v2:infrastructure:deployment:test-account:test-namespace:test-deployment"
+						]
+					}
+				}`)
+			})
+		})
+	})
+
+	Describe("#ListPendingManifestCacheRefreshes", func() {
+		BeforeEach(func() {
+			setup()
+			uri = svr.URL + "/cache/kubernetes/manifest"
+			createRequest(http.MethodGet)
+		})
+
+		AfterEach(func() {
+			teardown()
+		})
+
+		JustBeforeEach(func() {
+			doRequest()
+		})
+
+		It("returns no pending refreshes", func() {
+			Expect(res.StatusCode).To(Equal(http.StatusOK))
+			validateResponse(`[]`)
+		})
+	})
+})
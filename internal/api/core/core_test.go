@@ -50,6 +50,8 @@ var (
 	fakeKubeClient                    *kubernetesfakes.FakeClient
 	fakeKubeClientset                 *kubernetesfakes.FakeClientset
 	fakeKubeController                *kubernetesfakes.FakeController
+	fakeResourceCache                 *kubernetesfakes.FakeResourceCache
+	internalController                *internal.Controller
 	fakeStorageServer                 *fakestorage.Server
 	fakeGithubServer                  *ghttp.Server
 	fakeFileServer                    *ghttp.Server
@@ -220,6 +222,8 @@ func setup() {
 	fakeKubeController.NewClientReturns(fakeKubeClient, nil)
 	fakeKubeController.NewClientsetReturns(fakeKubeClientset, nil)
 
+	fakeResourceCache = &kubernetesfakes.FakeResourceCache{}
+
 	fakeArcadeClient = &arcadefakes.FakeClient{}
 	fakeFiatClient = &fiatfakes.FakeClient{}
 	fakeFront50Client = &front50fakes.FakeClient{}
@@ -285,6 +289,8 @@ func setup() {
 		SQLClient:                     fakeSQLClient,
 		KubernetesController:          fakeKubeController,
 	}
+	// Tests enable the resource cache by setting it on the internal controller.
+	internalController = c
 
 	// Create server.
 	server := api.NewServer(r)
@@ -2,7 +2,7 @@ package core
 
 import (
 	"context"
-	"encoding/base64"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -10,9 +10,9 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/client-go/rest"
 
 	"github.com/homedepot/go-clouddriver/internal/kubernetes"
 	clouddriver "github.com/homedepot/go-clouddriver/pkg"
@@ -87,6 +87,8 @@ func (cc *Controller) ListCredentials(c *gin.Context) {
 			Type:                    "kubernetes",
 		}
 
+		sca.Health = cc.accountHealth(provider.Name)
+
 		if expand == "true" {
 			sca.SpinnakerKindMap = spinnakerKindMap
 			if len(provider.Namespaces) > 0 {
@@ -146,26 +148,24 @@ func (cc *Controller) listNamespaces(provider kubernetes.Provider,
 	defer wg.Done()
 	defer sendToNsChan(accountNamespacesCh, &provider.Name, &namespaces)
 
-	cd, err := base64.StdEncoding.DecodeString(provider.CAData)
-	if err != nil {
-		clouddriver.Log(err)
-		return
+	// Providers listed with their permissions do not include the credentials
+	// of sources other than arcade.
+	if !kubernetes.UsesArcade(provider.TokenProvider) {
+		p, err := cc.SQLClient.GetKubernetesProvider(provider.Name)
+		if err != nil {
+			clouddriver.Log(err)
+			return
+		}
+
+		provider = p
 	}
 
-	token, err := cc.ArcadeClient.Token(provider.TokenProvider)
+	config, err := cc.KubernetesRESTConfig(provider, 0)
 	if err != nil {
 		clouddriver.Log(err)
 		return
 	}
 
-	config := &rest.Config{
-		Host:        provider.Host,
-		BearerToken: token,
-		TLSClientConfig: rest.TLSClientConfig{
-			CAData: cd,
-		},
-	}
-
 	client, err := cc.KubernetesController.NewClient(config)
 	if err != nil {
 		clouddriver.Log(err)
@@ -242,5 +242,47 @@ func (cc *Controller) GetAccountCredentials(c *gin.Context) {
 		Type:                    "kubernetes",
 	}
 
+	credentials.Health = cc.accountHealth(provider.Name)
+
 	c.JSON(http.StatusOK, credentials)
 }
+
+// GetAccountStatus returns the last health check of an account. Accounts that
+// have not been checked yet are checked when requested.
+func (cc *Controller) GetAccountStatus(c *gin.Context) {
+	account := c.Param("account")
+
+	provider, err := cc.SQLClient.GetKubernetesProvider(account)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			clouddriver.Error(c, http.StatusNotFound, fmt.Errorf("account %s not found", account))
+			return
+		}
+
+		clouddriver.Error(c, http.StatusInternalServerError, err)
+
+		return
+	}
+
+	if h := cc.accountHealth(provider.Name); h != nil {
+		c.JSON(http.StatusOK, h)
+		return
+	}
+
+	c.JSON(http.StatusOK, cc.RecordKubernetesAccountHealth(provider))
+}
+
+// accountHealth returns the last health check of an account,
+// or nil if the account has not been checked.
+func (cc *Controller) accountHealth(account string) *clouddriver.AccountHealth {
+	if cc.KubernetesAccountHealth == nil {
+		return nil
+	}
+
+	h, ok := cc.KubernetesAccountHealth.Get(account)
+	if !ok {
+		return nil
+	}
+
+	return &h
+}
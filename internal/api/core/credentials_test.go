@@ -10,6 +10,8 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/homedepot/go-clouddriver/internal/kubernetes"
@@ -426,5 +428,130 @@ var _ = Describe("Credential", func() {
 				validateResponse(payloadGetAccountCredentials)
 			})
 		})
+
+		When("the account has been health checked", func() {
+			BeforeEach(func() {
+				fakeSQLClient.GetKubernetesProviderReturns(kubernetes.Provider{Name: "test-account"}, nil)
+				internalController.KubernetesAccountHealth = kubernetes.NewAccountHealthStore()
+				internalController.KubernetesAccountHealth.Set(clouddriver.AccountHealth{
+					Account: "test-account",
+					Error:   "error getting server version: Unauthorized",
+				})
+			})
+
+			It("includes the health of the account", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				b, _ := io.ReadAll(res.Body)
+				credentials := clouddriver.Credentials{}
+				err := json.Unmarshal(b, &credentials)
+				Expect(err).To(BeNil())
+				Expect(credentials.Health).ToNot(BeNil())
+				Expect(credentials.Health.Reachable).To(BeFalse())
+				Expect(credentials.Health.Error).To(Equal("error getting server version: Unauthorized"))
+			})
+		})
+	})
+
+	Describe("#GetAccountStatus", func() {
+		BeforeEach(func() {
+			setup()
+			uri = svr.URL + "/credentials/test-account/status"
+			createRequest(http.MethodGet)
+			fakeSQLClient.GetKubernetesProviderReturns(kubernetes.Provider{
+				Name:          "test-account",
+				Host:          "test-host",
+				CAData:        "dGVzdAo=",
+				TokenProvider: "test-token-provider",
+			}, nil)
+			fakeKubeClientset.ServerVersionReturns("v1.26.15", nil)
+		})
+
+		AfterEach(func() {
+			teardown()
+		})
+
+		JustBeforeEach(func() {
+			doRequest()
+		})
+
+		When("the provider is not found", func() {
+			BeforeEach(func() {
+				fakeSQLClient.GetKubernetesProviderReturns(kubernetes.Provider{}, gorm.ErrRecordNotFound)
+			})
+
+			It("returns status not found", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusNotFound))
+				ce := getClouddriverError()
+				Expect(ce.Message).To(Equal("account test-account not found"))
+			})
+		})
+
+		When("getting the provider returns an error", func() {
+			BeforeEach(func() {
+				fakeSQLClient.GetKubernetesProviderReturns(kubernetes.Provider{}, errors.New("error getting kubernetes provider"))
+			})
+
+			It("returns an error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
+				ce := getClouddriverError()
+				Expect(ce.Message).To(Equal("error getting kubernetes provider"))
+			})
+		})
+
+		When("the account has been health checked", func() {
+			BeforeEach(func() {
+				internalController.KubernetesAccountHealth = kubernetes.NewAccountHealthStore()
+				internalController.KubernetesAccountHealth.Set(clouddriver.AccountHealth{
+					Account:       "test-account",
+					Reachable:     true,
+					ServerVersion: "v1.25.0",
+					TokenValid:    true,
+				})
+			})
+
+			It("returns the last health check without checking the account", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				h := getAccountHealth()
+				Expect(h.ServerVersion).To(Equal("v1.25.0"))
+				Expect(fakeKubeClientset.ServerVersionCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the account has not been health checked", func() {
+			It("checks the account", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				h := getAccountHealth()
+				Expect(h.Account).To(Equal("test-account"))
+				Expect(h.Reachable).To(BeTrue())
+				Expect(h.TokenValid).To(BeTrue())
+				Expect(h.ServerVersion).To(Equal("v1.26.15"))
+				Expect(h.LastSuccessfulDiscovery).ToNot(BeNil())
+				Expect(h.Error).To(BeEmpty())
+			})
+		})
+
+		When("the token is rejected", func() {
+			BeforeEach(func() {
+				fakeKubeClientset.ServerVersionReturns("", k8serrors.NewUnauthorized("Unauthorized"))
+			})
+
+			It("reports the token as invalid", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				h := getAccountHealth()
+				Expect(h.Reachable).To(BeTrue())
+				Expect(h.TokenValid).To(BeFalse())
+				Expect(h.LastSuccessfulDiscovery).To(BeNil())
+				Expect(h.Error).To(Equal("error getting server version: Unauthorized"))
+			})
+		})
 	})
 })
+
+func getAccountHealth() clouddriver.AccountHealth {
+	b, _ := io.ReadAll(res.Body)
+	h := clouddriver.AccountHealth{}
+	err := json.Unmarshal(b, &h)
+	Expect(err).To(BeNil())
+
+	return h
+}
@@ -39,6 +39,9 @@ var stages = []string{
 	"destroyServerGroup",
 }
 
+// ListStages lists the stages supported by this provider. Each preconfigured
+// job is advertised as an enabled stage named after the job.
+//
 // Expected response:
 //
 // [
@@ -137,7 +140,7 @@ var stages = []string{
 //	}
 //
 // ]
-func ListStages(c *gin.Context) {
+func (cc *Controller) ListStages(c *gin.Context) {
 	response := Stages{}
 
 	for _, stage := range stages {
@@ -148,5 +151,13 @@ func ListStages(c *gin.Context) {
 		response = append(response, s)
 	}
 
+	for _, pj := range cc.PreconfiguredJobs {
+		s := Stage{
+			Enabled: true,
+			Name:    pj.Name,
+		}
+		response = append(response, s)
+	}
+
 	c.JSON(http.StatusOK, response)
 }
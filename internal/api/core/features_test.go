@@ -0,0 +1,62 @@
+package core_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/homedepot/go-clouddriver/internal/api/core"
+	"github.com/homedepot/go-clouddriver/internal/kubernetes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Features", func() {
+	Describe("#ListStages", func() {
+		var stages core.Stages
+
+		BeforeEach(func() {
+			setup()
+			uri = svr.URL + "/features/stages"
+			createRequest(http.MethodGet)
+		})
+
+		JustBeforeEach(func() {
+			doRequest()
+
+			b, _ := io.ReadAll(res.Body)
+			stages = core.Stages{}
+			_ = json.Unmarshal(b, &stages)
+		})
+
+		AfterEach(func() {
+			teardown()
+		})
+
+		When("no preconfigured jobs are registered", func() {
+			It("lists the stages", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				Expect(stages).To(HaveLen(23))
+				Expect(stages).To(ContainElement(core.Stage{Enabled: false, Name: "runJob"}))
+			})
+		})
+
+		When("preconfigured jobs are registered", func() {
+			BeforeEach(func() {
+				internalController.PreconfiguredJobs = kubernetes.PreconfiguredJobs{
+					{Name: "test-job-1"},
+					{Name: "test-job-2"},
+				}
+			})
+
+			It("advertises each job as an enabled stage", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				Expect(stages).To(HaveLen(25))
+				Expect(stages[23:]).To(Equal(core.Stages{
+					{Enabled: true, Name: "test-job-1"},
+					{Enabled: true, Name: "test-job-2"},
+				}))
+			})
+		})
+	})
+})
@@ -1,9 +1,13 @@
 package core
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -12,11 +16,20 @@ import (
 	"github.com/homedepot/go-clouddriver/internal/kubernetes"
 	clouddriver "github.com/homedepot/go-clouddriver/pkg"
 	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 const (
 	defaultGetTimeoutSeconds = 10
+	// maxConsolePods is the number of a workload's newest pods
+	// whose logs are returned as its console.
+	maxConsolePods = 10
+	// maxConsoleLogRequests is the number of container logs
+	// requested from the API server at once for a console.
+	maxConsoleLogRequests = 5
 )
 
 // InstanceRepsponse represents the HTTP response
@@ -180,8 +193,18 @@ type console struct {
 	Output string `json:"output"`
 }
 
+// consoleWorkloadKinds are the kinds other than Pod that have a "console",
+// which is the logs of the pods they own.
+var consoleWorkloadKinds = []string{
+	"deployment",
+	"job",
+	"replicaSet",
+	"statefulSet",
+}
+
 // GetInstanceConsole returns the "console" of an instance. In the case for Kubernetes,
-// a "console" is the logs of a given Pod.
+// a "console" is the logs of a given Pod. For a Deployment, Job, ReplicaSet, or StatefulSet
+// it is the logs of the newest Pods the workload owns, each named '<pod>/<container>'.
 func (cc *Controller) GetInstanceConsole(c *gin.Context) {
 	account := c.Param("account")
 	namespace := c.Param("location")
@@ -205,8 +228,10 @@ func (cc *Controller) GetInstanceConsole(c *gin.Context) {
 		a2 := strings.Split(kind, ".")
 		kind = a2[0]
 	}
-	// If the requested Kubernetes kind is not a Pod, return status not implemented.
-	if !strings.EqualFold(kind, "pod") {
+
+	isPod := strings.EqualFold(kind, "pod")
+	// If the requested Kubernetes kind is not a Pod or a workload, return status not implemented.
+	if !isPod && !containsIgnoreCase(consoleWorkloadKinds, kind) {
 		clouddriver.Error(c, http.StatusNotImplemented, fmt.Errorf("kind %s console not implemented",
 			kind))
 		return
@@ -223,31 +248,66 @@ func (cc *Controller) GetInstanceConsole(c *gin.Context) {
 		clouddriver.Error(c, http.StatusInternalServerError, err)
 		return
 	}
-	// Declare a new pod structure and grab all containers
-	// and init containers from the pod.
-	p := kubernetes.NewPod(instance.Object)
-	o := p.Object()
-	// Combine the containers and init containers into
-	// one object.
-	containers := []v1.Container{}
-	containers = append(containers, o.Spec.Containers...)
-	containers = append(containers, o.Spec.InitContainers...)
+
+	pods := []*unstructured.Unstructured{instance}
+	if !isPod {
+		pods, err = listOwnedPods(provider, instance)
+		if err != nil {
+			clouddriver.Error(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		pods = newestPods(pods, maxConsolePods)
+	}
+	// Grab all containers and init containers from the pods.
+	// The console of a workload names each container after its pod,
+	// as the workload's pods share container names.
+	podContainers := []podContainer{}
+
+	for _, pod := range pods {
+		o := kubernetes.NewPod(pod.Object).Object()
+		containers := []v1.Container{}
+		containers = append(containers, o.Spec.Containers...)
+		containers = append(containers, o.Spec.InitContainers...)
+
+		for _, container := range containers {
+			pc := podContainer{
+				name:      container.Name,
+				pod:       pod,
+				container: container,
+			}
+			if !isPod {
+				pc.name = pod.GetName() + "/" + container.Name
+			}
+
+			podContainers = append(podContainers, pc)
+		}
+	}
 	// Declare a wait group for all the concurrent calls
 	// to make.
 	wg := &sync.WaitGroup{}
 	// Increment the wait group count to the total number of
 	// containers.
-	wg.Add(len(containers))
+	wg.Add(len(podContainers))
 	// Create a channel of console to send to.
-	cCh := make(chan console, len(containers))
+	cCh := make(chan console, len(podContainers))
 	// Grab logs for all containers concurrently. I could not
 	// find a way to grab all logs for all of a pod's containers,
 	// but this works.
 	//
 	// Unlike the dynamic client, the Kubernetes clientset
-	// does not have any hidden mutex locks and can run requests concurrently.
-	for _, container := range containers {
-		go getLogs(wg, cCh, provider.Clientset, instance, container)
+	// does not have any hidden mutex locks and can run requests concurrently,
+	// but limit how many are made at once for workloads with many containers.
+	sem := make(chan struct{}, maxConsoleLogRequests)
+
+	for _, pc := range podContainers {
+		sem <- struct{}{}
+
+		go func(pc podContainer) {
+			defer func() { <-sem }()
+
+			getLogs(wg, cCh, provider.Clientset, pc)
+		}(pc)
 	}
 	// Wait for all concurrent calls to finish.
 	wg.Wait()
@@ -268,10 +328,117 @@ func (cc *Controller) GetInstanceConsole(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"output": consoles})
 }
 
+// podContainer is a container of a pod, named as it is in a console.
+type podContainer struct {
+	name      string
+	pod       *unstructured.Unstructured
+	container v1.Container
+}
+
+// listOwnedPods lists the pods owned by a workload. As when listing server groups,
+// pods are grouped by their owner references, so a pod belongs to the workload when
+// it is owned by the workload or, for a Deployment, by one of its ReplicaSets.
+func listOwnedPods(provider *kubernetes.Provider, workload *unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+	lo := metav1.ListOptions{
+		LabelSelector: podSelector(workload),
+	}
+	owners := []string{string(workload.GetUID())}
+
+	if strings.EqualFold(workload.GetKind(), "deployment") {
+		ul, err := provider.Client.ListResourcesByKindAndNamespace("replicaSet", workload.GetNamespace(), lo)
+		if err != nil {
+			return nil, fmt.Errorf("error listing replica sets of deployment %s: %w", workload.GetName(), err)
+		}
+
+		owners = []string{}
+		if ul == nil {
+			return []*unstructured.Unstructured{}, nil
+		}
+
+		for _, rs := range ul.Items {
+			for _, ownerReference := range rs.GetOwnerReferences() {
+				if ownerReference.UID == workload.GetUID() {
+					owners = append(owners, string(rs.GetUID()))
+				}
+			}
+		}
+	}
+
+	ul, err := provider.Client.ListResourcesByKindAndNamespace("pod", workload.GetNamespace(), lo)
+	if err != nil {
+		return nil, fmt.Errorf("error listing pods of %s %s: %w", lowercaseFirst(workload.GetKind()), workload.GetName(), err)
+	}
+
+	pods := []*unstructured.Unstructured{}
+	if ul == nil {
+		return pods, nil
+	}
+
+	resources := []resource{}
+	podsByUID := map[string]*unstructured.Unstructured{}
+
+	for i := range ul.Items {
+		resources = append(resources, resource{u: ul.Items[i]})
+		podsByUID[string(ul.Items[i].GetUID())] = &ul.Items[i]
+	}
+
+	serverGroupMap := makeServerGroupMap(resources)
+
+	for _, owner := range owners {
+		for _, instance := range serverGroupMap[owner] {
+			pods = append(pods, podsByUID[instance.ID])
+		}
+	}
+
+	return pods, nil
+}
+
+// newestPods returns at most max pods, keeping the most recently created.
+func newestPods(pods []*unstructured.Unstructured, max int) []*unstructured.Unstructured {
+	if len(pods) <= max {
+		return pods
+	}
+
+	sort.SliceStable(pods, func(i, j int) bool {
+		ti := pods[i].GetCreationTimestamp()
+		tj := pods[j].GetCreationTimestamp()
+
+		return tj.Before(&ti)
+	})
+
+	return pods[:max]
+}
+
+// podSelector returns the label selector of the pods of a workload,
+// or an empty selector, selecting all pods, if it has none.
+func podSelector(workload *unstructured.Unstructured) string {
+	if strings.EqualFold(workload.GetKind(), "job") {
+		return kubernetes.NewJob(workload.Object).PodSelector()
+	}
+
+	m, found, err := unstructured.NestedMap(workload.Object, "spec", "selector")
+	if err != nil || !found {
+		return ""
+	}
+
+	ls := metav1.LabelSelector{}
+
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(m, &ls)
+	if err != nil {
+		return ""
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(&ls)
+	if err != nil {
+		return ""
+	}
+
+	return selector.String()
+}
+
 // getLogs grabs the logs from a given Pod container and sends them
 // to a channel of logs.
-func getLogs(wg *sync.WaitGroup, cc chan console, clientset kubernetes.Clientset,
-	pod *unstructured.Unstructured, container v1.Container) {
+func getLogs(wg *sync.WaitGroup, cc chan console, clientset kubernetes.Clientset, pc podContainer) {
 	defer wg.Done()
 
 	// This make a call to the following endpoint on the Kubernetes API server:
@@ -280,12 +447,142 @@ func getLogs(wg *sync.WaitGroup, cc chan console, clientset kubernetes.Clientset
 	//
 	// Since this is a direct call and does not need to do API discovery, it is safe
 	// to run this call concurrently.
-	output, err := clientset.PodLogs(pod.GetName(), pod.GetNamespace(), container.Name)
+	output, err := clientset.PodLogs(pc.pod.GetName(), pc.pod.GetNamespace(), pc.container.Name)
 	if err != nil {
 		// If there was an error, log and return.
 		clouddriver.Log(err)
 		return
 	}
 
-	cc <- console{Name: container.Name, Output: output}
+	cc <- console{Name: pc.name, Output: output}
+}
+
+// StreamInstanceLogs streams the logs of a container of an instance,
+// which for Kubernetes is a Pod, as chunked plain text. The logs are
+// selected by the following query parameters:
+//
+//   - container: the container to stream, required if the pod has more than one
+//   - follow: keep streaming new logs until the pod stops or the client disconnects
+//   - previous: stream the logs of the previous, such as crashed, instance of the container
+//   - sinceSeconds: only stream logs newer than this many seconds
+//   - tailLines: the number of previous lines to stream, 10000 by default
+func (cc *Controller) StreamInstanceLogs(c *gin.Context) {
+	account := c.Param("account")
+	namespace := c.Param("location")
+	a := strings.Split(c.Param("name"), " ")
+
+	if len(a) != 2 {
+		clouddriver.Error(c, http.StatusBadRequest, fmt.Errorf("name parameter must be in the format of 'kind name', got: %s", c.Param("name")))
+		return
+	}
+
+	kind := a[0]
+	name := a[1]
+
+	if !strings.EqualFold(kind, "pod") {
+		clouddriver.Error(c, http.StatusNotImplemented, fmt.Errorf("kind %s logs not implemented", kind))
+		return
+	}
+
+	options, err := podLogOptions(c)
+	if err != nil {
+		clouddriver.Error(c, http.StatusBadRequest, err)
+		return
+	}
+
+	// Streams must not time out, so the provider is created without a timeout.
+	provider, err := cc.KubernetesProvider(account)
+	if err != nil {
+		clouddriver.Error(c, http.StatusBadRequest, err)
+		return
+	}
+
+	err = provider.ValidateNamespaceAccess(namespace)
+	if err != nil {
+		clouddriver.Error(c, http.StatusBadRequest, err)
+		return
+	}
+
+	// The stream is closed when the client disconnects, canceling the request's context.
+	stream, err := provider.Clientset.StreamPodLogs(c.Request.Context(), name, namespace, options)
+	if err != nil {
+		switch {
+		case k8serrors.IsNotFound(err):
+			clouddriver.Error(c, http.StatusNotFound, err)
+		case k8serrors.IsBadRequest(err):
+			clouddriver.Error(c, http.StatusBadRequest, err)
+		default:
+			clouddriver.Error(c, http.StatusInternalServerError, err)
+		}
+
+		return
+	}
+	defer stream.Close()
+
+	c.Header("Content-Type", "text/plain; charset=utf-8")
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Status(http.StatusOK)
+
+	r := bufio.NewReader(stream)
+
+	// Flush each line to the client as soon as it is read.
+	c.Stream(func(w io.Writer) bool {
+		line, err := r.ReadBytes('\n')
+		if len(line) > 0 {
+			_, _ = w.Write(line)
+		}
+
+		if err != nil {
+			if !errors.Is(err, io.EOF) && c.Request.Context().Err() == nil {
+				clouddriver.Log(fmt.Errorf("error streaming logs of pod %s: %w", name, err))
+			}
+
+			return false
+		}
+
+		return true
+	})
+}
+
+// podLogOptions returns the pod log options defined by a request's query parameters.
+func podLogOptions(c *gin.Context) (v1.PodLogOptions, error) {
+	var err error
+
+	options := v1.PodLogOptions{
+		Container: c.Query("container"),
+	}
+
+	if v := c.Query("follow"); v != "" {
+		options.Follow, err = strconv.ParseBool(v)
+		if err != nil {
+			return options, fmt.Errorf("invalid follow parameter %s", v)
+		}
+	}
+
+	if v := c.Query("previous"); v != "" {
+		options.Previous, err = strconv.ParseBool(v)
+		if err != nil {
+			return options, fmt.Errorf("invalid previous parameter %s", v)
+		}
+	}
+
+	if v := c.Query("sinceSeconds"); v != "" {
+		sinceSeconds, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || sinceSeconds <= 0 {
+			return options, fmt.Errorf("invalid sinceSeconds parameter %s", v)
+		}
+
+		options.SinceSeconds = &sinceSeconds
+	}
+
+	if v := c.Query("tailLines"); v != "" {
+		tailLines, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || tailLines < 0 {
+			return options, fmt.Errorf("invalid tailLines parameter %s", v)
+		}
+
+		options.TailLines = &tailLines
+	}
+
+	return options, nil
 }
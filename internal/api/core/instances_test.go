@@ -2,12 +2,21 @@ package core_test
 
 import (
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/homedepot/go-clouddriver/internal/fiat"
 	"github.com/homedepot/go-clouddriver/internal/kubernetes"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 var _ = Describe("Instances", func() {
@@ -300,6 +309,200 @@ var _ = Describe("Instances", func() {
 			})
 		})
 
+		When("the kind is a workload", func() {
+			var pods, replicaSets *unstructured.UnstructuredList
+
+			newOwnedResource := func(kind, name, uid, ownerUID string) unstructured.Unstructured {
+				return unstructured.Unstructured{
+					Object: map[string]interface{}{
+						"kind":       kind,
+						"apiVersion": "v1",
+						"metadata": map[string]interface{}{
+							"name":      name,
+							"namespace": "test-namespace",
+							"uid":       uid,
+							"ownerReferences": []interface{}{
+								map[string]interface{}{
+									"name": "test-owner",
+									"uid":  ownerUID,
+								},
+							},
+						},
+						"spec": map[string]interface{}{
+							"containers": []interface{}{
+								map[string]interface{}{
+									"name": "test-container-name",
+								},
+							},
+						},
+					},
+				}
+			}
+
+			BeforeEach(func() {
+				replicaSets = &unstructured.UnstructuredList{
+					Items: []unstructured.Unstructured{
+						newOwnedResource("ReplicaSet", "test-rs1", "test-rs-uid1", "test-workload-uid"),
+						newOwnedResource("ReplicaSet", "test-rs2", "test-rs-uid2", "other-workload-uid"),
+					},
+				}
+				pods = &unstructured.UnstructuredList{
+					Items: []unstructured.Unstructured{
+						newOwnedResource("Pod", "test-pod3", "test-pod-uid3", "test-rs-uid1"),
+						newOwnedResource("Pod", "test-pod2", "test-pod-uid2", "test-rs-uid2"),
+						newOwnedResource("Pod", "test-pod1", "test-pod-uid1", "test-rs-uid1"),
+						newOwnedResource("Pod", "test-job-pod1", "test-job-pod-uid1", "test-workload-uid"),
+					},
+				}
+				fakeKubeClient.ListResourcesByKindAndNamespaceStub = func(kind, namespace string,
+					lo metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+					if kind == "replicaSet" {
+						return replicaSets, nil
+					}
+
+					return pods, nil
+				}
+			})
+
+			When("the kind is a deployment", func() {
+				BeforeEach(func() {
+					uri = svr.URL + "/instances/test-account/test-namespace/deployment test-deployment/console?provider=kubernetes"
+					createRequest(http.MethodGet)
+					fakeKubeClient.GetReturns(&unstructured.Unstructured{
+						Object: map[string]interface{}{
+							"kind":       "Deployment",
+							"apiVersion": "apps/v1",
+							"metadata": map[string]interface{}{
+								"name":      "test-deployment",
+								"namespace": "test-namespace",
+								"uid":       "test-workload-uid",
+							},
+							"spec": map[string]interface{}{
+								"selector": map[string]interface{}{
+									"matchLabels": map[string]interface{}{
+										"app": "test-app",
+									},
+								},
+							},
+						},
+					}, nil)
+				})
+
+				When("listing the replica sets returns an error", func() {
+					BeforeEach(func() {
+						fakeKubeClient.ListResourcesByKindAndNamespaceStub = nil
+						fakeKubeClient.ListResourcesByKindAndNamespaceReturns(nil, errors.New("error listing"))
+					})
+
+					It("returns status internal server error", func() {
+						Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
+						ce := getClouddriverError()
+						Expect(ce.Message).To(Equal("error listing replica sets of deployment test-deployment: error listing"))
+					})
+				})
+
+				It("returns the logs of the pods of its replica sets", func() {
+					Expect(res.StatusCode).To(Equal(http.StatusOK))
+					validateResponse(payloadGetInstanceConsoleDeployment)
+					Expect(fakeKubeClient.ListResourcesByKindAndNamespaceCallCount()).To(Equal(2))
+					kind, namespace, lo := fakeKubeClient.ListResourcesByKindAndNamespaceArgsForCall(0)
+					Expect(kind).To(Equal("replicaSet"))
+					Expect(namespace).To(Equal("test-namespace"))
+					Expect(lo.LabelSelector).To(Equal("app=test-app"))
+					kind, _, lo = fakeKubeClient.ListResourcesByKindAndNamespaceArgsForCall(1)
+					Expect(kind).To(Equal("pod"))
+					Expect(lo.LabelSelector).To(Equal("app=test-app"))
+				})
+			})
+
+			When("the kind is a job", func() {
+				BeforeEach(func() {
+					uri = svr.URL + "/instances/test-account/test-namespace/job test-job/console?provider=kubernetes"
+					createRequest(http.MethodGet)
+					fakeKubeClient.GetReturns(&unstructured.Unstructured{
+						Object: map[string]interface{}{
+							"kind":       "Job",
+							"apiVersion": "batch/v1",
+							"metadata": map[string]interface{}{
+								"name":      "test-job",
+								"namespace": "test-namespace",
+								"uid":       "test-workload-uid",
+							},
+						},
+					}, nil)
+				})
+
+				When("listing the pods returns an error", func() {
+					BeforeEach(func() {
+						fakeKubeClient.ListResourcesByKindAndNamespaceStub = nil
+						fakeKubeClient.ListResourcesByKindAndNamespaceReturns(nil, errors.New("error listing"))
+					})
+
+					It("returns status internal server error", func() {
+						Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
+						ce := getClouddriverError()
+						Expect(ce.Message).To(Equal("error listing pods of job test-job: error listing"))
+					})
+				})
+
+				When("the job has more pods than are returned", func() {
+					var (
+						mu                 sync.Mutex
+						inFlight, maxCalls int
+					)
+
+					BeforeEach(func() {
+						inFlight, maxCalls = 0, 0
+						pods.Items = []unstructured.Unstructured{}
+						created := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+						for i := 1; i <= 12; i++ {
+							pod := newOwnedResource("Pod", fmt.Sprintf("test-job-pod%02d", i),
+								fmt.Sprintf("test-job-pod-uid%d", i), "test-workload-uid")
+							pod.SetCreationTimestamp(metav1.NewTime(created.Add(time.Duration(i) * time.Minute)))
+							pods.Items = append(pods.Items, pod)
+						}
+
+						fakeKubeClientset.PodLogsStub = func(string, string, string) (string, error) {
+							mu.Lock()
+							inFlight++
+							if inFlight > maxCalls {
+								maxCalls = inFlight
+							}
+							mu.Unlock()
+
+							time.Sleep(10 * time.Millisecond)
+
+							mu.Lock()
+							inFlight--
+							mu.Unlock()
+
+							return "log output", nil
+						}
+					})
+
+					It("returns the logs of its newest pods a few at a time", func() {
+						Expect(res.StatusCode).To(Equal(http.StatusOK))
+						Expect(fakeKubeClientset.PodLogsCallCount()).To(Equal(10))
+						Expect(maxCalls).To(BeNumerically("<=", 5))
+						b, _ := io.ReadAll(res.Body)
+						Expect(string(b)).To(ContainSubstring("test-job-pod12/test-container-name"))
+						Expect(string(b)).To(ContainSubstring("test-job-pod03/test-container-name"))
+						Expect(string(b)).ToNot(ContainSubstring("test-job-pod02/test-container-name"))
+						Expect(string(b)).ToNot(ContainSubstring("test-job-pod01/test-container-name"))
+					})
+				})
+
+				It("returns the logs of its pods", func() {
+					Expect(res.StatusCode).To(Equal(http.StatusOK))
+					validateResponse(payloadGetInstanceConsoleJob)
+					Expect(fakeKubeClient.ListResourcesByKindAndNamespaceCallCount()).To(Equal(1))
+					_, _, lo := fakeKubeClient.ListResourcesByKindAndNamespaceArgsForCall(0)
+					Expect(lo.LabelSelector).To(Equal("job-name=test-job"))
+				})
+			})
+		})
+
 		When("it succeeds", func() {
 			It("returns the instance", func() {
 				Expect(res.StatusCode).To(Equal(http.StatusOK))
@@ -307,4 +510,163 @@ var _ = Describe("Instances", func() {
 			})
 		})
 	})
+
+	Describe("#StreamInstanceLogs", func() {
+		BeforeEach(func() {
+			setup()
+			uri = svr.URL + "/instances/test-account/test-namespace/pod test-pod/logs" +
+				"?container=test-container-name&follow=true&previous=true&sinceSeconds=60&tailLines=100"
+			createRequest(http.MethodGet)
+			fakeKubeClientset.StreamPodLogsReturns(io.NopCloser(strings.NewReader("line 1\nline 2\n")), nil)
+		})
+
+		AfterEach(func() {
+			teardown()
+		})
+
+		JustBeforeEach(func() {
+			doRequest()
+		})
+
+		When("the name is not in the format 'kind name'", func() {
+			BeforeEach(func() {
+				uri = svr.URL + "/instances/test-account/test-namespace/test-pod/logs"
+				createRequest(http.MethodGet)
+			})
+
+			It("returns status bad request", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+				ce := getClouddriverError()
+				Expect(ce.Message).To(Equal("name parameter must be in the format of 'kind name', got: test-pod"))
+			})
+		})
+
+		When("the kind is not a pod", func() {
+			BeforeEach(func() {
+				uri = svr.URL + "/instances/test-account/test-namespace/deployment test-deployment/logs"
+				createRequest(http.MethodGet)
+			})
+
+			It("returns status not implemented", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusNotImplemented))
+				ce := getClouddriverError()
+				Expect(ce.Message).To(Equal("kind deployment logs not implemented"))
+			})
+		})
+
+		When("a query parameter is invalid", func() {
+			BeforeEach(func() {
+				uri = svr.URL + "/instances/test-account/test-namespace/pod test-pod/logs?sinceSeconds=0"
+				createRequest(http.MethodGet)
+			})
+
+			It("returns status bad request", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+				ce := getClouddriverError()
+				Expect(ce.Message).To(Equal("invalid sinceSeconds parameter 0"))
+				Expect(fakeKubeClientset.StreamPodLogsCallCount()).To(BeZero())
+			})
+		})
+
+		When("the user cannot read the account", func() {
+			BeforeEach(func() {
+				fakeResp := fiat.Response{}
+				fakeResp.Accounts = []fiat.Account{
+					{
+						Name:           "test-account",
+						Authorizations: []string{"WRITE"},
+					},
+				}
+				fakeFiatClient.AuthorizeReturns(fakeResp, nil)
+				req.Header.Set("X-Spinnaker-User", "test-user")
+			})
+
+			It("returns status forbidden", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusForbidden))
+				ce := getClouddriverError()
+				Expect(ce.Message).To(Equal("access denied to account test-account - required authorization: READ"))
+				Expect(fakeKubeClientset.StreamPodLogsCallCount()).To(BeZero())
+			})
+		})
+
+		When("getting the provider returns an error", func() {
+			BeforeEach(func() {
+				fakeSQLClient.GetKubernetesProviderReturns(kubernetes.Provider{}, errors.New("error getting provider"))
+			})
+
+			It("returns status bad request", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+				ce := getClouddriverError()
+				Expect(ce.Message).To(Equal("internal: error getting kubernetes provider test-account: error getting provider"))
+			})
+		})
+
+		When("the account cannot access the namespace", func() {
+			BeforeEach(func() {
+				fakeSQLClient.GetKubernetesProviderReturns(kubernetes.Provider{
+					Name:       "test-account",
+					Namespaces: []string{"other-namespace"},
+				}, nil)
+			})
+
+			It("returns status bad request", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(fakeKubeClientset.StreamPodLogsCallCount()).To(BeZero())
+			})
+		})
+
+		When("the pod is not found", func() {
+			BeforeEach(func() {
+				fakeKubeClientset.StreamPodLogsReturns(nil,
+					k8serrors.NewNotFound(schema.GroupResource{Resource: "pods"}, "test-pod"))
+			})
+
+			It("returns status not found", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusNotFound))
+				ce := getClouddriverError()
+				Expect(ce.Message).To(Equal(`pods "test-pod" not found`))
+			})
+		})
+
+		When("the container has no previous logs", func() {
+			BeforeEach(func() {
+				fakeKubeClientset.StreamPodLogsReturns(nil,
+					k8serrors.NewBadRequest("previous terminated container \"test-container-name\" in pod \"test-pod\" not found"))
+			})
+
+			It("returns status bad request", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("streaming the logs returns an error", func() {
+			BeforeEach(func() {
+				fakeKubeClientset.StreamPodLogsReturns(nil, errors.New("error streaming logs"))
+			})
+
+			It("returns status internal server error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
+				ce := getClouddriverError()
+				Expect(ce.Message).To(Equal("error streaming logs"))
+			})
+		})
+
+		When("it succeeds", func() {
+			It("streams the logs of the container", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				Expect(res.Header.Get("Content-Type")).To(Equal("text/plain; charset=utf-8"))
+				b, _ := io.ReadAll(res.Body)
+				Expect(string(b)).To(Equal("line 1\nline 2\n"))
+
+				_, name, namespace, options := fakeKubeClientset.StreamPodLogsArgsForCall(0)
+				Expect(name).To(Equal("test-pod"))
+				Expect(namespace).To(Equal("test-namespace"))
+				Expect(options.Container).To(Equal("test-container-name"))
+				Expect(options.Follow).To(BeTrue())
+				Expect(options.Previous).To(BeTrue())
+				Expect(*options.SinceSeconds).To(Equal(int64(60)))
+				Expect(*options.TailLines).To(Equal(int64(100)))
+			})
+		})
+	})
 })
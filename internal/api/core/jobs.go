@@ -0,0 +1,61 @@
+package core
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/homedepot/go-clouddriver/internal/kubernetes"
+)
+
+type PreconfiguredJobs []PreconfiguredJob
+
+// PreconfiguredJob describes the stage of a preconfigured job,
+// run by the 'runPreconfiguredJob' kubernetes operation. Orca
+// uses its manifest and defaults for any field the stage does not set.
+type PreconfiguredJob struct {
+	Account           string                                 `json:"account"`
+	Application       string                                 `json:"application"`
+	CloudProvider     string                                 `json:"cloudProvider"`
+	Credentials       string                                 `json:"credentials"`
+	Description       string                                 `json:"description"`
+	Label             string                                 `json:"label"`
+	Manifest          map[string]interface{}                 `json:"manifest"`
+	Parameters        []kubernetes.PreconfiguredJobParameter `json:"parameters"`
+	ProducesArtifacts bool                                   `json:"producesArtifacts"`
+	PropertyFile      string                                 `json:"propertyFile"`
+	Type              string                                 `json:"type"`
+	WaitForCompletion bool                                   `json:"waitForCompletion"`
+}
+
+// ListPreconfiguredJobs lists the preconfigured jobs registered by operators,
+// with their manifest, stage defaults and parameters sorted by their order.
+func (cc *Controller) ListPreconfiguredJobs(c *gin.Context) {
+	response := PreconfiguredJobs{}
+
+	for _, pj := range cc.PreconfiguredJobs {
+		parameters := make([]kubernetes.PreconfiguredJobParameter, len(pj.Parameters))
+		copy(parameters, pj.Parameters)
+
+		sort.SliceStable(parameters, func(i, j int) bool {
+			return parameters[i].Order < parameters[j].Order
+		})
+
+		response = append(response, PreconfiguredJob{
+			Account:           pj.Account,
+			Application:       pj.Application,
+			CloudProvider:     "kubernetes",
+			Credentials:       pj.Account,
+			Description:       pj.Description,
+			Label:             pj.Label,
+			Manifest:          pj.Manifest,
+			Parameters:        parameters,
+			ProducesArtifacts: pj.ProducesArtifacts,
+			PropertyFile:      pj.PropertyFile,
+			Type:              pj.Name,
+			WaitForCompletion: pj.WaitForCompletion,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,75 @@
+package core_test
+
+import (
+	"net/http"
+
+	"github.com/homedepot/go-clouddriver/internal/kubernetes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Jobs", func() {
+	Describe("#ListPreconfiguredJobs", func() {
+		BeforeEach(func() {
+			setup()
+			uri = svr.URL + "/jobs/preconfigured"
+			createRequest(http.MethodGet)
+		})
+
+		JustBeforeEach(func() {
+			doRequest()
+		})
+
+		AfterEach(func() {
+			teardown()
+		})
+
+		When("no preconfigured jobs are registered", func() {
+			It("returns an empty list", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				validateResponse(`[]`)
+			})
+		})
+
+		When("preconfigured jobs are registered", func() {
+			BeforeEach(func() {
+				internalController.PreconfiguredJobs = kubernetes.PreconfiguredJobs{
+					{
+						Name:              "test-job",
+						Label:             "Test Job",
+						Description:       "Runs a test job.",
+						WaitForCompletion: true,
+						Account:           "test-account",
+						Application:       "test-application",
+						PropertyFile:      "test-container",
+						ProducesArtifacts: true,
+						Parameters: []kubernetes.PreconfiguredJobParameter{
+							{
+								Name:    "team",
+								Label:   "Team",
+								Mapping: "metadata.labels.team",
+								Order:   1,
+							},
+							{
+								Name:         "image",
+								Label:        "Image",
+								Type:         "string",
+								Mapping:      "spec.template.spec.containers[0].image",
+								DefaultValue: "test-image:latest",
+								Order:        0,
+							},
+						},
+						Manifest: map[string]interface{}{
+							"kind": "Job",
+						},
+					},
+				}
+			})
+
+			It("lists the jobs with their manifest, defaults and parameters in order", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				validateResponse(payloadListPreconfiguredJobs)
+			})
+		})
+	})
+})
@@ -0,0 +1,190 @@
+package kubernetes
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/homedepot/go-clouddriver/internal/kubernetes"
+	"github.com/homedepot/go-clouddriver/internal/sql"
+	clouddriver "github.com/homedepot/go-clouddriver/pkg"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	rollbackActionDeleted  = "deleted"
+	rollbackActionRestored = "restored"
+)
+
+// snapshot is the state of a manifest's live object captured
+// before it is applied during an atomic deploy.
+type snapshot struct {
+	kind      string
+	name      string
+	namespace string
+	// previous is the live object before it was applied,
+	// or nil if the object was created by the deploy.
+	previous *unstructured.Unstructured
+	// resourceID is the ID of the resource entry recorded for
+	// the manifest once it was applied, if any.
+	resourceID string
+}
+
+// deployTransaction tracks the manifests changed by an atomic deploy
+// so they can be rolled back if the task fails.
+type deployTransaction struct {
+	account   string
+	client    kubernetes.Client
+	snapshots []snapshot
+}
+
+// beginDeployTransaction starts tracking the manifests changed by an atomic
+// deploy to an account. Transactions are kept in the context for the duration
+// of the task, so if any operation of the task fails, each atomic deploy that
+// preceded it is rolled back along with the failed one.
+func beginDeployTransaction(c *gin.Context, account string, client kubernetes.Client) *deployTransaction {
+	tx := &deployTransaction{
+		account: account,
+		client:  client,
+	}
+
+	c.Set(keyTaskDeployTransactions, append(deployTransactions(c), tx))
+
+	return tx
+}
+
+// deployTransactions returns the deploy transactions started in the context.
+func deployTransactions(c *gin.Context) []*deployTransaction {
+	if v, ok := c.Get(keyTaskDeployTransactions); ok {
+		return v.([]*deployTransaction)
+	}
+
+	return nil
+}
+
+// capture saves the live state of a manifest before it is changed.
+func (tx *deployTransaction) capture(u *unstructured.Unstructured) error {
+	s := snapshot{
+		kind:      u.GetKind(),
+		name:      u.GetName(),
+		namespace: u.GetNamespace(),
+	}
+
+	previous, err := tx.client.Get(u.GetKind(), u.GetName(), u.GetNamespace())
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+	} else {
+		s.previous = previous
+	}
+
+	tx.snapshots = append(tx.snapshots, s)
+
+	return nil
+}
+
+// recorded saves the ID of the resource entry recorded for the
+// manifest last captured, to be deleted if the manifest is rolled back.
+func (tx *deployTransaction) recorded(id string) {
+	if len(tx.snapshots) > 0 {
+		tx.snapshots[len(tx.snapshots)-1].resourceID = id
+	}
+}
+
+// rollback reverts the manifests changed by the deploy in reverse order,
+// deleting objects the deploy created and restoring objects it updated,
+// along with the resource entries recorded for them.
+// Errors rolling back a manifest are reported in its result and do not
+// stop the rest of the rollback.
+func (tx *deployTransaction) rollback(sc sql.Client) []clouddriver.RolledBackManifest {
+	rbms := []clouddriver.RolledBackManifest{}
+
+	for i := len(tx.snapshots) - 1; i >= 0; i-- {
+		s := tx.snapshots[i]
+		rbm := clouddriver.RolledBackManifest{
+			Account:   tx.account,
+			Kind:      s.kind,
+			Name:      s.name,
+			Namespace: s.namespace,
+		}
+
+		var err error
+
+		if s.previous == nil {
+			rbm.Action = rollbackActionDeleted
+
+			err = tx.client.DeleteResourceByKindAndNameAndNamespace(s.kind, s.name, s.namespace, metav1.DeleteOptions{})
+			if errors.IsNotFound(err) {
+				err = nil
+			}
+		} else {
+			rbm.Action = rollbackActionRestored
+			_, err = tx.client.Replace(restorable(s.previous))
+		}
+
+		// Only forget the manifest was deployed once it is rolled back,
+		// as a manifest that failed to roll back is still live.
+		if err == nil && s.resourceID != "" {
+			err = sc.DeleteKubernetesResource(s.resourceID)
+			if err != nil {
+				err = fmt.Errorf("error deleting resource entry: %w", err)
+			}
+		}
+
+		if err != nil {
+			rbm.Error = err.Error()
+		}
+
+		rbms = append(rbms, rbm)
+	}
+
+	return rbms
+}
+
+// restorable returns a copy of a previously live object that can be
+// written back to the cluster, without the fields set by the server.
+func restorable(u *unstructured.Unstructured) *unstructured.Unstructured {
+	r := u.DeepCopy()
+	r.SetResourceVersion("")
+	r.SetUID("")
+	r.SetCreationTimestamp(metav1.Time{})
+	r.SetGeneration(0)
+	r.SetManagedFields(nil)
+	unstructured.RemoveNestedField(r.Object, "status")
+
+	return r
+}
+
+// rollbackDeploys rolls back the atomic deploys of a failed task, most recent
+// first, adding the manifests that were rolled back to the task's results.
+func (cc *Controller) rollbackDeploys(c *gin.Context) {
+	txs := deployTransactions(c)
+	rbms := []clouddriver.RolledBackManifest{}
+
+	for i := len(txs) - 1; i >= 0; i-- {
+		if len(txs[i].snapshots) == 0 {
+			continue
+		}
+
+		cc.status(c, phaseDeploy, fmt.Sprintf("Rolling back %d manifest(s) in account %s...",
+			len(txs[i].snapshots), txs[i].account))
+
+		rbms = append(rbms, txs[i].rollback(cc.SQLClient)...)
+	}
+
+	if len(rbms) == 0 {
+		return
+	}
+
+	addResult(c, clouddriver.TaskResultObject{
+		BoundArtifacts:                    []clouddriver.Artifact{},
+		CreatedArtifacts:                  []clouddriver.Artifact{},
+		DeployedNamesByLocation:           map[string][]string{},
+		ManifestNamesByNamespace:          map[string][]string{},
+		ManifestNamesByNamespaceToRefresh: map[string][]string{},
+		Manifests:                         []map[string]interface{}{},
+		RolledBackManifests:               rbms,
+	})
+}
@@ -19,6 +19,8 @@ func (cc *Controller) CleanupArtifacts(c *gin.Context, ca CleanupArtifactsReques
 	app := c.GetHeader("X-Spinnaker-Application")
 	taskID := clouddriver.TaskIDFromContext(c)
 
+	cc.status(c, phaseCleanup, "Beginning cleanup of artifacts...")
+
 	for _, manifest := range ca.Manifests {
 		u, err := kubernetes.ToUnstructured(manifest)
 		if err != nil {
@@ -121,6 +123,8 @@ func (cc *Controller) CleanupArtifacts(c *gin.Context, ca CleanupArtifactsReques
 			return
 		}
 	}
+
+	cc.status(c, phaseCleanup, "Cleanup artifacts task completed successfully.")
 }
 
 // clusterAnnotation returns the value of the annotation
@@ -20,6 +20,8 @@ func (cc *Controller) Delete(c *gin.Context, dm DeleteManifestRequest) {
 	taskID := clouddriver.TaskIDFromContext(c)
 	namespace := dm.Location
 
+	cc.status(c, phaseDelete, "Beginning deletion of manifests...")
+
 	provider, err := cc.KubernetesProvider(dm.Account)
 	if err != nil {
 		clouddriver.Error(c, http.StatusBadRequest, err)
@@ -82,6 +84,8 @@ func (cc *Controller) Delete(c *gin.Context, dm DeleteManifestRequest) {
 			return
 		}
 
+		cc.status(c, phaseDelete, fmt.Sprintf("Deleting manifest %s %s...", kind, name))
+
 		err = provider.Client.DeleteResourceByKindAndNameAndNamespace(kind, name, namespace, do)
 		if err != nil {
 			clouddriver.Error(c, http.StatusInternalServerError, err)
@@ -183,6 +187,7 @@ func (cc *Controller) Delete(c *gin.Context, dm DeleteManifestRequest) {
 			}
 
 			for _, item := range list.Items {
+				cc.status(c, phaseDelete, fmt.Sprintf("Deleting manifest %s %s...", kind, item.GetName()))
 				// Delete each resource and record it in the database.
 				err = provider.Client.DeleteResourceByKindAndNameAndNamespace(kind, item.GetName(), namespace, do)
 				if err != nil {
@@ -213,11 +218,11 @@ func (cc *Controller) Delete(c *gin.Context, dm DeleteManifestRequest) {
 				}
 			}
 		}
-
-		return
 	default:
 		clouddriver.Error(c, http.StatusNotImplemented,
 			fmt.Errorf("requested to delete manifest %s using mode %s which is not implemented", dm.ManifestName, mode))
 		return
 	}
+
+	cc.status(c, phaseDelete, "Delete manifest task completed successfully.")
 }
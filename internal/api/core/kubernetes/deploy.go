@@ -29,11 +29,24 @@ var (
 // to "apply" them to. It adds Spinnaker annotations/labels,
 // and handles any Spinnaker versioning, then applies each manifest
 // one by one.
+//
+// If a dry run is requested each manifest is instead applied using a server-side
+// dry run and the diffs against the live objects are added to the task's results.
+// Otherwise, the task can wait for the deployed resources to become stable.
+//
+// An atomic deployment rolls back the manifests it applied if it, or any
+// operation of the task that follows it, fails.
 func (cc *Controller) Deploy(c *gin.Context, dm DeployManifestRequest) {
 	taskID := clouddriver.TaskIDFromContext(c)
 	namespace := strings.TrimSpace(dm.NamespaceOverride)
 
-	provider, err := cc.KubernetesProvider(dm.Account)
+	if dm.DryRun {
+		cc.status(c, phaseDeploy, "Beginning dry run deployment of manifests...")
+	} else {
+		cc.status(c, phaseDeploy, "Beginning deployment of manifests...")
+	}
+
+	provider, err := cc.provider(c, dm.Account)
 	if err != nil {
 		clouddriver.Error(c, http.StatusBadRequest, err)
 		return
@@ -44,6 +57,11 @@ func (cc *Controller) Deploy(c *gin.Context, dm DeployManifestRequest) {
 		namespace = provider.Namespaces[0]
 	}
 
+	var tx *deployTransaction
+	if dm.Atomic && !dm.DryRun {
+		tx = beginDeployTransaction(c, dm.Account, provider.Client)
+	}
+
 	// First, convert all manifests to unstructured objects.
 	manifests, err := toUnstructured(dm.Manifests)
 	if err != nil {
@@ -70,6 +88,11 @@ func (cc *Controller) Deploy(c *gin.Context, dm DeployManifestRequest) {
 	artifacts := []clouddriver.Artifact{}
 	artifacts = append(artifacts, dm.RequiredArtifacts...)
 	artifacts = append(artifacts, dm.OptionalArtifacts...)
+	// Diffs and the resulting objects of a dry run.
+	diffs := []clouddriver.ManifestDiff{}
+	results := []map[string]interface{}{}
+	// Resources deployed, to wait on if waiting for stability.
+	deployed := []kubernetes.Metadata{}
 
 	for _, manifest := range manifests {
 		// Create a copy of the unstructured object since we access by reference.
@@ -131,7 +154,18 @@ func (cc *Controller) Deploy(c *gin.Context, dm DeployManifestRequest) {
 			}
 		}
 
-		if kubernetes.Recreate(manifest) {
+		// Capture the live object before it is changed
+		// so it can be restored if the deployment fails.
+		if tx != nil {
+			err = tx.capture(&manifest)
+			if err != nil {
+				clouddriver.Error(c, http.StatusInternalServerError, err)
+				return
+			}
+		}
+
+		// Recreating deletes the live object, so skip it for a dry run.
+		if kubernetes.Recreate(manifest) && !dm.DryRun {
 			err = handleRecreate(provider.Client, &manifest)
 			if err != nil {
 				clouddriver.Error(c, http.StatusInternalServerError, err)
@@ -161,6 +195,46 @@ func (cc *Controller) Deploy(c *gin.Context, dm DeployManifestRequest) {
 			}
 		}
 
+		if dm.DryRun {
+			cc.status(c, phaseDeploy, fmt.Sprintf("Dry run deploying manifest %s %s...", manifest.GetKind(), manifest.GetName()))
+
+			live, result, err := provider.Client.DryRunApply(&manifest)
+			if err != nil {
+				e := fmt.Errorf("error dry run applying manifest (kind: %s, apiVersion: %s, name: %s): %s",
+					manifest.GetKind(), manifest.GroupVersionKind().Version, manifest.GetName(), err.Error())
+				clouddriver.Error(c, http.StatusInternalServerError, e)
+
+				return
+			}
+
+			diff, err := kubernetes.Diff(live, result)
+			if err != nil {
+				clouddriver.Error(c, http.StatusInternalServerError, err)
+				return
+			}
+
+			diffs = append(diffs, clouddriver.ManifestDiff{
+				Account:   dm.Account,
+				Kind:      manifest.GetKind(),
+				Name:      manifest.GetName(),
+				Namespace: manifest.GetNamespace(),
+				Exists:    live != nil,
+				Changed:   len(diff) > 0,
+				Diff:      diff,
+			})
+			results = append(results, result.Object)
+			// Still bind the versioned name of this manifest to the manifests that follow it.
+			artifacts = append(artifacts, clouddriver.Artifact{
+				Name:      nameWithoutVersion,
+				Reference: manifest.GetName(),
+				Type:      artifact.Type(manifest.GetAnnotations()[kubernetes.AnnotationSpinnakerArtifactType]),
+			})
+
+			continue
+		}
+
+		cc.status(c, phaseDeploy, fmt.Sprintf("Deploying manifest %s %s...", manifest.GetKind(), manifest.GetName()))
+
 		meta := kubernetes.Metadata{}
 		if kubernetes.Replace(manifest) {
 			meta, err = provider.Client.Replace(&manifest)
@@ -174,8 +248,8 @@ func (cc *Controller) Deploy(c *gin.Context, dm DeployManifestRequest) {
 		} else {
 			meta, err = provider.Client.Apply(&manifest)
 			if err != nil {
-				e := fmt.Errorf("error applying manifest (kind: %s, apiVersion: %s, name: %s): %s",
-					manifest.GetKind(), manifest.GroupVersionKind().Version, manifest.GetName(), err.Error())
+				e := fmt.Errorf("error applying manifest (kind: %s, apiVersion: %s, name: %s): %w",
+					manifest.GetKind(), manifest.GroupVersionKind().Version, manifest.GetName(), err)
 				clouddriver.Error(c, http.StatusInternalServerError, e)
 
 				return
@@ -196,6 +270,7 @@ func (cc *Controller) Deploy(c *gin.Context, dm DeployManifestRequest) {
 			Kind:         meta.Kind,
 			SpinnakerApp: dm.Moniker.App,
 			Cluster:      kubernetes.Cluster(meta.Kind, nameWithoutVersion),
+			Labels:       kubernetes.SearchableLabels(manifest),
 		}
 
 		annotations := manifest.GetAnnotations()
@@ -212,7 +287,35 @@ func (cc *Controller) Deploy(c *gin.Context, dm DeployManifestRequest) {
 			clouddriver.Error(c, http.StatusInternalServerError, err)
 			return
 		}
+
+		if tx != nil {
+			tx.recorded(kr.ID)
+		}
+
+		deployed = append(deployed, meta)
+	}
+
+	if dm.WaitForStability && !dm.DryRun {
+		err = cc.waitForStability(c, provider, phaseDeploy, dm.StabilityOptions, deployed)
+		if err != nil {
+			clouddriver.Error(c, http.StatusInternalServerError, err)
+			return
+		}
 	}
+
+	if dm.DryRun {
+		addResult(c, clouddriver.TaskResultObject{
+			BoundArtifacts:                    []clouddriver.Artifact{},
+			CreatedArtifacts:                  []clouddriver.Artifact{},
+			DeployedNamesByLocation:           map[string][]string{},
+			ManifestNamesByNamespace:          map[string][]string{},
+			ManifestNamesByNamespaceToRefresh: map[string][]string{},
+			Manifests:                         results,
+			ManifestDiffs:                     diffs,
+		})
+	}
+
+	cc.status(c, phaseDeploy, "Deploy manifest task completed successfully.")
 }
 
 // toUnstructured converts a slice of map[string]interface{} to unstructured.Unstructured.
@@ -1143,6 +1143,199 @@ var _ = Describe("Deploy", func() {
 		})
 	})
 
+	When("an atomic deployment is requested", func() {
+		BeforeEach(func() {
+			deployManifestRequest.Atomic = true
+			deployManifestRequest.Account = "test-account"
+			deployManifestRequest.Manifests = []map[string]interface{}{
+				{
+					"kind":       "Pod",
+					"apiVersion": "v1",
+					"metadata": map[string]interface{}{
+						"namespace": "default",
+						"name":      "test-pod",
+					},
+				},
+				{
+					"kind":       "ServiceAccount",
+					"apiVersion": "v1",
+					"metadata": map[string]interface{}{
+						"namespace": "default",
+						"name":      "test-service-account",
+					},
+				},
+				{
+					"kind":       "Service",
+					"apiVersion": "v1",
+					"metadata": map[string]interface{}{
+						"namespace": "default",
+						"name":      "test-service",
+					},
+				},
+			}
+			fakeKubeClient.GetStub = func(kind, name, namespace string) (*unstructured.Unstructured, error) {
+				if name == "test-service-account" {
+					return &unstructured.Unstructured{
+						Object: map[string]interface{}{
+							"kind":       "ServiceAccount",
+							"apiVersion": "v1",
+							"metadata": map[string]interface{}{
+								"namespace":       "default",
+								"name":            "test-service-account",
+								"resourceVersion": "100",
+								"uid":             "test-uid",
+							},
+							"status": map[string]interface{}{},
+						},
+					}, nil
+				}
+
+				return nil, k8serrors.NewNotFound(schema.GroupResource{Resource: kind}, name)
+			}
+			fakeKubeClient.ApplyStub = func(u *unstructured.Unstructured) (kubernetes.Metadata, error) {
+				if u.GetKind() == "Pod" {
+					return kubernetes.Metadata{}, errors.New("error applying manifest")
+				}
+
+				return kubernetes.Metadata{Name: u.GetName()}, nil
+			}
+		})
+
+		When("capturing the live object returns an error", func() {
+			BeforeEach(func() {
+				fakeKubeClient.GetStub = nil
+				fakeKubeClient.GetReturns(nil, errors.New("error getting manifest"))
+			})
+
+			It("returns an error", func() {
+				Expect(c.Writer.Status()).To(Equal(http.StatusInternalServerError))
+				Expect(c.Errors.Last().Error()).To(Equal("error getting manifest"))
+				Expect(fakeKubeClient.ApplyCallCount()).To(Equal(0))
+			})
+		})
+
+		When("it succeeds", func() {
+			BeforeEach(func() {
+				fakeKubeClient.ApplyStub = nil
+			})
+
+			It("captures each live object before applying it", func() {
+				Expect(c.Errors).To(BeEmpty())
+				Expect(fakeKubeClient.ApplyCallCount()).To(Equal(3))
+				Expect(fakeKubeClient.GetCallCount()).To(Equal(3))
+				Expect(fakeKubeClient.DeleteResourceByKindAndNameAndNamespaceCallCount()).To(Equal(0))
+				Expect(fakeKubeClient.ReplaceCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	When("waiting for stability", func() {
+		BeforeEach(func() {
+			deployManifestRequest.WaitForStability = true
+			fakeKubeClient.ApplyReturns(kubernetes.Metadata{
+				Name:      "test-name",
+				Namespace: "test-namespace",
+				Resource:  "deployments",
+				Kind:      "Deployment",
+			}, nil)
+		})
+
+		When("a resource fails", func() {
+			BeforeEach(func() {
+				fakeKubeClient.GetReturns(&unstructured.Unstructured{
+					Object: map[string]interface{}{
+						"status": map[string]interface{}{
+							"conditions": []interface{}{
+								map[string]interface{}{
+									"type":    "Progressing",
+									"reason":  "ProgressDeadlineExceeded",
+									"message": "ReplicaSet has timed out progressing.",
+								},
+							},
+						},
+					},
+				}, nil)
+			})
+
+			It("returns an error", func() {
+				Expect(c.Writer.Status()).To(Equal(http.StatusInternalServerError))
+				Expect(c.Errors.Last().Error()).To(Equal("Deployment test-name failed: ReplicaSet has timed out progressing."))
+			})
+		})
+
+		When("the resources become stable", func() {
+			It("waits for each deployed resource", func() {
+				Expect(c.Errors).To(BeEmpty())
+				Expect(fakeKubeClient.GetCallCount()).To(Equal(fakeKubeClient.ApplyCallCount()))
+				kind, name, namespace := fakeKubeClient.GetArgsForCall(0)
+				Expect(kind).To(Equal("deployments"))
+				Expect(name).To(Equal("test-name"))
+				Expect(namespace).To(Equal("test-namespace"))
+			})
+		})
+
+		When("a dry run is requested", func() {
+			BeforeEach(func() {
+				deployManifestRequest.DryRun = true
+				fakeKubeClient.DryRunApplyReturns(nil, &unstructured.Unstructured{Object: map[string]interface{}{}}, nil)
+			})
+
+			It("does not wait", func() {
+				Expect(c.Errors).To(BeEmpty())
+				Expect(fakeKubeClient.GetCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	When("a dry run is requested", func() {
+		BeforeEach(func() {
+			deployManifestRequest.DryRun = true
+			fakeKubeClient.DryRunApplyStub = func(u *unstructured.Unstructured) (*unstructured.Unstructured, *unstructured.Unstructured, error) {
+				return nil, u.DeepCopy(), nil
+			}
+		})
+
+		When("the dry run returns an error", func() {
+			BeforeEach(func() {
+				fakeKubeClient.DryRunApplyStub = nil
+				fakeKubeClient.DryRunApplyReturns(nil, nil, errors.New("error dry running"))
+			})
+
+			It("returns an error", func() {
+				Expect(c.Writer.Status()).To(Equal(http.StatusInternalServerError))
+				Expect(c.Errors.Last().Error()).To(Equal("error dry run applying manifest (kind: Pod, apiVersion: v1, name: test-name-v000): error dry running"))
+			})
+		})
+
+		When("the manifest uses recreate strategy", func() {
+			BeforeEach(func() {
+				deployManifestRequest.Manifests[0]["metadata"] = map[string]interface{}{
+					"name":      "test-name",
+					"namespace": "default",
+					"annotations": map[string]interface{}{
+						kubernetes.AnnotationSpinnakerRecreate: "true",
+					},
+				}
+			})
+
+			It("does not delete the live resource", func() {
+				Expect(c.Writer.Status()).To(Equal(http.StatusOK))
+				Expect(fakeKubeClient.DeleteResourceByKindAndNameAndNamespaceCallCount()).To(Equal(0))
+			})
+		})
+
+		It("dry runs the manifests without changing the cluster", func() {
+			Expect(c.Writer.Status()).To(Equal(http.StatusOK))
+			Expect(fakeKubeClient.DryRunApplyCallCount()).To(Equal(1))
+			u := fakeKubeClient.DryRunApplyArgsForCall(0)
+			Expect(u.GetName()).To(Equal("test-name-v000"))
+			Expect(u.GetAnnotations()).To(HaveKey(kubernetes.AnnotationSpinnakerMonikerApplication))
+			Expect(fakeKubeClient.ApplyCallCount()).To(Equal(0))
+			Expect(fakeKubeClient.ReplaceCallCount()).To(Equal(0))
+			Expect(fakeSQLClient.CreateKubernetesResourceCallCount()).To(Equal(0))
+		})
+	})
+
 	When("Using a namespace-scoped provider", func() {
 		BeforeEach(func() {
 			fakeSQLClient.GetKubernetesProviderReturns(namespaceScopedProvider, nil)
@@ -34,6 +34,8 @@ func (cc *Controller) Disable(c *gin.Context, dm DisableManifestRequest) {
 	taskID := clouddriver.TaskIDFromContext(c)
 	namespace := dm.Location
 
+	cc.status(c, phaseDisable, fmt.Sprintf("Disabling manifest %s...", dm.ManifestName))
+
 	provider, err := cc.KubernetesProvider(dm.Account)
 	if err != nil {
 		clouddriver.Error(c, http.StatusBadRequest, err)
@@ -89,33 +91,13 @@ func (cc *Controller) Disable(c *gin.Context, dm DisableManifestRequest) {
 	}
 
 	var pods []*unstructured.Unstructured
-	// If the target manifest has load balancers and pods, list pods, grab those that has the owner UID
-	// of the target manifest, and patch those pods.
+	// If the target manifest has load balancers and pods, patch the pods it owns.
 	if len(loadBalancers) > 0 && hasPods(target) {
-		// Declare server side filtering options.
-		lo := metav1.ListOptions{
-			FieldSelector: "metadata.namespace=" + namespace,
-			LabelSelector: kubernetes.DefaultLabelSelector(),
-		}
-		// Declare a context with timeout.
-		ctx, cancel := context.WithTimeout(context.Background(), time.Second*internal.DefaultListTimeoutSeconds)
-		defer cancel()
-		// List resources with the context.
-		ul, err := provider.Client.ListResourceWithContext(ctx, "pods", lo)
+		pods, err = ownedPods(provider.Client, target, namespace)
 		if err != nil {
 			clouddriver.Error(c, http.StatusInternalServerError, err)
 			return
 		}
-		// Loop through all pods, finding all that are owned by the target manifest.
-		for _, u := range ul.Items {
-			for _, ownerReference := range u.GetOwnerReferences() {
-				if ownerReference.UID == target.GetUID() {
-					// Create a copy of the unstructured object since we access by reference.
-					u := u
-					pods = append(pods, &u)
-				}
-			}
-		}
 	}
 
 	for _, loadBalancer := range loadBalancers {
@@ -158,6 +140,8 @@ func (cc *Controller) Disable(c *gin.Context, dm DisableManifestRequest) {
 		clouddriver.Error(c, http.StatusInternalServerError, err)
 		return
 	}
+
+	cc.status(c, phaseDisable, "Disable manifest task completed successfully.")
 }
 
 // getLoadBalancer gets a given load balancer from a specified namespace.
@@ -211,22 +195,11 @@ type jsonPatch struct {
 //
 // ]
 func attachDetach(client kubernetes.Client, lb, target *unstructured.Unstructured, op string) error {
-	labelsPath := "spec.template.metadata.labels"
-
-	labels, found, err := unstructured.NestedStringMap(target.Object, strings.Split(labelsPath, ".")...)
+	labels, labelsPath, err := podLabels(target)
 	if err != nil {
 		return err
 	}
 
-	if !found {
-		labelsPath = "metadata.labels"
-
-		labels, _, err = unstructured.NestedStringMap(target.Object, strings.Split(labelsPath, ".")...)
-		if err != nil {
-			return err
-		}
-	}
-
 	selector, found, _ := unstructured.NestedStringMap(lb.Object, "spec", "selector")
 	if !found || len(selector) == 0 {
 		// No selectors here so just return.
@@ -286,6 +259,59 @@ func attachDetach(client kubernetes.Client, lb, target *unstructured.Unstructure
 	return nil
 }
 
+// podLabels returns the labels of the pod template of a target, or of the target
+// itself if it has no pod template, such as a pod, along with their path.
+func podLabels(target *unstructured.Unstructured) (map[string]string, string, error) {
+	labelsPath := "spec.template.metadata.labels"
+
+	labels, found, err := unstructured.NestedStringMap(target.Object, strings.Split(labelsPath, ".")...)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if !found {
+		labelsPath = "metadata.labels"
+
+		labels, _, err = unstructured.NestedStringMap(target.Object, strings.Split(labelsPath, ".")...)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	return labels, labelsPath, nil
+}
+
+// ownedPods lists the pods in a namespace that are owned by a given target manifest.
+func ownedPods(client kubernetes.Client, target *unstructured.Unstructured, namespace string) ([]*unstructured.Unstructured, error) {
+	// Declare server side filtering options.
+	lo := metav1.ListOptions{
+		FieldSelector: "metadata.namespace=" + namespace,
+		LabelSelector: kubernetes.DefaultLabelSelector(),
+	}
+	// Declare a context with timeout.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*internal.DefaultListTimeoutSeconds)
+	defer cancel()
+	// List resources with the context.
+	ul, err := client.ListResourceWithContext(ctx, "pods", lo)
+	if err != nil {
+		return nil, err
+	}
+
+	var pods []*unstructured.Unstructured
+	// Loop through all pods, finding all that are owned by the target manifest.
+	for _, u := range ul.Items {
+		for _, ownerReference := range u.GetOwnerReferences() {
+			if ownerReference.UID == target.GetUID() {
+				// Create a copy of the unstructured object since we access by reference.
+				u := u
+				pods = append(pods, &u)
+			}
+		}
+	}
+
+	return pods, nil
+}
+
 // hasPods returns true if the kind of a Kubernetes object is
 // - CronJob
 // - DaemonSet
@@ -1,19 +1,15 @@
 package kubernetes
 
 import (
-	"context"
 	"fmt"
 	"net/http"
 	"strings"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"github.com/homedepot/go-clouddriver/internal"
 	"github.com/homedepot/go-clouddriver/internal/kubernetes"
 	clouddriver "github.com/homedepot/go-clouddriver/pkg"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -26,6 +22,8 @@ func (cc *Controller) Enable(c *gin.Context, dm EnableManifestRequest) {
 	taskID := clouddriver.TaskIDFromContext(c)
 	namespace := dm.Location
 
+	cc.status(c, phaseEnable, fmt.Sprintf("Enabling manifest %s...", dm.ManifestName))
+
 	provider, err := cc.KubernetesProvider(dm.Account)
 	if err != nil {
 		clouddriver.Error(c, http.StatusBadRequest, err)
@@ -81,33 +79,13 @@ func (cc *Controller) Enable(c *gin.Context, dm EnableManifestRequest) {
 	}
 
 	var pods []*unstructured.Unstructured
-	// If the target manifest has load balancers and pods, list pods, grab those that has the owner UID
-	// of the target manifest, and patch those pods.
+	// If the target manifest has load balancers and pods, patch the pods it owns.
 	if len(loadBalancers) > 0 && hasPods(target) {
-		// Declare server side filtering options.
-		lo := metav1.ListOptions{
-			FieldSelector: "metadata.namespace=" + namespace,
-			LabelSelector: kubernetes.DefaultLabelSelector(),
-		}
-		// Declare a context with timeout.
-		ctx, cancel := context.WithTimeout(context.Background(), time.Second*internal.DefaultListTimeoutSeconds)
-		defer cancel()
-		// List resources with the context.
-		ul, err := provider.Client.ListResourceWithContext(ctx, "pods", lo)
+		pods, err = ownedPods(provider.Client, target, namespace)
 		if err != nil {
 			clouddriver.Error(c, http.StatusInternalServerError, err)
 			return
 		}
-		// Loop through all pods, finding all that are owned by the target manifest.
-		for _, u := range ul.Items {
-			for _, ownerReference := range u.GetOwnerReferences() {
-				if ownerReference.UID == target.GetUID() {
-					// Create a copy of the unstructured object since we access by reference.
-					u := u
-					pods = append(pods, &u)
-				}
-			}
-		}
 	}
 
 	for _, loadBalancer := range loadBalancers {
@@ -150,4 +128,6 @@ func (cc *Controller) Enable(c *gin.Context, dm EnableManifestRequest) {
 		clouddriver.Error(c, http.StatusInternalServerError, err)
 		return
 	}
+
+	cc.status(c, phaseEnable, "Enable manifest task completed successfully.")
 }
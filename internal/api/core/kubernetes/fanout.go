@@ -0,0 +1,241 @@
+package kubernetes
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/homedepot/go-clouddriver/internal/kubernetes"
+	clouddriver "github.com/homedepot/go-clouddriver/pkg"
+)
+
+const (
+	accountStatusSucceeded = "succeeded"
+	accountStatusFailed    = "failed"
+	accountStatusSkipped   = "skipped"
+)
+
+var errInvalidFanOutOperation = errors.New("fan out request must specify exactly one of deployManifest, patchManifest, or scaleManifest")
+
+// operation returns the name of the operation to fan out and a function
+// that performs it in a given account.
+func (fo FanOutManifestRequest) operation() (string, func(*Controller, *gin.Context, string), error) {
+	var (
+		name    string
+		perform func(*Controller, *gin.Context, string)
+		count   int
+	)
+
+	if fo.DeployManifest != nil {
+		count++
+		name = "deployManifest"
+		perform = func(cc *Controller, c *gin.Context, account string) {
+			dm := *fo.DeployManifest
+			dm.Account = account
+			cc.Deploy(c, dm)
+		}
+	}
+
+	if fo.PatchManifest != nil {
+		count++
+		name = "patchManifest"
+		perform = func(cc *Controller, c *gin.Context, account string) {
+			pm := *fo.PatchManifest
+			pm.Account = account
+			cc.Patch(c, pm)
+		}
+	}
+
+	if fo.ScaleManifest != nil {
+		count++
+		name = "scaleManifest"
+		perform = func(cc *Controller, c *gin.Context, account string) {
+			sm := *fo.ScaleManifest
+			sm.Account = account
+			cc.Scale(c, sm)
+		}
+	}
+
+	if count != 1 {
+		return "", nil, errInvalidFanOutOperation
+	}
+
+	return name, perform, nil
+}
+
+// FanOut performs the same deploy, patch, or scale in each account of the
+// request concurrently, adding the outcome in each account to the task's results.
+//
+// The operation is performed in each account in its own context, so a failure
+// in one account does not stop the others. Atomic deploys that fail are rolled
+// back in their account right away; those that succeed are rolled back with
+// the rest of the task if it fails.
+//
+// The task fails if the operation fails in more accounts than the failure threshold.
+func (cc *Controller) FanOut(c *gin.Context, fo FanOutManifestRequest) {
+	name, perform, err := fo.operation()
+	if err != nil {
+		clouddriver.Error(c, http.StatusBadRequest, err)
+		return
+	}
+
+	cc.status(c, phaseFanOut, fmt.Sprintf("Performing %s in %d account(s)...", name, len(fo.Accounts)))
+
+	providers, err := cc.KubernetesProvidersForAccountsWithTimeout(fo.Accounts, 0)
+	if err != nil {
+		clouddriver.Error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	pm := map[string]*kubernetes.Provider{}
+	for _, provider := range providers {
+		pm[provider.Name] = provider
+	}
+
+	parallelism := fo.Parallelism
+	if parallelism <= 0 || parallelism > len(fo.Accounts) {
+		parallelism = len(fo.Accounts)
+	}
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed int
+	)
+
+	sem := make(chan struct{}, parallelism)
+	ars := make([]clouddriver.AccountResult, len(fo.Accounts))
+	contexts := make([]*gin.Context, len(fo.Accounts))
+
+	for i, account := range fo.Accounts {
+		sem <- struct{}{}
+
+		mu.Lock()
+		exceeded := failed > fo.FailureThreshold
+		mu.Unlock()
+
+		if exceeded {
+			<-sem
+
+			ars[i] = clouddriver.AccountResult{Account: account, Status: accountStatusSkipped}
+
+			continue
+		}
+
+		ac := accountContext(c, pm[account])
+		contexts[i] = ac
+
+		wg.Add(1)
+
+		go func(i int, account string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			ars[i] = cc.performInAccount(ac, name, account, perform)
+
+			if ars[i].Status == accountStatusFailed {
+				mu.Lock()
+				failed++
+				mu.Unlock()
+			}
+		}(i, account)
+	}
+
+	wg.Wait()
+
+	for i, ac := range contexts {
+		if ac == nil {
+			continue
+		}
+
+		for _, ro := range results(ac) {
+			addResult(c, ro)
+		}
+
+		if ars[i].Status == accountStatusSucceeded {
+			c.Set(keyTaskDeployTransactions, append(deployTransactions(c), deployTransactions(ac)...))
+		}
+	}
+
+	addResult(c, clouddriver.TaskResultObject{
+		BoundArtifacts:                    []clouddriver.Artifact{},
+		CreatedArtifacts:                  []clouddriver.Artifact{},
+		DeployedNamesByLocation:           map[string][]string{},
+		ManifestNamesByNamespace:          map[string][]string{},
+		ManifestNamesByNamespaceToRefresh: map[string][]string{},
+		Manifests:                         []map[string]interface{}{},
+		AccountResults:                    ars,
+	})
+
+	if failed > fo.FailureThreshold {
+		clouddriver.Error(c, http.StatusInternalServerError,
+			fmt.Errorf("%s failed in %d of %d account(s)", name, failed, len(fo.Accounts)))
+		return
+	}
+
+	cc.status(c, phaseFanOut, fmt.Sprintf("%s succeeded in %d of %d account(s).",
+		name, len(fo.Accounts)-failed, len(fo.Accounts)))
+}
+
+// performInAccount performs a fanned out operation in a single account,
+// rolling back any atomic deploys it made if it fails.
+func (cc *Controller) performInAccount(c *gin.Context, name, account string,
+	perform func(*Controller, *gin.Context, string)) clouddriver.AccountResult {
+	ar := clouddriver.AccountResult{Account: account}
+
+	if _, ok := c.Get(keyTaskProvider); !ok {
+		ar.Status = accountStatusFailed
+		ar.Error = fmt.Sprintf("no provider available for account %s", account)
+		cc.status(c, phaseFanOut, fmt.Sprintf("Failed to perform %s in account %s: %s", name, account, ar.Error))
+
+		return ar
+	}
+
+	cc.status(c, phaseFanOut, fmt.Sprintf("Performing %s in account %s...", name, account))
+
+	perform(cc, c, account)
+
+	if len(c.Errors) > 0 {
+		cc.rollbackDeploys(c)
+
+		ar.Status = accountStatusFailed
+		ar.Error = c.Errors.Last().Error()
+		cc.status(c, phaseFanOut, fmt.Sprintf("Failed to perform %s in account %s: %s", name, account, ar.Error))
+
+		return ar
+	}
+
+	ar.Status = accountStatusSucceeded
+
+	return ar
+}
+
+// accountContext returns a copy of the task's context to perform an
+// operation in a single account with the given provider. Results and
+// deploy transactions start out empty so they can be merged back into
+// the task's context once the operation completes.
+func accountContext(c *gin.Context, provider *kubernetes.Provider) *gin.Context {
+	ac := c.Copy()
+	delete(ac.Keys, keyTaskResults)
+	delete(ac.Keys, keyTaskDeployTransactions)
+
+	if provider != nil {
+		ac.Set(keyTaskProvider, provider)
+	}
+
+	return ac
+}
+
+// provider returns the provider of an account, reusing the provider
+// set in the context by a fanned out operation if there is one.
+func (cc *Controller) provider(c *gin.Context, account string) (*kubernetes.Provider, error) {
+	if v, ok := c.Get(keyTaskProvider); ok {
+		if provider := v.(*kubernetes.Provider); provider.Name == account {
+			return provider, nil
+		}
+	}
+
+	return cc.KubernetesProvider(account)
+}
@@ -0,0 +1,186 @@
+package kubernetes_test
+
+import (
+	"errors"
+	"net/http"
+
+	. "github.com/homedepot/go-clouddriver/internal/api/core/kubernetes"
+	"github.com/homedepot/go-clouddriver/internal/kubernetes"
+	"github.com/homedepot/go-clouddriver/internal/kubernetes/kubernetesfakes"
+	clouddriver "github.com/homedepot/go-clouddriver/pkg"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/rest"
+)
+
+var _ = Describe("FanOut", func() {
+	var (
+		fanOutManifestRequest FanOutManifestRequest
+		fakeFailingKubeClient *kubernetesfakes.FakeClient
+	)
+
+	BeforeEach(func() {
+		setup()
+
+		sm := newScaleManifestRequest()
+		fanOutManifestRequest = FanOutManifestRequest{
+			Accounts:      []string{"account-1", "account-2", "account-3"},
+			ScaleManifest: &sm,
+		}
+
+		fakeSQLClient.ListKubernetesProvidersReturns([]kubernetes.Provider{
+			{Name: "account-1", Host: "http://account-1"},
+			{Name: "account-2", Host: "http://account-2"},
+			{Name: "account-3", Host: "http://account-3"},
+		}, nil)
+
+		// Each scale gets its own copy of the live object, as accounts are scaled concurrently.
+		fakeKubeClient.GetStub = func(string, string, string) (*unstructured.Unstructured, error) {
+			return &unstructured.Unstructured{Object: map[string]interface{}{}}, nil
+		}
+
+		fakeFailingKubeClient = &kubernetesfakes.FakeClient{}
+		fakeFailingKubeClient.GetStub = fakeKubeClient.GetStub
+		fakeFailingKubeClient.ApplyReturns(kubernetes.Metadata{}, errors.New("error applying manifest"))
+
+		fakeKubeController.NewClientStub = func(config *rest.Config) (kubernetes.Client, error) {
+			if config.Host == "http://account-2" {
+				return fakeFailingKubeClient, nil
+			}
+
+			return fakeKubeClient, nil
+		}
+	})
+
+	JustBeforeEach(func() {
+		kubernetesController.FanOut(c, fanOutManifestRequest)
+	})
+
+	accountResults := func() []clouddriver.AccountResult {
+		v, ok := c.Get("TaskResults")
+		Expect(ok).To(BeTrue())
+		ro := v.([]clouddriver.TaskResultObject)
+
+		return ro[len(ro)-1].AccountResults
+	}
+
+	When("no operation is given", func() {
+		BeforeEach(func() {
+			fanOutManifestRequest.ScaleManifest = nil
+		})
+
+		It("returns an error", func() {
+			Expect(c.Writer.Status()).To(Equal(http.StatusBadRequest))
+			Expect(c.Errors.Last().Error()).To(Equal("fan out request must specify exactly one of deployManifest, patchManifest, or scaleManifest"))
+		})
+	})
+
+	When("more than one operation is given", func() {
+		BeforeEach(func() {
+			pm := newPatchManifestRequest()
+			fanOutManifestRequest.PatchManifest = &pm
+		})
+
+		It("returns an error", func() {
+			Expect(c.Writer.Status()).To(Equal(http.StatusBadRequest))
+			Expect(c.Errors.Last().Error()).To(Equal("fan out request must specify exactly one of deployManifest, patchManifest, or scaleManifest"))
+		})
+	})
+
+	When("listing the providers returns an error", func() {
+		BeforeEach(func() {
+			fakeSQLClient.ListKubernetesProvidersReturns(nil, errors.New("error listing providers"))
+		})
+
+		It("returns an error", func() {
+			Expect(c.Writer.Status()).To(Equal(http.StatusInternalServerError))
+			Expect(c.Errors.Last().Error()).To(Equal("internal: error listing kubernetes providers: error listing providers"))
+		})
+	})
+
+	When("the operation fails in more accounts than the failure threshold", func() {
+		It("returns an error", func() {
+			Expect(c.Writer.Status()).To(Equal(http.StatusInternalServerError))
+			Expect(c.Errors.Last().Error()).To(Equal("scaleManifest failed in 1 of 3 account(s)"))
+		})
+
+		It("reports the outcome in each account", func() {
+			Expect(accountResults()).To(Equal([]clouddriver.AccountResult{
+				{Account: "account-1", Status: "succeeded"},
+				{Account: "account-2", Status: "failed", Error: "error applying manifest"},
+				{Account: "account-3", Status: "succeeded"},
+			}))
+		})
+	})
+
+	When("the operation fails in no more accounts than the failure threshold", func() {
+		BeforeEach(func() {
+			fanOutManifestRequest.FailureThreshold = 1
+		})
+
+		It("succeeds and reports the failed account", func() {
+			Expect(c.Errors).To(BeEmpty())
+			Expect(accountResults()[1]).To(Equal(clouddriver.AccountResult{
+				Account: "account-2",
+				Status:  "failed",
+				Error:   "error applying manifest",
+			}))
+		})
+	})
+
+	When("an account has no provider", func() {
+		BeforeEach(func() {
+			fanOutManifestRequest.Accounts = []string{"account-1", "account-4"}
+		})
+
+		It("reports the account as failed", func() {
+			Expect(c.Errors.Last().Error()).To(Equal("scaleManifest failed in 1 of 2 account(s)"))
+			Expect(accountResults()).To(Equal([]clouddriver.AccountResult{
+				{Account: "account-1", Status: "succeeded"},
+				{Account: "account-4", Status: "failed", Error: "no provider available for account account-4"},
+			}))
+		})
+	})
+
+	When("the failure threshold is exceeded before all accounts are started", func() {
+		BeforeEach(func() {
+			fanOutManifestRequest.Parallelism = 1
+			fanOutManifestRequest.Accounts = []string{"account-2", "account-1", "account-3"}
+		})
+
+		It("skips the remaining accounts", func() {
+			Expect(c.Errors.Last().Error()).To(Equal("scaleManifest failed in 1 of 3 account(s)"))
+			Expect(accountResults()).To(Equal([]clouddriver.AccountResult{
+				{Account: "account-2", Status: "failed", Error: "error applying manifest"},
+				{Account: "account-1", Status: "skipped"},
+				{Account: "account-3", Status: "skipped"},
+			}))
+			Expect(fakeKubeClient.ApplyCallCount()).To(Equal(0))
+		})
+	})
+
+	When("it succeeds", func() {
+		BeforeEach(func() {
+			fanOutManifestRequest.Accounts = []string{"account-1", "account-3"}
+		})
+
+		It("performs the operation in each account using its listed provider", func() {
+			Expect(c.Errors).To(BeEmpty())
+			Expect(fakeSQLClient.GetKubernetesProviderCallCount()).To(Equal(0))
+			Expect(fakeKubeClient.ApplyCallCount()).To(Equal(2))
+			Expect(fakeSQLClient.CreateKubernetesResourceCallCount()).To(Equal(2))
+
+			accounts := []string{}
+			for i := 0; i < fakeSQLClient.CreateKubernetesResourceCallCount(); i++ {
+				accounts = append(accounts, fakeSQLClient.CreateKubernetesResourceArgsForCall(i).AccountName)
+			}
+
+			Expect(accounts).To(ConsistOf("account-1", "account-3"))
+			Expect(accountResults()).To(Equal([]clouddriver.AccountResult{
+				{Account: "account-1", Status: "succeeded"},
+				{Account: "account-3", Status: "succeeded"},
+			}))
+		})
+	})
+})
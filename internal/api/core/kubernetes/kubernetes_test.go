@@ -34,6 +34,8 @@ var (
 	undoRolloutManifestRequest      UndoRolloutManifestRequest
 	rollingRestartManifestRequest   RollingRestartManifestRequest
 	patchManifestRequest            PatchManifestRequest
+	pauseRolloutManifestRequest     PauseRolloutManifestRequest
+	resumeRolloutManifestRequest    ResumeRolloutManifestRequest
 	runJobRequest                   RunJobRequest
 	clusterScopedProvider           kubernetes.Provider
 	namespaceScopedProvider         kubernetes.Provider
@@ -130,6 +132,8 @@ func setup() {
 	undoRolloutManifestRequest = newUndoRolloutManifestRequest()
 	rollingRestartManifestRequest = newRollingRestartManifestRequest()
 	patchManifestRequest = newPatchManifestRequest()
+	pauseRolloutManifestRequest = newPauseRolloutManifestRequest()
+	resumeRolloutManifestRequest = newResumeRolloutManifestRequest()
 	runJobRequest = newRunJobRequest()
 }
 
@@ -263,6 +267,20 @@ func newRollingRestartManifestRequest() RollingRestartManifestRequest {
 	}
 }
 
+func newPauseRolloutManifestRequest() PauseRolloutManifestRequest {
+	return PauseRolloutManifestRequest{
+		Account:      "spin-cluster-account",
+		ManifestName: "deployment test-deployment",
+	}
+}
+
+func newResumeRolloutManifestRequest() ResumeRolloutManifestRequest {
+	return ResumeRolloutManifestRequest{
+		Account:      "spin-cluster-account",
+		ManifestName: "deployment test-deployment",
+	}
+}
+
 func newPatchManifestRequest() PatchManifestRequest {
 	return PatchManifestRequest{
 		Account:      "spin-cluster-account",
@@ -3,6 +3,7 @@ package kubernetes
 import (
 	"encoding/json"
 
+	"github.com/homedepot/go-clouddriver/internal/kubernetes"
 	"github.com/homedepot/go-clouddriver/internal/kubernetes/manifest"
 	clouddriver "github.com/homedepot/go-clouddriver/pkg"
 	v1 "k8s.io/api/core/v1"
@@ -15,20 +16,18 @@ type OperationsResponse struct {
 
 type Operations []Operation
 
-type Operation struct {
-	CleanupArtifacts       *CleanupArtifactsRequest       `json:"cleanupArtifacts"`
-	DeleteManifest         *DeleteManifestRequest         `json:"deleteManifest"`
-	DeployManifest         *DeployManifestRequest         `json:"deployManifest"`
-	DisableManifest        *DisableManifestRequest        `json:"disableManifest"`
-	EnableManifest         *EnableManifestRequest         `json:"enableManifest"`
-	PatchManifest          *PatchManifestRequest          `json:"patchManifest"`
-	RollingRestartManifest *RollingRestartManifestRequest `json:"rollingRestartManifest"`
-	RunJob                 *RunJobRequest                 `json:"runJob"`
-	ScaleManifest          *ScaleManifestRequest          `json:"scaleManifest"`
-	UndoRolloutManifest    *UndoRolloutManifestRequest    `json:"undoRolloutManifest"`
-}
+// Operation is a single kubernetes operation, mapping the name of the
+// operation (such as "deployManifest") to its decoded request.
+// Only operations registered in this package can be decoded.
+type Operation map[string]interface{}
 
 type DeployManifestRequest struct {
+	// DryRun performs a server-side dry run of the deployment, reporting
+	// the diff of each manifest against the live object without changing the cluster.
+	DryRun bool `json:"dryRun"`
+	// Atomic rolls back the manifests applied by the deployment if any
+	// manifest fails to deploy, deleting created objects and restoring updated ones.
+	Atomic            bool                     `json:"atomic"`
 	EnableTraffic     bool                     `json:"enableTraffic"`
 	NamespaceOverride string                   `json:"namespaceOverride"`
 	CloudProvider     string                   `json:"cloudProvider"`
@@ -42,6 +41,36 @@ type DeployManifestRequest struct {
 	SkipExpressionEvaluation bool                   `json:"skipExpressionEvaluation"`
 	RequiredArtifacts        []clouddriver.Artifact `json:"requiredArtifacts"`
 	OptionalArtifacts        []clouddriver.Artifact `json:"optionalArtifacts"`
+	StabilityOptions
+}
+
+// StabilityOptions are the options of operations that can wait for the
+// resources they change to become stable before completing the task.
+type StabilityOptions struct {
+	// WaitForStability keeps the task running until each affected resource
+	// reports a stable status, failing the task if a resource fails or doesn't
+	// become stable within StabilityTimeoutSeconds (10 minutes by default).
+	WaitForStability        bool `json:"waitForStability"`
+	StabilityTimeoutSeconds int  `json:"stabilityTimeoutSeconds"`
+}
+
+// FanOutManifestRequest performs the same deploy, patch, or scale in each
+// of a list of accounts concurrently. Exactly one of the operations must be
+// given; its account is ignored in favor of the accounts of the request.
+type FanOutManifestRequest struct {
+	// Accounts are the accounts the operation is performed in. They must be
+	// listed, as providers have no labels for a selector to match.
+	Accounts []string `json:"accounts"`
+	// Parallelism is the maximum number of accounts the operation is
+	// performed in at once. By default it is performed in all of them at once.
+	Parallelism int `json:"parallelism"`
+	// FailureThreshold is the number of accounts the operation can fail in
+	// without failing the task. Once exceeded, the operation is not started
+	// in any remaining accounts.
+	FailureThreshold int                    `json:"failureThreshold"`
+	DeployManifest   *DeployManifestRequest `json:"deployManifest"`
+	PatchManifest    *PatchManifestRequest  `json:"patchManifest"`
+	ScaleManifest    *ScaleManifestRequest  `json:"scaleManifest"`
 }
 
 type TrafficManagement struct {
@@ -71,6 +100,27 @@ type EnableManifestRequest struct {
 	Account       string `json:"account"`
 }
 
+// ShiftTrafficManifestRequest shifts the traffic of a cluster's load balancers
+// from one server group to another in steps, such as for a canary.
+type ShiftTrafficManifestRequest struct {
+	App           string `json:"app"`
+	CloudProvider string `json:"cloudProvider"`
+	// SourceManifestName is the server group traffic is shifted from, like 'ReplicaSet test-rs-v001'.
+	SourceManifestName string `json:"sourceManifestName"`
+	// TargetManifestName is the server group traffic is shifted to, like 'ReplicaSet test-rs-v002'.
+	TargetManifestName string `json:"targetManifestName"`
+	// Steps are the increasing percentages of traffic shifted to the target,
+	// such as [10, 50, 100]. At each step that percentage of the target's pods
+	// are attached to its load balancers and the same percentage of the
+	// source's pods are detached from them.
+	Steps []int `json:"steps"`
+	// PauseSeconds is how long to wait between steps.
+	PauseSeconds int    `json:"pauseSeconds"`
+	Location     string `json:"location"`
+	User         string `json:"user"`
+	Account      string `json:"account"`
+}
+
 type PatchManifestRequest struct {
 	App      string `json:"app"`
 	Cluster  string `json:"cluster"`
@@ -109,6 +159,15 @@ type ManifestResponse struct {
 	Warnings  []interface{}           `json:"warnings"`
 }
 
+// ManifestManagedFieldsResponse lists the field managers of a manifest
+// and the fields each of them owns.
+type ManifestManagedFieldsResponse struct {
+	Account       string                     `json:"account"`
+	Location      string                     `json:"location"`
+	Name          string                     `json:"name"`
+	ManagedFields []kubernetes.ManagedFields `json:"managedFields"`
+}
+
 type ManifestCoordinatesResponse struct {
 	Kind      string `json:"kind"`
 	Name      string `json:"name"`
@@ -127,6 +186,7 @@ type ScaleManifestRequest struct {
 	Location      string `json:"location"`
 	User          string `json:"user"`
 	Account       string `json:"account"`
+	StabilityOptions
 }
 
 type CleanupArtifactsRequest struct {
@@ -172,6 +232,23 @@ type UndoRolloutManifestRequest struct {
 	User             string `json:"user"`
 	Account          string `json:"account"`
 	Revision         string `json:"revision"`
+	StabilityOptions
+}
+
+type PauseRolloutManifestRequest struct {
+	CloudProvider string `json:"cloudProvider"`
+	ManifestName  string `json:"manifestName"`
+	Location      string `json:"location"`
+	User          string `json:"user"`
+	Account       string `json:"account"`
+}
+
+type ResumeRolloutManifestRequest struct {
+	CloudProvider string `json:"cloudProvider"`
+	ManifestName  string `json:"manifestName"`
+	Location      string `json:"location"`
+	User          string `json:"user"`
+	Account       string `json:"account"`
 }
 
 type RollingRestartManifestRequest struct {
@@ -180,6 +257,7 @@ type RollingRestartManifestRequest struct {
 	Location      string `json:"location"`
 	User          string `json:"user"`
 	Account       string `json:"account"`
+	StabilityOptions
 }
 
 type RunJobRequest struct {
@@ -190,14 +268,20 @@ type RunJobRequest struct {
 	Manifest          map[string]interface{} `json:"manifest"`
 	RequiredArtifacts []clouddriver.Artifact `json:"requiredArtifacts"`
 	OptionalArtifacts []clouddriver.Artifact `json:"optionalArtifacts"`
-	// PreconfiguredJobParameters []struct {
-	// 	Mapping     string `json:"mapping"`
-	// 	Name        string `json:"name"`
-	// 	Description string `json:"description"`
-	// 	Label       string `json:"label"`
-	// 	Type        string `json:"type"`
-	// 	Order       int    `json:"order"`
-	// } `json:"preconfiguredJobParameters"`
 	// WaitForCompletion bool   `json:"waitForCompletion"`
 	// Source            string `json:"source"`
 }
+
+// RunPreconfiguredJobRequest runs a preconfigured job, rendering the
+// parameters into its manifest before running it as a RunJobRequest.
+type RunPreconfiguredJobRequest struct {
+	Account       string `json:"account"`
+	Application   string `json:"application"`
+	CloudProvider string `json:"cloudProvider"`
+	// PreconfiguredJob is the name of the preconfigured job to run.
+	PreconfiguredJob string `json:"preconfiguredJob"`
+	// Parameters are the values of the preconfigured job's parameters by name.
+	Parameters        map[string]string      `json:"parameters"`
+	RequiredArtifacts []clouddriver.Artifact `json:"requiredArtifacts"`
+	OptionalArtifacts []clouddriver.Artifact `json:"optionalArtifacts"`
+}
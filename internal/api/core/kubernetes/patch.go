@@ -18,7 +18,9 @@ func (cc *Controller) Patch(c *gin.Context, pm PatchManifestRequest) {
 	taskID := clouddriver.TaskIDFromContext(c)
 	namespace := pm.Location
 
-	provider, err := cc.KubernetesProvider(pm.Account)
+	cc.status(c, phasePatch, fmt.Sprintf("Patching manifest %s...", pm.ManifestName))
+
+	provider, err := cc.provider(c, pm.Account)
 	if err != nil {
 		clouddriver.Error(c, http.StatusBadRequest, err)
 		return
@@ -125,4 +127,6 @@ func (cc *Controller) Patch(c *gin.Context, pm PatchManifestRequest) {
 		clouddriver.Error(c, http.StatusInternalServerError, err)
 		return
 	}
+
+	cc.status(c, phasePatch, "Patch manifest task completed successfully.")
 }
@@ -0,0 +1,151 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	// ErrUnsupportedOperation is returned when decoding an operation
+	// that has no handler registered.
+	ErrUnsupportedOperation = errors.New("unsupported kubernetes operation")
+
+	registry       = map[string]operation{}
+	operationNames []string
+)
+
+// operation holds the functions needed to decode, authorize, and perform
+// a registered kubernetes operation.
+type operation struct {
+	decode   func(json.RawMessage) (interface{}, error)
+	accounts func(interface{}) []string
+	perform  func(*Controller, *gin.Context, interface{})
+}
+
+// register adds a kubernetes operation to the registry. Operations are
+// performed in the order they are registered when a single Operation
+// contains more than one of them.
+func register[T any](name string, perform func(*Controller, *gin.Context, T), account func(T) string) {
+	registerMultiAccount(name, perform, func(req T) []string {
+		return []string{account(req)}
+	})
+}
+
+// registerMultiAccount adds a kubernetes operation that acts on
+// more than one account to the registry.
+func registerMultiAccount[T any](name string, perform func(*Controller, *gin.Context, T), accounts func(T) []string) {
+	registry[name] = operation{
+		decode: func(b json.RawMessage) (interface{}, error) {
+			var req T
+
+			err := json.Unmarshal(b, &req)
+
+			return req, err
+		},
+		accounts: func(req interface{}) []string {
+			return accounts(req.(T))
+		},
+		perform: func(cc *Controller, c *gin.Context, req interface{}) {
+			perform(cc, c, req.(T))
+		},
+	}
+	operationNames = append(operationNames, name)
+}
+
+func init() {
+	register("deployManifest", (*Controller).Deploy,
+		func(r DeployManifestRequest) string { return r.Account })
+	register("deleteManifest", (*Controller).Delete,
+		func(r DeleteManifestRequest) string { return r.Account })
+	register("disableManifest", (*Controller).Disable,
+		func(r DisableManifestRequest) string { return r.Account })
+	register("enableManifest", (*Controller).Enable,
+		func(r EnableManifestRequest) string { return r.Account })
+	register("scaleManifest", (*Controller).Scale,
+		func(r ScaleManifestRequest) string { return r.Account })
+	register("cleanupArtifacts", (*Controller).CleanupArtifacts,
+		func(r CleanupArtifactsRequest) string { return r.Account })
+	register("rollingRestartManifest", (*Controller).RollingRestart,
+		func(r RollingRestartManifestRequest) string { return r.Account })
+	register("runJob", (*Controller).RunJob,
+		func(r RunJobRequest) string { return r.Account })
+	register("runPreconfiguredJob", (*Controller).RunPreconfiguredJob,
+		func(r RunPreconfiguredJobRequest) string { return r.Account })
+	register("undoRolloutManifest", (*Controller).Rollback,
+		func(r UndoRolloutManifestRequest) string { return r.Account })
+	register("patchManifest", (*Controller).Patch,
+		func(r PatchManifestRequest) string { return r.Account })
+	register("pauseRolloutManifest", (*Controller).PauseRollout,
+		func(r PauseRolloutManifestRequest) string { return r.Account })
+	register("resumeRolloutManifest", (*Controller).ResumeRollout,
+		func(r ResumeRolloutManifestRequest) string { return r.Account })
+	register("shiftTrafficManifest", (*Controller).ShiftTraffic,
+		func(r ShiftTrafficManifestRequest) string { return r.Account })
+	registerMultiAccount("fanOutManifest", (*Controller).FanOut,
+		func(r FanOutManifestRequest) []string { return r.Accounts })
+}
+
+// UnmarshalJSON decodes each operation using its registered request type,
+// returning ErrUnsupportedOperation for any operation that is not registered.
+func (o *Operation) UnmarshalJSON(b []byte) error {
+	raw := map[string]json.RawMessage{}
+
+	err := json.Unmarshal(b, &raw)
+	if err != nil {
+		return err
+	}
+
+	op := Operation{}
+
+	for name, r := range raw {
+		registered, ok := registry[name]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrUnsupportedOperation, name)
+		}
+
+		// Treat explicit nulls as if the operation was not requested.
+		if string(r) == "null" {
+			continue
+		}
+
+		req, err := registered.decode(r)
+		if err != nil {
+			return err
+		}
+
+		op[name] = req
+	}
+
+	*o = op
+
+	return nil
+}
+
+// Accounts returns the accounts the operation acts on.
+func (o Operation) Accounts() []string {
+	accounts := []string{}
+
+	for _, name := range operationNames {
+		if req, ok := o[name]; ok {
+			for _, account := range registry[name].accounts(req) {
+				if account != "" {
+					accounts = append(accounts, account)
+				}
+			}
+		}
+	}
+
+	return accounts
+}
+
+// perform performs each operation in the order they were registered.
+func (o Operation) perform(cc *Controller, c *gin.Context) {
+	for _, name := range operationNames {
+		if req, ok := o[name]; ok {
+			registry[name].perform(cc, c, req)
+		}
+	}
+}
@@ -0,0 +1,330 @@
+package kubernetes_test
+
+import (
+	"encoding/json"
+	"errors"
+
+	. "github.com/homedepot/go-clouddriver/internal/api/core/kubernetes"
+	"github.com/homedepot/go-clouddriver/internal/kubernetes"
+	clouddriver "github.com/homedepot/go-clouddriver/pkg"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var _ = Describe("Operation", func() {
+	var (
+		b   []byte
+		ko  Operations
+		err error
+	)
+
+	BeforeEach(func() {
+		setup()
+
+		b = []byte(`[
+			{
+				"scaleManifest": {
+					"account": "spin-cluster-account",
+					"manifestName": "deployment test-deployment",
+					"replicas": "16"
+				},
+				"deleteManifest": null
+			},
+			{
+				"rollingRestartManifest": {
+					"account": "spin-cluster-account-2",
+					"manifestName": "deployment test-deployment"
+				}
+			}
+		]`)
+	})
+
+	JustBeforeEach(func() {
+		ko = Operations{}
+		err = json.Unmarshal(b, &ko)
+	})
+
+	Describe("#UnmarshalJSON", func() {
+		When("the operation is not supported", func() {
+			BeforeEach(func() {
+				b = []byte(`[{ "resizeServerGroup": { "account": "spin-cluster-account" } }]`)
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(errors.Is(err, ErrUnsupportedOperation)).To(BeTrue())
+				Expect(err.Error()).To(Equal("unsupported kubernetes operation: resizeServerGroup"))
+			})
+		})
+
+		When("the request is bad data", func() {
+			BeforeEach(func() {
+				b = []byte(`[{ "scaleManifest": { "account": 1 } }]`)
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(errors.Is(err, ErrUnsupportedOperation)).To(BeFalse())
+			})
+		})
+
+		When("it succeeds", func() {
+			It("decodes each operation into its request", func() {
+				Expect(err).To(BeNil())
+				Expect(ko).To(HaveLen(2))
+				Expect(ko[0]).To(HaveLen(1))
+				sm, ok := ko[0]["scaleManifest"].(ScaleManifestRequest)
+				Expect(ok).To(BeTrue())
+				Expect(sm.Replicas).To(Equal("16"))
+				_, ok = ko[1]["rollingRestartManifest"].(RollingRestartManifestRequest)
+				Expect(ok).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("#Accounts", func() {
+		It("returns the accounts of each operation", func() {
+			Expect(err).To(BeNil())
+			Expect(ko[0].Accounts()).To(Equal([]string{"spin-cluster-account"}))
+			Expect(ko[1].Accounts()).To(Equal([]string{"spin-cluster-account-2"}))
+		})
+
+		When("the operation acts on more than one account", func() {
+			BeforeEach(func() {
+				b = []byte(`[{
+					"fanOutManifest": {
+						"accounts": ["spin-cluster-account", "spin-cluster-account-2"],
+						"scaleManifest": { "manifestName": "deployment test-deployment", "replicas": "16" }
+					}
+				}]`)
+			})
+
+			It("returns each account", func() {
+				Expect(err).To(BeNil())
+				Expect(ko[0].Accounts()).To(Equal([]string{"spin-cluster-account", "spin-cluster-account-2"}))
+			})
+		})
+	})
+
+	Describe("#Execute", func() {
+		JustBeforeEach(func() {
+			kubernetesController.Execute(c, ko)
+		})
+
+		When("an operation returns an error", func() {
+			BeforeEach(func() {
+				fakeKubeClient.GetReturnsOnCall(0, nil, errors.New("error getting manifest"))
+			})
+
+			It("stops performing operations", func() {
+				Expect(c.Errors.Last().Error()).To(Equal("error getting manifest"))
+				Expect(fakeKubeClient.GetCallCount()).To(Equal(1))
+				Expect(fakeKubeClient.ApplyCallCount()).To(Equal(0))
+			})
+		})
+
+		When("an operation fails with server-side apply conflicts", func() {
+			BeforeEach(func() {
+				fakeKubeClient.ApplyReturns(kubernetes.Metadata{}, &kubernetes.ConflictError{
+					Conflicts: []clouddriver.FieldConflict{
+						{
+							Field:    ".spec.replicas",
+							Managers: []string{"kubectl-client-side-apply"},
+						},
+					},
+				})
+			})
+
+			It("adds the conflicts to the results", func() {
+				Expect(c.Errors).To(HaveLen(1))
+				Expect(fakeKubeClient.ApplyCallCount()).To(Equal(1))
+				v, ok := c.Get("TaskResults")
+				Expect(ok).To(BeTrue())
+				ro := v.([]clouddriver.TaskResultObject)
+				Expect(ro).To(HaveLen(1))
+				Expect(ro[0].FieldConflicts).To(Equal([]clouddriver.FieldConflict{
+					{
+						Field:    ".spec.replicas",
+						Managers: []string{"kubectl-client-side-apply"},
+					},
+				}))
+			})
+		})
+
+		When("an operation fails after atomic deploys", func() {
+			BeforeEach(func() {
+				b, _ = json.Marshal([]map[string]interface{}{
+					{
+						"deployManifest": map[string]interface{}{
+							"account": "test-account-1",
+							"atomic":  true,
+							"manifests": []map[string]interface{}{
+								{
+									"kind":       "ServiceAccount",
+									"apiVersion": "v1",
+									"metadata": map[string]interface{}{
+										"namespace": "default",
+										"name":      "test-service-account",
+									},
+								},
+							},
+						},
+					},
+					{
+						"deployManifest": map[string]interface{}{
+							"account": "test-account-2",
+							"atomic":  true,
+							"manifests": []map[string]interface{}{
+								{
+									"kind":       "Service",
+									"apiVersion": "v1",
+									"metadata": map[string]interface{}{
+										"namespace": "default",
+										"name":      "test-service",
+									},
+								},
+								{
+									"kind":       "Pod",
+									"apiVersion": "v1",
+									"metadata": map[string]interface{}{
+										"namespace": "default",
+										"name":      "test-pod",
+									},
+								},
+							},
+						},
+					},
+				})
+				fakeKubeClient.GetStub = func(kind, name, namespace string) (*unstructured.Unstructured, error) {
+					if name == "test-service-account" {
+						return &unstructured.Unstructured{
+							Object: map[string]interface{}{
+								"kind":       "ServiceAccount",
+								"apiVersion": "v1",
+								"metadata": map[string]interface{}{
+									"namespace":       "default",
+									"name":            "test-service-account",
+									"resourceVersion": "100",
+									"uid":             "test-uid",
+								},
+								"status": map[string]interface{}{},
+							},
+						}, nil
+					}
+
+					return nil, k8serrors.NewNotFound(schema.GroupResource{Resource: kind}, name)
+				}
+				fakeKubeClient.ApplyStub = func(u *unstructured.Unstructured) (kubernetes.Metadata, error) {
+					if u.GetKind() == "Pod" {
+						return kubernetes.Metadata{}, errors.New("error applying manifest")
+					}
+
+					return kubernetes.Metadata{Name: u.GetName()}, nil
+				}
+			})
+
+			It("deletes created manifests", func() {
+				Expect(c.Errors.Last().Error()).To(Equal("error applying manifest (kind: Pod, apiVersion: v1, name: test-pod-v000): error applying manifest"))
+				Expect(fakeKubeClient.DeleteResourceByKindAndNameAndNamespaceCallCount()).To(Equal(2))
+				kind, name, namespace, _ := fakeKubeClient.DeleteResourceByKindAndNameAndNamespaceArgsForCall(0)
+				Expect(kind).To(Equal("Pod"))
+				Expect(name).To(Equal("test-pod-v000"))
+				Expect(namespace).To(Equal("default"))
+				kind, name, _, _ = fakeKubeClient.DeleteResourceByKindAndNameAndNamespaceArgsForCall(1)
+				Expect(kind).To(Equal("Service"))
+				Expect(name).To(Equal("test-service"))
+			})
+
+			It("restores updated manifests of each account", func() {
+				Expect(fakeKubeClient.ReplaceCallCount()).To(Equal(1))
+				u := fakeKubeClient.ReplaceArgsForCall(0)
+				Expect(u.GetName()).To(Equal("test-service-account"))
+				Expect(u.GetResourceVersion()).To(BeEmpty())
+				Expect(u.GetUID()).To(BeEmpty())
+				_, found, _ := unstructured.NestedFieldNoCopy(u.Object, "status")
+				Expect(found).To(BeFalse())
+			})
+
+			It("deletes the resource entries of the rolled back manifests", func() {
+				Expect(fakeSQLClient.CreateKubernetesResourceCallCount()).To(Equal(2))
+				Expect(fakeSQLClient.DeleteKubernetesResourceCallCount()).To(Equal(2))
+				Expect(fakeSQLClient.DeleteKubernetesResourceArgsForCall(0)).To(Equal(fakeSQLClient.CreateKubernetesResourceArgsForCall(1).ID))
+				Expect(fakeSQLClient.DeleteKubernetesResourceArgsForCall(1)).To(Equal(fakeSQLClient.CreateKubernetesResourceArgsForCall(0).ID))
+			})
+
+			It("adds the rolled back manifests to the results", func() {
+				v, ok := c.Get("TaskResults")
+				Expect(ok).To(BeTrue())
+				ro := v.([]clouddriver.TaskResultObject)
+				Expect(ro).To(HaveLen(1))
+				Expect(ro[0].RolledBackManifests).To(Equal([]clouddriver.RolledBackManifest{
+					{
+						Account:   "test-account-2",
+						Kind:      "Pod",
+						Name:      "test-pod-v000",
+						Namespace: "default",
+						Action:    "deleted",
+					},
+					{
+						Account:   "test-account-2",
+						Kind:      "Service",
+						Name:      "test-service",
+						Namespace: "default",
+						Action:    "deleted",
+					},
+					{
+						Account:   "test-account-1",
+						Kind:      "ServiceAccount",
+						Name:      "test-service-account",
+						Namespace: "default",
+						Action:    "restored",
+					},
+				}))
+			})
+
+			When("rolling back a manifest returns an error", func() {
+				BeforeEach(func() {
+					fakeKubeClient.ReplaceReturns(kubernetes.Metadata{}, errors.New("error replacing manifest"))
+				})
+
+				It("reports the error and rolls back the other manifests", func() {
+					Expect(fakeKubeClient.DeleteResourceByKindAndNameAndNamespaceCallCount()).To(Equal(2))
+					v, _ := c.Get("TaskResults")
+					ro := v.([]clouddriver.TaskResultObject)
+					Expect(ro[0].RolledBackManifests).To(HaveLen(3))
+					Expect(ro[0].RolledBackManifests[2].Error).To(Equal("error replacing manifest"))
+				})
+
+				It("keeps the resource entry of the manifest", func() {
+					Expect(fakeSQLClient.DeleteKubernetesResourceCallCount()).To(Equal(1))
+					Expect(fakeSQLClient.DeleteKubernetesResourceArgsForCall(0)).To(Equal(fakeSQLClient.CreateKubernetesResourceArgsForCall(1).ID))
+				})
+			})
+
+			When("deleting a resource entry returns an error", func() {
+				BeforeEach(func() {
+					fakeSQLClient.DeleteKubernetesResourceReturns(errors.New("error deleting resource"))
+				})
+
+				It("reports the error", func() {
+					v, _ := c.Get("TaskResults")
+					ro := v.([]clouddriver.TaskResultObject)
+					Expect(ro[0].RolledBackManifests).To(HaveLen(3))
+					Expect(ro[0].RolledBackManifests[0].Error).To(BeEmpty())
+					Expect(ro[0].RolledBackManifests[1].Error).To(Equal("error deleting resource entry: error deleting resource"))
+				})
+			})
+		})
+
+		When("it succeeds", func() {
+			It("performs each operation", func() {
+				Expect(c.Errors).To(BeEmpty())
+				Expect(fakeKubeClient.ApplyCallCount()).To(Equal(2))
+				Expect(fakeSQLClient.CreateKubernetesResourceCallCount()).To(Equal(2))
+			})
+		})
+	})
+})
@@ -19,6 +19,8 @@ func (cc *Controller) RollingRestart(c *gin.Context, rr RollingRestartManifestRe
 	taskID := clouddriver.TaskIDFromContext(c)
 	namespace := rr.Location
 
+	cc.status(c, phaseRollingRestart, fmt.Sprintf("Restarting manifest %s...", rr.ManifestName))
+
 	provider, err := cc.KubernetesProvider(rr.Account)
 	if err != nil {
 		clouddriver.Error(c, http.StatusBadRequest, err)
@@ -94,4 +96,14 @@ func (cc *Controller) RollingRestart(c *gin.Context, rr RollingRestartManifestRe
 		clouddriver.Error(c, http.StatusInternalServerError, err)
 		return
 	}
+
+	if rr.WaitForStability {
+		err = cc.waitForStability(c, provider, phaseRollingRestart, rr.StabilityOptions, []kubernetes.Metadata{meta})
+		if err != nil {
+			clouddriver.Error(c, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	cc.status(c, phaseRollingRestart, "Rolling restart manifest task completed successfully.")
 }
@@ -3,6 +3,7 @@ package kubernetes
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"sort"
 	"strconv"
@@ -47,6 +48,8 @@ func (cc *Controller) Rollback(c *gin.Context, ur UndoRolloutManifestRequest) {
 	taskID := clouddriver.TaskIDFromContext(c)
 	namespace := ur.Location
 
+	cc.status(c, phaseUndoRollout, fmt.Sprintf("Rolling back manifest %s...", ur.ManifestName))
+
 	a := strings.Split(ur.ManifestName, " ")
 	manifestKind := a[0]
 	manifestName := a[1]
@@ -177,6 +180,7 @@ func (cc *Controller) Rollback(c *gin.Context, ur UndoRolloutManifestRequest) {
 		Version:      meta.Version,
 		Kind:         meta.Kind,
 		SpinnakerApp: app,
+		Labels:       kubernetes.SearchableLabels(u),
 	}
 
 	err = cc.SQLClient.CreateKubernetesResource(kr)
@@ -184,6 +188,16 @@ func (cc *Controller) Rollback(c *gin.Context, ur UndoRolloutManifestRequest) {
 		clouddriver.Error(c, http.StatusInternalServerError, err)
 		return
 	}
+
+	if ur.WaitForStability {
+		err = cc.waitForStability(c, provider, phaseUndoRollout, ur.StabilityOptions, []kubernetes.Metadata{meta})
+		if err != nil {
+			clouddriver.Error(c, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	cc.status(c, phaseUndoRollout, "Undo rollout manifest task completed successfully.")
 }
 
 // https://github.com/kubernetes/kubernetes/blob/master/pkg/controller/deployment/util/deployment_util.go#L679
@@ -0,0 +1,130 @@
+package kubernetes
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/homedepot/go-clouddriver/internal/kubernetes"
+	clouddriver "github.com/homedepot/go-clouddriver/pkg"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// PauseRollout performs a `kubectl rollout pause` by setting `spec.paused`
+// to true on the manifest, so changes to its pod template do not trigger a rollout.
+func (cc *Controller) PauseRollout(c *gin.Context, pr PauseRolloutManifestRequest) {
+	cc.status(c, phasePauseRollout, fmt.Sprintf("Pausing rollout of manifest %s...", pr.ManifestName))
+
+	if !cc.setPaused(c, pr.Account, pr.ManifestName, pr.Location, true) {
+		return
+	}
+
+	cc.status(c, phasePauseRollout, "Pause rollout manifest task completed successfully.")
+}
+
+// ResumeRollout performs a `kubectl rollout resume` by setting `spec.paused`
+// to false on the manifest, rolling out any changes made while it was paused.
+func (cc *Controller) ResumeRollout(c *gin.Context, rr ResumeRolloutManifestRequest) {
+	cc.status(c, phaseResumeRollout, fmt.Sprintf("Resuming rollout of manifest %s...", rr.ManifestName))
+
+	if !cc.setPaused(c, rr.Account, rr.ManifestName, rr.Location, false) {
+		return
+	}
+
+	cc.status(c, phaseResumeRollout, "Resume rollout manifest task completed successfully.")
+}
+
+// setPaused sets `spec.paused` on the manifest and records it for the task,
+// returning false if an error was attached to the context.
+//
+// Only Deployments can be paused, matching the kinds supported by
+// `kubectl rollout pause`.
+func (cc *Controller) setPaused(c *gin.Context, account, manifestName, location string, paused bool) bool {
+	app := c.GetHeader("X-Spinnaker-Application")
+	taskID := clouddriver.TaskIDFromContext(c)
+	namespace := location
+
+	provider, err := cc.KubernetesProvider(account)
+	if err != nil {
+		clouddriver.Error(c, http.StatusBadRequest, err)
+		return false
+	}
+
+	// Preserve backwards compatibility
+	if len(provider.Namespaces) == 1 {
+		namespace = provider.Namespaces[0]
+	}
+
+	a := strings.Split(manifestName, " ")
+	if len(a) != 2 {
+		clouddriver.Error(c, http.StatusBadRequest, errInvalidManifestName)
+		return false
+	}
+
+	kind := a[0]
+	name := a[1]
+
+	err = provider.ValidateKindStatus(kind)
+	if err != nil {
+		clouddriver.Error(c, http.StatusBadRequest, err)
+		return false
+	}
+
+	err = provider.ValidateNamespaceAccess(namespace)
+	if err != nil {
+		clouddriver.Error(c, http.StatusBadRequest, err)
+		return false
+	}
+
+	if !strings.EqualFold(kind, "deployment") {
+		action := "pausing"
+		if !paused {
+			action = "resuming"
+		}
+
+		clouddriver.Error(c, http.StatusBadRequest, fmt.Errorf("%s rollout of kind %s not currently supported", action, kind))
+
+		return false
+	}
+
+	u, err := provider.Client.Get(kind, name, namespace)
+	if err != nil {
+		clouddriver.Error(c, http.StatusInternalServerError, err)
+		return false
+	}
+
+	err = unstructured.SetNestedField(u.Object, paused, "spec", "paused")
+	if err != nil {
+		clouddriver.Error(c, http.StatusInternalServerError, err)
+		return false
+	}
+
+	meta, err := provider.Client.Apply(u)
+	if err != nil {
+		clouddriver.Error(c, http.StatusInternalServerError, err)
+		return false
+	}
+
+	kr := kubernetes.Resource{
+		AccountName:  account,
+		ID:           uuid.New().String(),
+		TaskID:       taskID,
+		APIGroup:     meta.Group,
+		Name:         meta.Name,
+		Namespace:    meta.Namespace,
+		Resource:     meta.Resource,
+		Version:      meta.Version,
+		Kind:         meta.Kind,
+		SpinnakerApp: app,
+	}
+
+	err = cc.SQLClient.CreateKubernetesResource(kr)
+	if err != nil {
+		clouddriver.Error(c, http.StatusInternalServerError, err)
+		return false
+	}
+
+	return true
+}
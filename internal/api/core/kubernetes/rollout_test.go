@@ -0,0 +1,184 @@
+package kubernetes_test
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/homedepot/go-clouddriver/internal/kubernetes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var _ = Describe("PauseRollout", func() {
+	BeforeEach(func() {
+		setup()
+	})
+
+	JustBeforeEach(func() {
+		kubernetesController.PauseRollout(c, pauseRolloutManifestRequest)
+	})
+
+	When("getting the provider returns an error", func() {
+		BeforeEach(func() {
+			fakeSQLClient.GetKubernetesProviderReturns(kubernetes.Provider{}, errors.New("error getting provider"))
+		})
+
+		It("returns an error", func() {
+			Expect(c.Writer.Status()).To(Equal(http.StatusBadRequest))
+			Expect(c.Errors.Last().Error()).To(Equal("internal: error getting kubernetes provider spin-cluster-account: error getting provider"))
+		})
+	})
+
+	When("the manifest name is invalid", func() {
+		BeforeEach(func() {
+			pauseRolloutManifestRequest.ManifestName = "test-deployment"
+		})
+
+		It("returns an error", func() {
+			Expect(c.Writer.Status()).To(Equal(http.StatusBadRequest))
+			Expect(c.Errors.Last().Error()).To(Equal("manifest name must be in format '{kind} {name}'"))
+		})
+	})
+
+	When("the kind is not supported to be paused", func() {
+		BeforeEach(func() {
+			pauseRolloutManifestRequest.ManifestName = "statefulSet test-name"
+		})
+
+		It("returns an error", func() {
+			Expect(c.Writer.Status()).To(Equal(http.StatusBadRequest))
+			Expect(c.Errors.Last().Error()).To(Equal("pausing rollout of kind statefulSet not currently supported"))
+		})
+	})
+
+	When("getting the manifest returns an error", func() {
+		BeforeEach(func() {
+			fakeKubeClient.GetReturns(nil, errors.New("error getting manifest"))
+		})
+
+		It("returns an error", func() {
+			Expect(c.Writer.Status()).To(Equal(http.StatusInternalServerError))
+			Expect(c.Errors.Last().Error()).To(Equal("error getting manifest"))
+		})
+	})
+
+	When("applying the manifest returns an error", func() {
+		BeforeEach(func() {
+			fakeKubeClient.ApplyReturns(kubernetes.Metadata{}, errors.New("error applying manifest"))
+		})
+
+		It("returns an error", func() {
+			Expect(c.Writer.Status()).To(Equal(http.StatusInternalServerError))
+			Expect(c.Errors.Last().Error()).To(Equal("error applying manifest"))
+		})
+	})
+
+	When("creating the resource returns an error", func() {
+		BeforeEach(func() {
+			fakeSQLClient.CreateKubernetesResourceReturns(errors.New("error creating resource"))
+		})
+
+		It("returns an error", func() {
+			Expect(c.Writer.Status()).To(Equal(http.StatusInternalServerError))
+			Expect(c.Errors.Last().Error()).To(Equal("error creating resource"))
+		})
+	})
+
+	When("it succeeds", func() {
+		It("pauses the deployment", func() {
+			Expect(c.Writer.Status()).To(Equal(http.StatusOK))
+			kind, name, _ := fakeKubeClient.GetArgsForCall(0)
+			Expect(kind).To(Equal("deployment"))
+			Expect(name).To(Equal("test-deployment"))
+			u := fakeKubeClient.ApplyArgsForCall(0)
+			paused, _, _ := unstructured.NestedBool(u.Object, "spec", "paused")
+			Expect(paused).To(BeTrue())
+			Expect(fakeSQLClient.CreateKubernetesResourceCallCount()).To(Equal(1))
+			kr := fakeSQLClient.CreateKubernetesResourceArgsForCall(0)
+			Expect(kr.TaskID).To(Equal("test-task-id"))
+			Expect(kr.SpinnakerApp).To(Equal("test-app"))
+		})
+	})
+
+	When("Using a namespace-scoped provider", func() {
+		BeforeEach(func() {
+			fakeSQLClient.GetKubernetesProviderReturns(namespaceScopedProvider, nil)
+		})
+
+		When("the kind is not supported", func() {
+			BeforeEach(func() {
+				pauseRolloutManifestRequest.ManifestName = "namespace someNamespace"
+			})
+
+			It("returns an error", func() {
+				Expect(c.Writer.Status()).To(Equal(http.StatusBadRequest))
+				Expect(c.Errors.Last().Error()).To(Equal("namespace-scoped account not allowed to access cluster-scoped kind: 'namespace'"))
+			})
+		})
+
+		When("the kind is supported", func() {
+			It("succeeds", func() {
+				Expect(c.Writer.Status()).To(Equal(http.StatusOK))
+				_, _, namespace := fakeKubeClient.GetArgsForCall(0)
+				Expect(namespace).To(Equal("provider-namespace"))
+			})
+		})
+	})
+})
+
+var _ = Describe("ResumeRollout", func() {
+	BeforeEach(func() {
+		setup()
+		fakeKubeClient.GetReturns(&unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"paused": true,
+				},
+			},
+		}, nil)
+	})
+
+	JustBeforeEach(func() {
+		kubernetesController.ResumeRollout(c, resumeRolloutManifestRequest)
+	})
+
+	When("the kind is not supported to be resumed", func() {
+		BeforeEach(func() {
+			resumeRolloutManifestRequest.ManifestName = "daemonSet test-name"
+		})
+
+		It("returns an error", func() {
+			Expect(c.Writer.Status()).To(Equal(http.StatusBadRequest))
+			Expect(c.Errors.Last().Error()).To(Equal("resuming rollout of kind daemonSet not currently supported"))
+		})
+	})
+
+	When("applying the manifest returns an error", func() {
+		BeforeEach(func() {
+			fakeKubeClient.ApplyReturns(kubernetes.Metadata{}, errors.New("error applying manifest"))
+		})
+
+		It("returns an error", func() {
+			Expect(c.Writer.Status()).To(Equal(http.StatusInternalServerError))
+			Expect(c.Errors.Last().Error()).To(Equal("error applying manifest"))
+		})
+	})
+
+	When("it succeeds", func() {
+		It("resumes the deployment", func() {
+			Expect(c.Writer.Status()).To(Equal(http.StatusOK))
+			u := fakeKubeClient.ApplyArgsForCall(0)
+			paused, found, _ := unstructured.NestedBool(u.Object, "spec", "paused")
+			Expect(found).To(BeTrue())
+			Expect(paused).To(BeFalse())
+			Expect(fakeSQLClient.CreateKubernetesResourceCallCount()).To(Equal(1))
+		})
+
+		It("records the task history", func() {
+			th := fakeSQLClient.CreateTaskHistoryArgsForCall(1)
+			Expect(th.Phase).To(Equal("RESUME_ROLLOUT_KUBERNETES_MANIFEST"))
+			Expect(th.Status).To(Equal("Resume rollout manifest task completed successfully."))
+		})
+	})
+})
@@ -1,6 +1,7 @@
 package kubernetes
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -16,6 +17,8 @@ const randNameNumber = 5
 func (cc *Controller) RunJob(c *gin.Context, rj RunJobRequest) {
 	taskID := clouddriver.TaskIDFromContext(c)
 
+	cc.status(c, phaseRunJob, "Beginning job run...")
+
 	provider, err := cc.KubernetesProvider(rj.Account)
 	if err != nil {
 		clouddriver.Error(c, http.StatusBadRequest, err)
@@ -64,6 +67,8 @@ func (cc *Controller) RunJob(c *gin.Context, rj RunJobRequest) {
 
 	kubernetes.BindArtifacts(&u, append(rj.RequiredArtifacts, rj.OptionalArtifacts...), rj.Account)
 
+	cc.status(c, phaseRunJob, fmt.Sprintf("Running job %s...", u.GetName()))
+
 	meta := kubernetes.Metadata{}
 	if kubernetes.Replace(u) {
 		meta, err = provider.Client.Replace(&u)
@@ -94,4 +99,33 @@ func (cc *Controller) RunJob(c *gin.Context, rj RunJobRequest) {
 		clouddriver.Error(c, http.StatusInternalServerError, err)
 		return
 	}
+
+	cc.status(c, phaseRunJob, "Run job task completed successfully.")
+}
+
+// RunPreconfiguredJob renders the parameters of a preconfigured job into its
+// manifest and runs the rendered manifest as a job.
+func (cc *Controller) RunPreconfiguredJob(c *gin.Context, rpj RunPreconfiguredJobRequest) {
+	cc.status(c, phaseRunJob, fmt.Sprintf("Rendering preconfigured job %s...", rpj.PreconfiguredJob))
+
+	pj, err := cc.PreconfiguredJobs.Get(rpj.PreconfiguredJob)
+	if err != nil {
+		clouddriver.Error(c, http.StatusBadRequest, err)
+		return
+	}
+
+	manifest, err := pj.Render(rpj.Parameters)
+	if err != nil {
+		clouddriver.Error(c, http.StatusBadRequest, err)
+		return
+	}
+
+	cc.RunJob(c, RunJobRequest{
+		Account:           rpj.Account,
+		Application:       rpj.Application,
+		CloudProvider:     rpj.CloudProvider,
+		Manifest:          manifest,
+		RequiredArtifacts: rpj.RequiredArtifacts,
+		OptionalArtifacts: rpj.OptionalArtifacts,
+	})
 }
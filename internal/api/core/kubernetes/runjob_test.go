@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 
+	. "github.com/homedepot/go-clouddriver/internal/api/core/kubernetes"
 	"github.com/homedepot/go-clouddriver/internal/artifact"
 	"github.com/homedepot/go-clouddriver/internal/kubernetes"
 	clouddriver "github.com/homedepot/go-clouddriver/pkg"
@@ -241,3 +242,77 @@ var _ = Describe("RunJob", func() {
 		})
 	})
 })
+
+var _ = Describe("RunPreconfiguredJob", func() {
+	var runPreconfiguredJobRequest RunPreconfiguredJobRequest
+
+	BeforeEach(func() {
+		setup()
+		kubernetesController.PreconfiguredJobs = kubernetes.PreconfiguredJobs{
+			{
+				Name: "test-preconfigured-job",
+				Parameters: []kubernetes.PreconfiguredJobParameter{
+					{
+						Name:    "image",
+						Mapping: "spec.template.spec.containers[0].image",
+					},
+				},
+				Manifest: newRunJobRequest().Manifest,
+			},
+		}
+		runPreconfiguredJobRequest = RunPreconfiguredJobRequest{
+			Account:          "test-account",
+			Application:      "test-application",
+			CloudProvider:    "kubernetes",
+			PreconfiguredJob: "test-preconfigured-job",
+			Parameters: map[string]string{
+				"image": "gcr.io/test-project/test-container-image:v2.0.0",
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		kubernetesController.RunPreconfiguredJob(c, runPreconfiguredJobRequest)
+	})
+
+	When("the preconfigured job is not registered", func() {
+		BeforeEach(func() {
+			runPreconfiguredJobRequest.PreconfiguredJob = "missing-job"
+		})
+
+		It("returns an error", func() {
+			Expect(c.Writer.Status()).To(Equal(http.StatusBadRequest))
+			Expect(c.Errors.Last().Error()).To(Equal("preconfigured job not found: missing-job"))
+			Expect(fakeKubeClient.ApplyCallCount()).To(BeZero())
+		})
+	})
+
+	When("rendering the preconfigured job returns an error", func() {
+		BeforeEach(func() {
+			runPreconfiguredJobRequest.Parameters["unknown"] = "value"
+		})
+
+		It("returns an error", func() {
+			Expect(c.Writer.Status()).To(Equal(http.StatusBadRequest))
+			Expect(c.Errors.Last().Error()).To(Equal("unknown parameter unknown for preconfigured job test-preconfigured-job"))
+			Expect(fakeKubeClient.ApplyCallCount()).To(BeZero())
+		})
+	})
+
+	When("it succeeds", func() {
+		It("runs the rendered job", func() {
+			Expect(c.Writer.Status()).To(Equal(http.StatusOK))
+			Expect(fakeKubeClient.ApplyCallCount()).To(Equal(1))
+			u := fakeKubeClient.ApplyArgsForCall(0)
+			j := kubernetes.NewJob(u.Object)
+			containers := j.Object().Spec.Template.Spec.Containers
+			Expect(containers).To(HaveLen(1))
+			Expect(containers[0].Image).To(Equal("gcr.io/test-project/test-container-image:v2.0.0"))
+			Expect(u.GetName()).To(HavePrefix("test-"))
+			kr := fakeSQLClient.CreateKubernetesResourceArgsForCall(0)
+			Expect(kr.AccountName).To(Equal("test-account"))
+			Expect(kr.SpinnakerApp).To(Equal("test-application"))
+			Expect(kr.Kind).To(Equal("job"))
+		})
+	})
+})
@@ -18,7 +18,9 @@ func (cc *Controller) Scale(c *gin.Context, sm ScaleManifestRequest) {
 	taskID := clouddriver.TaskIDFromContext(c)
 	namespace := sm.Location
 
-	provider, err := cc.KubernetesProvider(sm.Account)
+	cc.status(c, phaseScale, fmt.Sprintf("Scaling manifest %s to %s replicas...", sm.ManifestName, sm.Replicas))
+
+	provider, err := cc.provider(c, sm.Account)
 	if err != nil {
 		clouddriver.Error(c, http.StatusBadRequest, err)
 		return
@@ -96,4 +98,14 @@ func (cc *Controller) Scale(c *gin.Context, sm ScaleManifestRequest) {
 		clouddriver.Error(c, http.StatusInternalServerError, err)
 		return
 	}
+
+	if sm.WaitForStability {
+		err = cc.waitForStability(c, provider, phaseScale, sm.StabilityOptions, []kubernetes.Metadata{meta})
+		if err != nil {
+			clouddriver.Error(c, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	cc.status(c, phaseScale, "Scale manifest task completed successfully.")
 }
@@ -8,6 +8,9 @@ import (
 	"github.com/homedepot/go-clouddriver/internal/kubernetes"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 var _ = Describe("Scale", func() {
@@ -105,6 +108,126 @@ var _ = Describe("Scale", func() {
 		})
 	})
 
+	When("waiting for stability", func() {
+		BeforeEach(func() {
+			scaleManifestRequest.WaitForStability = true
+			fakeKubeClient.ApplyReturns(kubernetes.Metadata{
+				Name:      "test-deployment",
+				Namespace: "test-namespace",
+				Resource:  "deployments",
+				Kind:      "Deployment",
+			}, nil)
+		})
+
+		When("getting the resource returns an error until the timeout", func() {
+			BeforeEach(func() {
+				scaleManifestRequest.StabilityTimeoutSeconds = 1
+				fakeKubeClient.GetReturnsOnCall(1, nil, errors.New("error getting resource"))
+			})
+
+			It("returns the error once timed out", func() {
+				Expect(c.Writer.Status()).To(Equal(http.StatusInternalServerError))
+				Expect(c.Errors.Last().Error()).To(Equal("timed out waiting for Deployment test-deployment to become stable: " +
+					"error getting resource"))
+			})
+		})
+
+		When("the resource is not found", func() {
+			BeforeEach(func() {
+				fakeKubeClient.GetReturnsOnCall(1, nil,
+					k8serrors.NewNotFound(schema.GroupResource{Resource: "deployments"}, "test-deployment"))
+			})
+
+			It("returns an error without waiting", func() {
+				Expect(c.Writer.Status()).To(Equal(http.StatusInternalServerError))
+				Expect(c.Errors.Last().Error()).To(Equal(`deployments "test-deployment" not found`))
+			})
+		})
+
+		When("getting the resource is forbidden", func() {
+			BeforeEach(func() {
+				fakeKubeClient.GetReturnsOnCall(1, nil,
+					k8serrors.NewForbidden(schema.GroupResource{Resource: "deployments"}, "test-deployment", errors.New("no access")))
+			})
+
+			It("returns an error without waiting", func() {
+				Expect(c.Writer.Status()).To(Equal(http.StatusInternalServerError))
+				Expect(c.Errors.Last().Error()).To(Equal(`deployments "test-deployment" is forbidden: no access`))
+			})
+		})
+
+		When("the resource fails", func() {
+			BeforeEach(func() {
+				fakeKubeClient.GetReturnsOnCall(1, &unstructured.Unstructured{
+					Object: map[string]interface{}{
+						"status": map[string]interface{}{
+							"conditions": []interface{}{
+								map[string]interface{}{
+									"type":    "Progressing",
+									"reason":  "ProgressDeadlineExceeded",
+									"message": "ReplicaSet has timed out progressing.",
+								},
+							},
+						},
+					},
+				}, nil)
+			})
+
+			It("returns an error", func() {
+				Expect(c.Writer.Status()).To(Equal(http.StatusInternalServerError))
+				Expect(c.Errors.Last().Error()).To(Equal("Deployment test-deployment failed: ReplicaSet has timed out progressing."))
+			})
+		})
+
+		When("the resource does not become stable before the timeout", func() {
+			BeforeEach(func() {
+				scaleManifestRequest.StabilityTimeoutSeconds = 1
+				fakeKubeClient.GetReturnsOnCall(1, &unstructured.Unstructured{
+					Object: map[string]interface{}{
+						"metadata": map[string]interface{}{
+							"generation": int64(2),
+						},
+						"status": map[string]interface{}{
+							"observedGeneration": int64(1),
+						},
+					},
+				}, nil)
+			})
+
+			It("returns an error", func() {
+				Expect(c.Writer.Status()).To(Equal(http.StatusInternalServerError))
+				Expect(c.Errors.Last().Error()).To(Equal("timed out waiting for Deployment test-deployment to become stable: " +
+					"Waiting for status generation to match updated object generation"))
+			})
+		})
+
+		When("the resource becomes stable", func() {
+			BeforeEach(func() {
+				fakeKubeClient.GetReturnsOnCall(1, &unstructured.Unstructured{Object: map[string]interface{}{}}, nil)
+			})
+
+			It("waits for the resource", func() {
+				Expect(c.Errors).To(BeEmpty())
+				Expect(fakeKubeClient.GetCallCount()).To(Equal(2))
+				kind, name, namespace := fakeKubeClient.GetArgsForCall(1)
+				Expect(kind).To(Equal("deployments"))
+				Expect(name).To(Equal("test-deployment"))
+				Expect(namespace).To(Equal("test-namespace"))
+			})
+
+			It("records the task history", func() {
+				Expect(fakeSQLClient.CreateTaskHistoryCallCount()).To(Equal(4))
+				th := fakeSQLClient.CreateTaskHistoryArgsForCall(1)
+				Expect(th.Phase).To(Equal("SCALE_KUBERNETES_MANIFEST"))
+				Expect(th.Status).To(Equal("Waiting for Deployment test-deployment to become stable..."))
+				th = fakeSQLClient.CreateTaskHistoryArgsForCall(2)
+				Expect(th.Status).To(Equal("All resources are stable."))
+				th = fakeSQLClient.CreateTaskHistoryArgsForCall(3)
+				Expect(th.Status).To(Equal("Scale manifest task completed successfully."))
+			})
+		})
+	})
+
 	When("it succeeds", func() {
 		It("succeeds", func() {
 			Expect(c.Writer.Status()).To(Equal(http.StatusOK))
@@ -114,6 +237,16 @@ var _ = Describe("Scale", func() {
 			b, _ := json.Marshal(&u)
 			Expect(string(b)).To(Equal("{\"spec\":{\"replicas\":16}}"))
 		})
+
+		It("records the task history", func() {
+			Expect(fakeSQLClient.CreateTaskHistoryCallCount()).To(Equal(2))
+			th := fakeSQLClient.CreateTaskHistoryArgsForCall(0)
+			Expect(th.TaskID).To(Equal("test-task-id"))
+			Expect(th.Phase).To(Equal("SCALE_KUBERNETES_MANIFEST"))
+			Expect(th.Status).To(Equal("Scaling manifest deployment test-deployment to 16 replicas..."))
+			th = fakeSQLClient.CreateTaskHistoryArgsForCall(1)
+			Expect(th.Status).To(Equal("Scale manifest task completed successfully."))
+		})
 	})
 
 	When("Using a namespace-scoped provider", func() {
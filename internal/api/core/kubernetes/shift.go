@@ -0,0 +1,344 @@
+package kubernetes
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/homedepot/go-clouddriver/internal/kubernetes"
+	clouddriver "github.com/homedepot/go-clouddriver/pkg"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var (
+	errInvalidTrafficSteps = errors.New("traffic steps must be increasing percentages between 1 and 100")
+)
+
+// ShiftTraffic shifts the traffic of the load balancers in the
+// `traffic.spinnaker.io/load-balancers` annotation of a target server group
+// away from a source server group of the same cluster, a step at a time.
+//
+// At each step a percentage of the target's pods are attached to the load balancers
+// by patching their labels as Enable does, and the same percentage of the source's
+// pods are detached as Disable does. Pods are listed again at each step, so pods
+// created during the shift are shifted too. Before shifting all traffic, the pod
+// templates are patched as well so that new pods of the target receive traffic
+// and new pods of the source do not.
+func (cc *Controller) ShiftTraffic(c *gin.Context, st ShiftTrafficManifestRequest) {
+	taskID := clouddriver.TaskIDFromContext(c)
+	namespace := st.Location
+
+	cc.status(c, phaseShiftTraffic, fmt.Sprintf("Shifting traffic from %s to %s...",
+		st.SourceManifestName, st.TargetManifestName))
+
+	provider, err := cc.provider(c, st.Account)
+	if err != nil {
+		clouddriver.Error(c, http.StatusBadRequest, err)
+		return
+	}
+
+	// Preserve backwards compatibility
+	if len(provider.Namespaces) == 1 {
+		namespace = provider.Namespaces[0]
+	}
+
+	err = validateTrafficSteps(st.Steps)
+	if err != nil {
+		clouddriver.Error(c, http.StatusBadRequest, err)
+		return
+	}
+
+	err = provider.ValidateNamespaceAccess(namespace)
+	if err != nil {
+		clouddriver.Error(c, http.StatusBadRequest, err)
+		return
+	}
+
+	source, status, err := getServerGroup(provider, st.SourceManifestName, namespace)
+	if err != nil {
+		clouddriver.Error(c, status, err)
+		return
+	}
+
+	target, status, err := getServerGroup(provider, st.TargetManifestName, namespace)
+	if err != nil {
+		clouddriver.Error(c, status, err)
+		return
+	}
+
+	cluster := target.GetAnnotations()[kubernetes.AnnotationSpinnakerMonikerCluster]
+	if cluster == "" || source.GetAnnotations()[kubernetes.AnnotationSpinnakerMonikerCluster] != cluster {
+		clouddriver.Error(c, http.StatusBadRequest, fmt.Errorf("server groups %s and %s are not in the same cluster",
+			st.SourceManifestName, st.TargetManifestName))
+		return
+	}
+
+	loadBalancers, err := kubernetes.LoadBalancers(*target)
+	if err != nil {
+		clouddriver.Error(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if len(loadBalancers) == 0 {
+		clouddriver.Error(c, http.StatusBadRequest, fmt.Errorf("server group %s has no load balancers to shift traffic of",
+			st.TargetManifestName))
+		return
+	}
+
+	lbs := []*unstructured.Unstructured{}
+
+	for _, loadBalancer := range loadBalancers {
+		lb, err := getLoadBalancer(provider.Client, loadBalancer, namespace)
+		if err != nil {
+			clouddriver.Error(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		lbs = append(lbs, lb)
+	}
+
+	for i, step := range st.Steps {
+		cc.status(c, phaseShiftTraffic, fmt.Sprintf("Shifting %d%% of traffic to %s...", step, st.TargetManifestName))
+
+		// Patch the templates before the pods of the last step, so that pods created
+		// while their server groups are being patched are attached as the rest will be.
+		if step == 100 {
+			err = shiftTemplates(provider.Client, lbs, source, target)
+			if err != nil {
+				clouddriver.Error(c, http.StatusInternalServerError, err)
+				return
+			}
+		}
+
+		// List the pods at each step to include any created since the last step.
+		sourcePods, err := ownedPods(provider.Client, source, namespace)
+		if err != nil {
+			clouddriver.Error(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		targetPods, err := ownedPods(provider.Client, target, namespace)
+		if err != nil {
+			clouddriver.Error(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		// Attach the target's pods first so the load balancers are never left without endpoints.
+		err = attachPods(provider.Client, lbs, targetPods, podsForPercentage(step, len(targetPods)))
+		if err != nil {
+			clouddriver.Error(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		err = attachPods(provider.Client, lbs, sourcePods, len(sourcePods)-podsForPercentage(step, len(sourcePods)))
+		if err != nil {
+			clouddriver.Error(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		if i < len(st.Steps)-1 && st.PauseSeconds > 0 {
+			cc.status(c, phaseShiftTraffic, fmt.Sprintf("Pausing for %d seconds...", st.PauseSeconds))
+
+			select {
+			case <-c.Request.Context().Done():
+				clouddriver.Error(c, http.StatusInternalServerError,
+					fmt.Errorf("shifting traffic canceled: %w", c.Request.Context().Err()))
+				return
+			case <-time.After(time.Duration(st.PauseSeconds) * time.Second):
+			}
+		}
+	}
+
+	// Just create one entry for a successful shift of traffic to the target.
+	kr := kubernetes.Resource{
+		TaskType:     clouddriver.TaskTypeNoOp,
+		AccountName:  st.Account,
+		SpinnakerApp: st.App,
+		ID:           uuid.New().String(),
+		TaskID:       taskID,
+		Name:         target.GetName(),
+		Namespace:    namespace,
+		Kind:         target.GetKind(),
+	}
+
+	err = cc.SQLClient.CreateKubernetesResource(kr)
+	if err != nil {
+		clouddriver.Error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	cc.status(c, phaseShiftTraffic, "Shift traffic task completed successfully.")
+}
+
+// validateTrafficSteps verifies that each step shifts more traffic than the last.
+func validateTrafficSteps(steps []int) error {
+	if len(steps) == 0 {
+		return errInvalidTrafficSteps
+	}
+
+	last := 0
+
+	for _, step := range steps {
+		if step <= last || step > 100 {
+			return errInvalidTrafficSteps
+		}
+
+		last = step
+	}
+
+	return nil
+}
+
+// getServerGroup gets a server group, like 'ReplicaSet test-rs-v001', that owns pods
+// whose traffic can be shifted. It returns the HTTP status to respond with on error.
+func getServerGroup(provider *kubernetes.Provider, manifestName, namespace string) (*unstructured.Unstructured, int, error) {
+	a := strings.Split(manifestName, " ")
+	if len(a) != 2 {
+		return nil, http.StatusBadRequest, errInvalidManifestName
+	}
+
+	kind := a[0]
+	name := a[1]
+
+	err := provider.ValidateKindStatus(kind)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	u, err := provider.Client.Get(kind, name, namespace)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, http.StatusNotFound, fmt.Errorf("resource %s %s does not exist", kind, name)
+		}
+
+		return nil, http.StatusInternalServerError, fmt.Errorf("error getting resource (kind: %s, name: %s, namespace: %s): %v",
+			kind, name, namespace, err)
+	}
+
+	if !hasPods(u) {
+		return nil, http.StatusBadRequest, fmt.Errorf("shifting traffic of kind %s not currently supported", kind)
+	}
+
+	return u, http.StatusOK, nil
+}
+
+// shiftTemplates patches the pod templates of the server groups so that new pods
+// of the target are attached to each load balancer and new pods of the source are not.
+// A target whose template is already attached, for example as it was deployed
+// with the selector labels of the load balancers, is left as is.
+func shiftTemplates(client kubernetes.Client, lbs []*unstructured.Unstructured, source, target *unstructured.Unstructured) error {
+	for _, lb := range lbs {
+		if !isAttached(lb, target) {
+			err := attachDetach(client, lb, target, "add")
+			if err != nil {
+				return err
+			}
+		}
+
+		err := attachDetach(client, lb, source, "remove")
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// podsForPercentage returns the number of pods, rounded up,
+// that make up a percentage of the given total.
+func podsForPercentage(percentage, total int) int {
+	return (percentage*total + 99) / 100
+}
+
+// attachPods attaches the first n pods to each load balancer and detaches the rest,
+// patching only the pods whose attachment changes. Pods already attached to more
+// load balancers come first, then pods are ordered by name, so that a pod created
+// during the shift does not take the place of a pod attached at an earlier step.
+func attachPods(client kubernetes.Client, lbs, pods []*unstructured.Unstructured, n int) error {
+	attachments := map[string]int{}
+
+	for _, pod := range pods {
+		for _, lb := range lbs {
+			if isAttached(lb, pod) {
+				attachments[pod.GetName()]++
+			}
+		}
+	}
+
+	sort.Slice(pods, func(i, j int) bool {
+		ai, aj := attachments[pods[i].GetName()], attachments[pods[j].GetName()]
+		if ai != aj {
+			return ai > aj
+		}
+
+		return pods[i].GetName() < pods[j].GetName()
+	})
+
+	for i, pod := range pods {
+		for _, lb := range lbs {
+			attached := isAttached(lb, pod)
+
+			op := ""
+			if i < n && !attached {
+				op = "add"
+			} else if i >= n && attached {
+				op = "remove"
+			}
+
+			if op == "" {
+				continue
+			}
+
+			err := attachDetach(client, lb, pod, op)
+			if err != nil {
+				return err
+			}
+
+			// Keep the pod's labels in sync with the patch for the next step.
+			setSelectorLabels(lb, pod, op)
+		}
+	}
+
+	return nil
+}
+
+// setSelectorLabels adds or removes the selector labels of a load balancer from a pod's labels.
+func setSelectorLabels(lb, pod *unstructured.Unstructured, op string) {
+	selector, _, _ := unstructured.NestedStringMap(lb.Object, "spec", "selector")
+	labels := pod.GetLabels()
+
+	if labels == nil {
+		labels = map[string]string{}
+	}
+
+	for k, v := range selector {
+		if op == "add" {
+			labels[k] = v
+		} else {
+			delete(labels, k)
+		}
+	}
+
+	pod.SetLabels(labels)
+}
+
+// isAttached returns true if the labels of a pod, or the pod template of a
+// server group, match the selector of a load balancer.
+func isAttached(lb, target *unstructured.Unstructured) bool {
+	selector, _, _ := unstructured.NestedStringMap(lb.Object, "spec", "selector")
+	labels, _, _ := podLabels(target)
+
+	for k, v := range selector {
+		if labels[k] != v {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,376 @@
+package kubernetes_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	. "github.com/homedepot/go-clouddriver/internal/api/core/kubernetes"
+	"github.com/homedepot/go-clouddriver/internal/kubernetes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("ShiftTraffic", func() {
+	var (
+		shiftTrafficManifestRequest ShiftTrafficManifestRequest
+		source, target              *unstructured.Unstructured
+		pods                        map[string]unstructured.Unstructured
+	)
+
+	serverGroup := func(name, uid string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"kind":       "ReplicaSet",
+				"apiVersion": "apps/v1",
+				"metadata": map[string]interface{}{
+					"name":      name,
+					"namespace": "test-namespace",
+					"uid":       uid,
+					"annotations": map[string]interface{}{
+						kubernetes.AnnotationSpinnakerMonikerCluster:       "replicaSet test-rs",
+						kubernetes.AnnotationSpinnakerTrafficLoadBalancers: `["service test-svc"]`,
+					},
+				},
+				"spec": map[string]interface{}{
+					"template": map[string]interface{}{
+						"metadata": map[string]interface{}{
+							"labels": map[string]interface{}{
+								"app": "test-rs",
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	pod := func(name, ownerUID string, labels map[string]string) unstructured.Unstructured {
+		u := unstructured.Unstructured{Object: map[string]interface{}{}}
+		u.SetKind("Pod")
+		u.SetName(name)
+		u.SetNamespace("test-namespace")
+		u.SetLabels(labels)
+		u.SetOwnerReferences([]metav1.OwnerReference{{UID: types.UID(ownerUID)}})
+
+		return u
+	}
+
+	// patches returns the resources and patches of each call to PatchUsingStrategy.
+	patches := func() []string {
+		p := []string{}
+
+		for i := 0; i < fakeKubeClient.PatchUsingStrategyCallCount(); i++ {
+			kind, name, _, b, _ := fakeKubeClient.PatchUsingStrategyArgsForCall(i)
+			p = append(p, kind+" "+name+" "+string(b))
+		}
+
+		return p
+	}
+
+	BeforeEach(func() {
+		setup()
+
+		shiftTrafficManifestRequest = ShiftTrafficManifestRequest{
+			App:                "test-app",
+			Account:            "test-account",
+			Location:           "test-namespace",
+			SourceManifestName: "ReplicaSet test-rs-v001",
+			TargetManifestName: "ReplicaSet test-rs-v002",
+			Steps:              []int{50, 100},
+		}
+
+		source = serverGroup("test-rs-v001", "source-uid")
+		target = serverGroup("test-rs-v002", "target-uid")
+
+		fakeKubeClient.GetStub = func(kind, name, namespace string) (*unstructured.Unstructured, error) {
+			switch name {
+			case "test-rs-v001":
+				return source, nil
+			case "test-rs-v002":
+				return target, nil
+			case "test-svc":
+				return &unstructured.Unstructured{
+					Object: map[string]interface{}{
+						"kind": "Service",
+						"spec": map[string]interface{}{
+							"selector": map[string]interface{}{
+								"lb": "test-svc",
+							},
+						},
+					},
+				}, nil
+			}
+
+			return nil, k8serrors.NewNotFound(schema.GroupResource{Resource: kind}, name)
+		}
+
+		// Keep the labels of the pods in sync with their patches like the cluster would.
+		pods = map[string]unstructured.Unstructured{
+			"source-pod-b": pod("source-pod-b", "source-uid", map[string]string{"app": "test-rs", "lb": "test-svc"}),
+			"source-pod-a": pod("source-pod-a", "source-uid", map[string]string{"app": "test-rs", "lb": "test-svc"}),
+			"target-pod-a": pod("target-pod-a", "target-uid", map[string]string{"app": "test-rs"}),
+			"target-pod-b": pod("target-pod-b", "target-uid", map[string]string{"app": "test-rs"}),
+		}
+		fakeKubeClient.ListResourceWithContextStub = func(context.Context, string, metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+			ul := &unstructured.UnstructuredList{}
+			for _, p := range pods {
+				ul.Items = append(ul.Items, *p.DeepCopy())
+			}
+
+			return ul, nil
+		}
+		fakeKubeClient.PatchUsingStrategyStub = func(kind, name, namespace string, b []byte,
+			strategy types.PatchType) (kubernetes.Metadata, *unstructured.Unstructured, error) {
+			p, ok := pods[name]
+			if !ok {
+				return kubernetes.Metadata{}, nil, nil
+			}
+
+			labels := p.GetLabels()
+			if strings.Contains(string(b), `"op":"add"`) {
+				labels["lb"] = "test-svc"
+			} else {
+				delete(labels, "lb")
+			}
+
+			p.SetLabels(labels)
+			pods[name] = p
+
+			return kubernetes.Metadata{}, nil, nil
+		}
+	})
+
+	JustBeforeEach(func() {
+		kubernetesController.ShiftTraffic(c, shiftTrafficManifestRequest)
+	})
+
+	When("the steps are not increasing", func() {
+		BeforeEach(func() {
+			shiftTrafficManifestRequest.Steps = []int{50, 10}
+		})
+
+		It("returns an error", func() {
+			Expect(c.Writer.Status()).To(Equal(http.StatusBadRequest))
+			Expect(c.Errors.Last().Error()).To(Equal("traffic steps must be increasing percentages between 1 and 100"))
+		})
+	})
+
+	When("a step is more than 100 percent", func() {
+		BeforeEach(func() {
+			shiftTrafficManifestRequest.Steps = []int{50, 150}
+		})
+
+		It("returns an error", func() {
+			Expect(c.Writer.Status()).To(Equal(http.StatusBadRequest))
+			Expect(c.Errors.Last().Error()).To(Equal("traffic steps must be increasing percentages between 1 and 100"))
+		})
+	})
+
+	When("the manifest name is invalid", func() {
+		BeforeEach(func() {
+			shiftTrafficManifestRequest.SourceManifestName = "test-rs-v001"
+		})
+
+		It("returns an error", func() {
+			Expect(c.Writer.Status()).To(Equal(http.StatusBadRequest))
+			Expect(c.Errors.Last().Error()).To(Equal("manifest name must be in format '{kind} {name}'"))
+		})
+	})
+
+	When("the target does not exist", func() {
+		BeforeEach(func() {
+			shiftTrafficManifestRequest.TargetManifestName = "ReplicaSet test-rs-v003"
+		})
+
+		It("returns an error", func() {
+			Expect(c.Writer.Status()).To(Equal(http.StatusNotFound))
+			Expect(c.Errors.Last().Error()).To(Equal("resource ReplicaSet test-rs-v003 does not exist"))
+		})
+	})
+
+	When("the kind does not have pods", func() {
+		BeforeEach(func() {
+			shiftTrafficManifestRequest.TargetManifestName = "Service test-svc"
+		})
+
+		It("returns an error", func() {
+			Expect(c.Writer.Status()).To(Equal(http.StatusBadRequest))
+			Expect(c.Errors.Last().Error()).To(Equal("shifting traffic of kind Service not currently supported"))
+		})
+	})
+
+	When("the server groups are in different clusters", func() {
+		BeforeEach(func() {
+			target.SetAnnotations(map[string]string{
+				kubernetes.AnnotationSpinnakerMonikerCluster: "replicaSet other-rs",
+			})
+		})
+
+		It("returns an error", func() {
+			Expect(c.Writer.Status()).To(Equal(http.StatusBadRequest))
+			Expect(c.Errors.Last().Error()).To(Equal("server groups ReplicaSet test-rs-v001 and ReplicaSet test-rs-v002 are not in the same cluster"))
+		})
+	})
+
+	When("the target has no load balancers", func() {
+		BeforeEach(func() {
+			target.SetAnnotations(map[string]string{
+				kubernetes.AnnotationSpinnakerMonikerCluster: "replicaSet test-rs",
+			})
+		})
+
+		It("returns an error", func() {
+			Expect(c.Writer.Status()).To(Equal(http.StatusBadRequest))
+			Expect(c.Errors.Last().Error()).To(Equal("server group ReplicaSet test-rs-v002 has no load balancers to shift traffic of"))
+		})
+	})
+
+	When("listing pods returns an error", func() {
+		BeforeEach(func() {
+			fakeKubeClient.ListResourceWithContextStub = nil
+			fakeKubeClient.ListResourceWithContextReturns(nil, errors.New("error listing pods"))
+		})
+
+		It("returns an error", func() {
+			Expect(c.Writer.Status()).To(Equal(http.StatusInternalServerError))
+			Expect(c.Errors.Last().Error()).To(Equal("error listing pods"))
+		})
+	})
+
+	When("patching a pod returns an error", func() {
+		BeforeEach(func() {
+			fakeKubeClient.PatchUsingStrategyStub = nil
+			fakeKubeClient.PatchUsingStrategyReturns(kubernetes.Metadata{}, nil, errors.New("error patching pod"))
+		})
+
+		It("returns an error", func() {
+			Expect(c.Writer.Status()).To(Equal(http.StatusInternalServerError))
+			Expect(c.Errors.Last().Error()).To(Equal("error patching pod"))
+		})
+	})
+
+	When("the last step shifts part of the traffic", func() {
+		BeforeEach(func() {
+			shiftTrafficManifestRequest.Steps = []int{50}
+		})
+
+		It("only patches that percentage of pods", func() {
+			Expect(c.Errors).To(BeEmpty())
+			Expect(patches()).To(Equal([]string{
+				`Pod target-pod-a [{"op":"add","path":"/metadata/labels/lb","value":"test-svc"}]`,
+				`Pod source-pod-b [{"op":"remove","path":"/metadata/labels/lb"}]`,
+			}))
+		})
+	})
+
+	When("the target's template is already attached", func() {
+		BeforeEach(func() {
+			err := unstructured.SetNestedStringMap(target.Object, map[string]string{"app": "test-rs", "lb": "test-svc"},
+				"spec", "template", "metadata", "labels")
+			Expect(err).To(BeNil())
+		})
+
+		It("does not patch the template", func() {
+			Expect(c.Errors).To(BeEmpty())
+			Expect(patches()).To(Equal([]string{
+				`Pod target-pod-a [{"op":"add","path":"/metadata/labels/lb","value":"test-svc"}]`,
+				`Pod source-pod-b [{"op":"remove","path":"/metadata/labels/lb"}]`,
+				`Pod target-pod-b [{"op":"add","path":"/metadata/labels/lb","value":"test-svc"}]`,
+				`Pod source-pod-a [{"op":"remove","path":"/metadata/labels/lb"}]`,
+			}))
+		})
+	})
+
+	When("a pod is created during the shift", func() {
+		BeforeEach(func() {
+			list := fakeKubeClient.ListResourceWithContextStub
+			fakeKubeClient.ListResourceWithContextStub = func(ctx context.Context, resource string,
+				lo metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+				// Create the pod once the pods of the first step are listed.
+				if fakeKubeClient.ListResourceWithContextCallCount() == 3 {
+					pods["target-pod-c"] = pod("target-pod-c", "target-uid", map[string]string{"app": "test-rs"})
+				}
+
+				return list(ctx, resource, lo)
+			}
+		})
+
+		It("shifts the traffic of the pod", func() {
+			Expect(c.Errors).To(BeEmpty())
+			Expect(patches()).To(ContainElement(
+				`Pod target-pod-c [{"op":"add","path":"/metadata/labels/lb","value":"test-svc"}]`))
+		})
+	})
+
+	When("a pod that sorts before an attached pod is created during the shift", func() {
+		BeforeEach(func() {
+			shiftTrafficManifestRequest.Steps = []int{25, 33}
+			list := fakeKubeClient.ListResourceWithContextStub
+			fakeKubeClient.ListResourceWithContextStub = func(ctx context.Context, resource string,
+				lo metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+				// Create the pod once the pods of the first step are listed.
+				if fakeKubeClient.ListResourceWithContextCallCount() == 3 {
+					pods["target-pod-0"] = pod("target-pod-0", "target-uid", map[string]string{"app": "test-rs"})
+				}
+
+				return list(ctx, resource, lo)
+			}
+		})
+
+		It("keeps the pod that is already attached", func() {
+			Expect(c.Errors).To(BeEmpty())
+			Expect(patches()).To(Equal([]string{
+				`Pod target-pod-a [{"op":"add","path":"/metadata/labels/lb","value":"test-svc"}]`,
+				`Pod source-pod-b [{"op":"remove","path":"/metadata/labels/lb"}]`,
+			}))
+		})
+	})
+
+	When("the shift is canceled while pausing between steps", func() {
+		BeforeEach(func() {
+			shiftTrafficManifestRequest.PauseSeconds = 60
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			c.Request = c.Request.WithContext(ctx)
+		})
+
+		It("returns an error", func() {
+			Expect(c.Writer.Status()).To(Equal(http.StatusInternalServerError))
+			Expect(c.Errors.Last().Error()).To(Equal("shifting traffic canceled: context canceled"))
+			Expect(patches()).To(HaveLen(2))
+		})
+	})
+
+	When("it succeeds", func() {
+		It("shifts the traffic of each step and then patches the templates", func() {
+			Expect(c.Errors).To(BeEmpty())
+			Expect(fakeKubeClient.ListResourceWithContextCallCount()).To(Equal(4))
+			_, resource, lo := fakeKubeClient.ListResourceWithContextArgsForCall(0)
+			Expect(resource).To(Equal("pods"))
+			Expect(lo.FieldSelector).To(Equal("metadata.namespace=test-namespace"))
+			Expect(patches()).To(Equal([]string{
+				`Pod target-pod-a [{"op":"add","path":"/metadata/labels/lb","value":"test-svc"}]`,
+				`Pod source-pod-b [{"op":"remove","path":"/metadata/labels/lb"}]`,
+				`ReplicaSet test-rs-v002 [{"op":"add","path":"/spec/template/metadata/labels/lb","value":"test-svc"}]`,
+				`Pod target-pod-b [{"op":"add","path":"/metadata/labels/lb","value":"test-svc"}]`,
+				`Pod source-pod-a [{"op":"remove","path":"/metadata/labels/lb"}]`,
+			}))
+		})
+
+		It("records the target", func() {
+			Expect(fakeSQLClient.CreateKubernetesResourceCallCount()).To(Equal(1))
+			kr := fakeSQLClient.CreateKubernetesResourceArgsForCall(0)
+			Expect(kr.Name).To(Equal("test-rs-v002"))
+			Expect(kr.Kind).To(Equal("ReplicaSet"))
+			Expect(kr.AccountName).To(Equal("test-account"))
+		})
+	})
+})
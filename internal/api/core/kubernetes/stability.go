@@ -0,0 +1,86 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/homedepot/go-clouddriver/internal/kubernetes"
+	"github.com/homedepot/go-clouddriver/internal/kubernetes/manifest"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+var (
+	stabilityPollInterval   = 5 * time.Second
+	defaultStabilityTimeout = 10 * time.Minute
+)
+
+// timeout returns how long to wait for resources to become stable.
+func (so StabilityOptions) timeout() time.Duration {
+	if so.StabilityTimeoutSeconds > 0 {
+		return time.Duration(so.StabilityTimeoutSeconds) * time.Second
+	}
+
+	return defaultStabilityTimeout
+}
+
+// waitForStability polls the status of each resource until it reports stable,
+// recording progress in the task's history. The task stays running while waiting.
+//
+// It returns an error as soon as a resource reports failed or cannot be read
+// as it is not found or access is forbidden, or if a resource is not stable
+// when the timeout is reached. Other errors getting a resource are retried.
+func (cc *Controller) waitForStability(c *gin.Context, provider *kubernetes.Provider,
+	phase string, so StabilityOptions, resources []kubernetes.Metadata) error {
+	ctx, cancel := context.WithTimeout(context.Background(), so.timeout())
+	defer cancel()
+
+	for _, r := range resources {
+		cc.status(c, phase, fmt.Sprintf("Waiting for %s %s to become stable...", r.Kind, r.Name))
+
+		var (
+			status  manifest.Status
+			lastErr error
+		)
+
+		err := wait.PollUntilContextCancel(ctx, stabilityPollInterval, true, func(context.Context) (bool, error) {
+			u, err := provider.Client.Get(r.Resource, r.Name, r.Namespace)
+			if err != nil {
+				if k8serrors.IsNotFound(err) || k8serrors.IsForbidden(err) {
+					return false, err
+				}
+
+				lastErr = err
+
+				return false, nil
+			}
+
+			lastErr = nil
+
+			status = kubernetes.GetStatus(r.Kind, u.Object)
+			if status.Failed.State {
+				return false, fmt.Errorf("%s %s failed: %s", r.Kind, r.Name, status.Failed.Message)
+			}
+
+			return status.Stable.State, nil
+		})
+		if err != nil {
+			if wait.Interrupted(err) {
+				if lastErr != nil {
+					return fmt.Errorf("timed out waiting for %s %s to become stable: %w", r.Kind, r.Name, lastErr)
+				}
+
+				return fmt.Errorf("timed out waiting for %s %s to become stable: %s",
+					r.Kind, r.Name, status.Stable.Message)
+			}
+
+			return err
+		}
+	}
+
+	cc.status(c, phase, "All resources are stable.")
+
+	return nil
+}
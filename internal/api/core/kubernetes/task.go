@@ -0,0 +1,114 @@
+package kubernetes
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/homedepot/go-clouddriver/internal"
+	"github.com/homedepot/go-clouddriver/internal/kubernetes"
+	clouddriver "github.com/homedepot/go-clouddriver/pkg"
+)
+
+// Phases recorded in the history of a task, matching those used by OSS clouddriver.
+const (
+	phaseOrchestration  = "ORCHESTRATION"
+	phaseDeploy         = "DEPLOY_KUBERNETES_MANIFEST"
+	phaseDelete         = "DELETE_KUBERNETES_MANIFEST"
+	phaseDisable        = "DISABLE_MANIFEST"
+	phaseEnable         = "ENABLE_MANIFEST"
+	phaseScale          = "SCALE_KUBERNETES_MANIFEST"
+	phaseCleanup        = "CLEANUP_KUBERNETES_ARTIFACTS"
+	phaseRollingRestart = "ROLLING_RESTART_KUBERNETES_MANIFEST"
+	phaseRunJob         = "RUN_KUBERNETES_JOB"
+	phaseUndoRollout    = "UNDO_ROLLOUT_KUBERNETES_MANIFEST"
+	phasePatch          = "PATCH_KUBERNETES_MANIFEST"
+	phasePauseRollout   = "PAUSE_ROLLOUT_KUBERNETES_MANIFEST"
+	phaseResumeRollout  = "RESUME_ROLLOUT_KUBERNETES_MANIFEST"
+	phaseFanOut         = "FAN_OUT_KUBERNETES_MANIFEST"
+	phaseShiftTraffic   = "SHIFT_TRAFFIC_KUBERNETES_MANIFEST"
+
+	keyTaskPhase              = "TaskPhase"
+	keyTaskResults            = "TaskResults"
+	keyTaskDeployTransactions = "TaskDeployTransactions"
+	keyTaskProvider           = "TaskProvider"
+)
+
+// Execute performs each request in the kubernetes operations in order,
+// stopping at the first operation that attaches an error to the context.
+//
+// If the operation failed because of server-side apply conflicts, the
+// conflicting fields are added to the task's results. Any atomic deploys
+// performed by the task are rolled back.
+func (cc *Controller) Execute(c *gin.Context, ko Operations) {
+	for _, op := range ko {
+		op.perform(cc, c)
+
+		if len(c.Errors) > 0 {
+			var ce *kubernetes.ConflictError
+			if errors.As(c.Errors.Last().Err, &ce) {
+				addResult(c, clouddriver.TaskResultObject{
+					BoundArtifacts:                    []clouddriver.Artifact{},
+					CreatedArtifacts:                  []clouddriver.Artifact{},
+					DeployedNamesByLocation:           map[string][]string{},
+					ManifestNamesByNamespace:          map[string][]string{},
+					ManifestNamesByNamespaceToRefresh: map[string][]string{},
+					Manifests:                         []map[string]interface{}{},
+					FieldConflicts:                    ce.Conflicts,
+				})
+			}
+
+			cc.rollbackDeploys(c)
+
+			return
+		}
+	}
+}
+
+// status records a status message in the history of the task in the
+// context, remembering the phase so a later failure can be attributed to it.
+func (cc *Controller) status(c *gin.Context, phase, status string) {
+	c.Set(keyTaskPhase, phase)
+	cc.recordHistory(clouddriver.TaskIDFromContext(c), phase, status)
+}
+
+// recordHistory saves a phase and status message for a task. History is
+// informational only, so errors are logged instead of failing the task.
+func (cc *Controller) recordHistory(taskID, phase, status string) {
+	th := clouddriver.TaskHistory{
+		TaskID:    taskID,
+		Phase:     phase,
+		Status:    status,
+		Timestamp: internal.CurrentTimeUTC(),
+	}
+
+	err := cc.SQLClient.CreateTaskHistory(th)
+	if err != nil {
+		clouddriver.Log(fmt.Errorf("error recording history for task %s: %w", taskID, err))
+	}
+}
+
+// addResult adds a result object to save with the task in the context.
+// Most operations record kubernetes resources instead, which the task's
+// result objects are built from.
+func addResult(c *gin.Context, ro clouddriver.TaskResultObject) {
+	c.Set(keyTaskResults, append(results(c), ro))
+}
+
+// results returns the result objects added to the context.
+func results(c *gin.Context) []clouddriver.TaskResultObject {
+	if v, ok := c.Get(keyTaskResults); ok {
+		return v.([]clouddriver.TaskResultObject)
+	}
+
+	return nil
+}
+
+// phase returns the phase of the last status recorded in the context.
+func phase(c *gin.Context) string {
+	if p := c.GetString(keyTaskPhase); p != "" {
+		return p
+	}
+
+	return phaseOrchestration
+}
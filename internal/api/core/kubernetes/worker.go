@@ -0,0 +1,256 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/homedepot/go-clouddriver/internal"
+	clouddriver "github.com/homedepot/go-clouddriver/pkg"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultTaskPollInterval  = time.Second
+	defaultTaskLeaseDuration = time.Minute
+	// maxTaskAttempts is the number of times a task is claimed before it
+	// is failed instead of run, so a task that keeps taking down the
+	// replicas running it is not resumed forever.
+	maxTaskAttempts = 3
+)
+
+// TaskWorker claims queued kubernetes operation tasks from the DB
+// and executes them in the background.
+//
+// Each claimed task holds a lease that is renewed while the task runs.
+// If a replica dies mid-task its lease expires and the task is claimed
+// and run again by another replica. A task whose lease is lost, or that is
+// running when the worker shuts down, is canceled and left for the replica
+// that claims it next.
+type TaskWorker struct {
+	*Controller
+	engine        *gin.Engine
+	owner         string
+	size          int
+	pollInterval  time.Duration
+	leaseDuration time.Duration
+}
+
+// NewTaskWorker returns a worker pool of the given size that
+// executes tasks using the internal controller.
+func NewTaskWorker(ic *internal.Controller, size int) *TaskWorker {
+	hostname, _ := os.Hostname()
+
+	return &TaskWorker{
+		Controller:    &Controller{ic},
+		engine:        gin.New(),
+		owner:         hostname + "-" + uuid.New().String(),
+		size:          size,
+		pollInterval:  defaultTaskPollInterval,
+		leaseDuration: defaultTaskLeaseDuration,
+	}
+}
+
+// WithLeaseDuration sets how long the lease on a claimed task lasts
+// before it must be renewed.
+func (w *TaskWorker) WithLeaseDuration(d time.Duration) *TaskWorker {
+	w.leaseDuration = d
+	return w
+}
+
+// Start starts each worker in the pool. Workers stop polling
+// for new tasks when the context is done.
+func (w *TaskWorker) Start(ctx context.Context) {
+	for i := 0; i < w.size; i++ {
+		go w.work(ctx)
+	}
+}
+
+func (w *TaskWorker) work(ctx context.Context) {
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// Drain all claimable tasks before waiting on the next tick.
+		for ctx.Err() == nil && w.runNext(ctx) {
+		}
+	}
+}
+
+// runNext claims and runs the next task, returning false if
+// there was no task to claim.
+func (w *TaskWorker) runNext(ctx context.Context) bool {
+	t, err := w.SQLClient.ClaimTask(w.owner, w.leaseExpiry())
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			clouddriver.Log(fmt.Errorf("error claiming task: %w", err))
+		}
+
+		return false
+	}
+
+	w.Run(ctx, t)
+
+	return true
+}
+
+// Run executes a claimed task and records whether it succeeded or failed.
+// The operations are canceled when the context is done or the lease on
+// the task is lost, in which case the state of the task is left as is.
+func (w *TaskWorker) Run(ctx context.Context, t clouddriver.TaskRecord) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go w.renewLease(ctx, cancel, t.ID)
+
+	defer func() {
+		if r := recover(); r != nil {
+			w.fail(t.ID, phaseOrchestration, fmt.Sprintf("panic executing task: %v", r))
+		}
+	}()
+
+	if t.Attempts > maxTaskAttempts {
+		w.fail(t.ID, phaseOrchestration, fmt.Sprintf("task abandoned after %d attempts", maxTaskAttempts))
+		return
+	}
+
+	if t.Attempts > 1 {
+		w.recordHistory(t.ID, phaseOrchestration, fmt.Sprintf("Resuming Orchestration Task (attempt %d)", t.Attempts))
+	} else {
+		w.recordHistory(t.ID, phaseOrchestration, "Initializing Orchestration Task")
+	}
+
+	ko := Operations{}
+
+	err := json.Unmarshal([]byte(t.Body), &ko)
+	if err != nil {
+		w.fail(t.ID, phaseOrchestration, fmt.Sprintf("error decoding task operations: %v", err))
+		return
+	}
+
+	c, err := w.newTaskContext(ctx, t)
+	if err != nil {
+		w.fail(t.ID, phaseOrchestration, err.Error())
+		return
+	}
+
+	// A resumed task may have recorded resources during a previous attempt,
+	// so clear them out to have the task only report on this attempt.
+	if t.Attempts > 1 {
+		err = w.SQLClient.DeleteKubernetesResourcesByTaskID(t.ID)
+		if err != nil {
+			w.fail(t.ID, phaseOrchestration, fmt.Sprintf("error clearing resources from previous attempt: %v", err))
+			return
+		}
+	}
+
+	w.Execute(c, ko)
+
+	if ctx.Err() != nil {
+		clouddriver.Log(fmt.Errorf("task %s stopped before completing: %w", t.ID, ctx.Err()))
+		return
+	}
+
+	// Results are saved for failed tasks too, as they may describe the failure.
+	if ro := results(c); len(ro) > 0 {
+		b, err := json.Marshal(ro)
+		if err != nil {
+			w.fail(t.ID, phaseOrchestration, fmt.Sprintf("error encoding task result: %v", err))
+			return
+		}
+
+		err = w.SQLClient.UpdateTaskResult(t.ID, w.owner, string(b))
+		if err != nil {
+			w.fail(t.ID, phaseOrchestration, fmt.Sprintf("error saving task result: %v", err))
+			return
+		}
+	}
+
+	if len(c.Errors) > 0 {
+		w.fail(t.ID, phase(c), c.Errors.Last().Error())
+		return
+	}
+
+	w.recordHistory(t.ID, phaseOrchestration, "Orchestration completed.")
+	w.finish(t.ID, clouddriver.TaskStateSucceeded, "")
+}
+
+// fail records the failure message in the history of the task
+// under the given phase and marks the task as failed.
+func (w *TaskWorker) fail(id, phase, message string) {
+	w.recordHistory(id, phase, message)
+	w.finish(id, clouddriver.TaskStateFailed, message)
+}
+
+func (w *TaskWorker) finish(id, state, message string) {
+	err := w.SQLClient.UpdateTaskState(id, w.owner, state, message)
+	if err != nil {
+		clouddriver.Log(fmt.Errorf("error updating task %s to state %s: %w", id, state, err))
+	}
+}
+
+// renewLease periodically extends the lease on a task until the context
+// is done, canceling the task if the lease cannot be renewed.
+func (w *TaskWorker) renewLease(ctx context.Context, cancel context.CancelFunc, id string) {
+	ticker := time.NewTicker(w.leaseDuration / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := w.SQLClient.RenewTaskLease(id, w.owner, w.leaseExpiry())
+			if err != nil {
+				clouddriver.Log(fmt.Errorf("error renewing lease for task %s: %w", id, err))
+				cancel()
+
+				return
+			}
+		}
+	}
+}
+
+func (w *TaskWorker) leaseExpiry() time.Time {
+	return time.Now().UTC().Add(w.leaseDuration)
+}
+
+// newTaskContext builds the context the operations of a task are executed in,
+// restoring the task ID and the headers of the request that created the task.
+// The request carries ctx so long running operations stop when it is done.
+func (w *TaskWorker) newTaskContext(ctx context.Context, t clouddriver.TaskRecord) (*gin.Context, error) {
+	header := http.Header{}
+
+	if t.Headers != "" {
+		err := json.Unmarshal([]byte(t.Headers), &header)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding task headers: %w", err)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/kubernetes/ops", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header = header
+
+	c := gin.CreateTestContextOnly(httptest.NewRecorder(), w.engine)
+	c.Request = req
+	c.Set(clouddriver.TaskIDKey, t.ID)
+
+	return c, nil
+}
@@ -0,0 +1,305 @@
+package kubernetes_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	. "github.com/homedepot/go-clouddriver/internal/api/core/kubernetes"
+	"github.com/homedepot/go-clouddriver/internal/kubernetes"
+	clouddriver "github.com/homedepot/go-clouddriver/pkg"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var _ = Describe("TaskWorker", func() {
+	var (
+		worker *TaskWorker
+		task   clouddriver.TaskRecord
+		ctx    context.Context
+	)
+
+	BeforeEach(func() {
+		setup()
+
+		worker = NewTaskWorker(kubernetesController.Controller, 1)
+		ctx = context.Background()
+
+		b, _ := json.Marshal(Operations{
+			{
+				"scaleManifest": scaleManifestRequest,
+			},
+		})
+		task = clouddriver.TaskRecord{
+			ID:       "test-task-id",
+			State:    clouddriver.TaskStateRunning,
+			Body:     string(b),
+			Headers:  `{"X-Spinnaker-Application":["test-app"]}`,
+			Attempts: 1,
+		}
+	})
+
+	JustBeforeEach(func() {
+		worker.Run(ctx, task)
+	})
+
+	When("the task body cannot be decoded", func() {
+		BeforeEach(func() {
+			task.Body = "{}"
+		})
+
+		It("fails the task", func() {
+			Expect(fakeSQLClient.UpdateTaskStateCallCount()).To(Equal(1))
+			id, _, state, message := fakeSQLClient.UpdateTaskStateArgsForCall(0)
+			Expect(id).To(Equal("test-task-id"))
+			Expect(state).To(Equal(clouddriver.TaskStateFailed))
+			Expect(message).To(HavePrefix("error decoding task operations: "))
+		})
+
+		It("records the error in the history", func() {
+			th := fakeSQLClient.CreateTaskHistoryArgsForCall(1)
+			Expect(th.Phase).To(Equal("ORCHESTRATION"))
+			Expect(th.Status).To(HavePrefix("error decoding task operations: "))
+		})
+	})
+
+	When("the task headers cannot be decoded", func() {
+		BeforeEach(func() {
+			task.Headers = "[]"
+		})
+
+		It("fails the task", func() {
+			Expect(fakeSQLClient.UpdateTaskStateCallCount()).To(Equal(1))
+			_, _, state, message := fakeSQLClient.UpdateTaskStateArgsForCall(0)
+			Expect(state).To(Equal(clouddriver.TaskStateFailed))
+			Expect(message).To(HavePrefix("error decoding task headers: "))
+		})
+	})
+
+	When("an operation returns an error", func() {
+		BeforeEach(func() {
+			fakeSQLClient.GetKubernetesProviderReturns(kubernetes.Provider{}, errors.New("error getting provider"))
+		})
+
+		It("fails the task with the error", func() {
+			Expect(fakeSQLClient.UpdateTaskStateCallCount()).To(Equal(1))
+			id, _, state, message := fakeSQLClient.UpdateTaskStateArgsForCall(0)
+			Expect(id).To(Equal("test-task-id"))
+			Expect(state).To(Equal(clouddriver.TaskStateFailed))
+			Expect(message).To(Equal("internal: error getting kubernetes provider spin-cluster-account: error getting provider"))
+		})
+
+		It("records the error in the history under the phase of the operation", func() {
+			count := fakeSQLClient.CreateTaskHistoryCallCount()
+			th := fakeSQLClient.CreateTaskHistoryArgsForCall(count - 1)
+			Expect(th.TaskID).To(Equal("test-task-id"))
+			Expect(th.Phase).To(Equal("SCALE_KUBERNETES_MANIFEST"))
+			Expect(th.Status).To(Equal("internal: error getting kubernetes provider spin-cluster-account: error getting provider"))
+		})
+	})
+
+	When("the task is being resumed", func() {
+		BeforeEach(func() {
+			task.Attempts = 2
+		})
+
+		It("clears the resources from the previous attempt", func() {
+			Expect(fakeSQLClient.DeleteKubernetesResourcesByTaskIDCallCount()).To(Equal(1))
+			Expect(fakeSQLClient.DeleteKubernetesResourcesByTaskIDArgsForCall(0)).To(Equal("test-task-id"))
+			_, _, state, _ := fakeSQLClient.UpdateTaskStateArgsForCall(0)
+			Expect(state).To(Equal(clouddriver.TaskStateSucceeded))
+		})
+
+		It("records the task was resumed", func() {
+			th := fakeSQLClient.CreateTaskHistoryArgsForCall(0)
+			Expect(th.Phase).To(Equal("ORCHESTRATION"))
+			Expect(th.Status).To(Equal("Resuming Orchestration Task (attempt 2)"))
+		})
+	})
+
+	When("the task has been attempted too many times", func() {
+		BeforeEach(func() {
+			task.Attempts = 4
+		})
+
+		It("fails the task without running it", func() {
+			Expect(fakeKubeClient.ApplyCallCount()).To(Equal(0))
+			Expect(fakeSQLClient.UpdateTaskStateCallCount()).To(Equal(1))
+			_, _, state, message := fakeSQLClient.UpdateTaskStateArgsForCall(0)
+			Expect(state).To(Equal(clouddriver.TaskStateFailed))
+			Expect(message).To(Equal("task abandoned after 3 attempts"))
+		})
+	})
+
+	When("the worker is shutting down", func() {
+		BeforeEach(func() {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithCancel(ctx)
+			cancel()
+		})
+
+		It("leaves the state of the task as is", func() {
+			Expect(fakeSQLClient.UpdateTaskStateCallCount()).To(Equal(0))
+		})
+	})
+
+	When("the worker shuts down while a task is pausing between steps", func() {
+		var start time.Time
+
+		BeforeEach(func() {
+			b, _ := json.Marshal(Operations{
+				{
+					"shiftTrafficManifest": ShiftTrafficManifestRequest{
+						Account:            "test-account",
+						Location:           "test-namespace",
+						SourceManifestName: "ReplicaSet test-rs-v001",
+						TargetManifestName: "ReplicaSet test-rs-v002",
+						Steps:              []int{50, 100},
+						PauseSeconds:       60,
+					},
+				},
+			})
+			task.Body = string(b)
+			fakeKubeClient.GetStub = func(kind, name, namespace string) (*unstructured.Unstructured, error) {
+				u := &unstructured.Unstructured{Object: map[string]interface{}{}}
+				u.SetKind(kind)
+				u.SetName(name)
+				u.SetAnnotations(map[string]string{
+					kubernetes.AnnotationSpinnakerMonikerCluster:       "replicaSet test-rs",
+					kubernetes.AnnotationSpinnakerTrafficLoadBalancers: `["service test-svc"]`,
+				})
+
+				return u, nil
+			}
+			fakeKubeClient.ListResourceWithContextReturns(&unstructured.UnstructuredList{}, nil)
+
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithCancel(ctx)
+			time.AfterFunc(50*time.Millisecond, cancel)
+			start = time.Now()
+		})
+
+		It("stops the task without waiting out the pause", func() {
+			count := fakeSQLClient.CreateTaskHistoryCallCount()
+			th := fakeSQLClient.CreateTaskHistoryArgsForCall(count - 1)
+			Expect(th.Status).To(Equal("Pausing for 60 seconds..."))
+			Expect(time.Since(start)).To(BeNumerically("<", 10*time.Second))
+			Expect(fakeSQLClient.UpdateTaskStateCallCount()).To(Equal(0))
+		})
+	})
+
+	When("the lease on the task is lost", func() {
+		BeforeEach(func() {
+			worker.WithLeaseDuration(30 * time.Millisecond)
+			fakeSQLClient.RenewTaskLeaseReturns(errors.New("task test-task-id is no longer owned"))
+			fakeKubeClient.ApplyStub = func(u *unstructured.Unstructured) (kubernetes.Metadata, error) {
+				time.Sleep(100 * time.Millisecond)
+				return kubernetes.Metadata{}, nil
+			}
+		})
+
+		It("stops renewing the lease", func() {
+			Expect(fakeSQLClient.RenewTaskLeaseCallCount()).To(Equal(1))
+		})
+
+		It("leaves the state of the task to its new owner", func() {
+			Expect(fakeSQLClient.UpdateTaskStateCallCount()).To(Equal(0))
+		})
+	})
+
+	When("clearing the resources from the previous attempt returns an error", func() {
+		BeforeEach(func() {
+			task.Attempts = 2
+			fakeSQLClient.DeleteKubernetesResourcesByTaskIDReturns(errors.New("error deleting resources"))
+		})
+
+		It("fails the task", func() {
+			Expect(fakeKubeClient.ApplyCallCount()).To(Equal(0))
+			_, _, state, message := fakeSQLClient.UpdateTaskStateArgsForCall(0)
+			Expect(state).To(Equal(clouddriver.TaskStateFailed))
+			Expect(message).To(Equal("error clearing resources from previous attempt: error deleting resources"))
+		})
+	})
+
+	When("an operation adds results to the task", func() {
+		BeforeEach(func() {
+			deployManifestRequest.DryRun = true
+			b, _ := json.Marshal(Operations{
+				{
+					"deployManifest": deployManifestRequest,
+				},
+			})
+			task.Body = string(b)
+			fakeKubeClient.DryRunApplyStub = func(u *unstructured.Unstructured) (*unstructured.Unstructured, *unstructured.Unstructured, error) {
+				return nil, u.DeepCopy(), nil
+			}
+		})
+
+		It("saves the results with the task", func() {
+			Expect(fakeSQLClient.UpdateTaskResultCallCount()).To(Equal(1))
+			id, owner, result := fakeSQLClient.UpdateTaskResultArgsForCall(0)
+			Expect(id).To(Equal("test-task-id"))
+			Expect(owner).ToNot(BeEmpty())
+			ro := []clouddriver.TaskResultObject{}
+			err := json.Unmarshal([]byte(result), &ro)
+			Expect(err).To(BeNil())
+			Expect(ro).To(HaveLen(1))
+			Expect(ro[0].ManifestDiffs).To(HaveLen(1))
+			Expect(ro[0].ManifestDiffs[0].Name).To(Equal("test-name-v000"))
+			Expect(ro[0].ManifestDiffs[0].Exists).To(BeFalse())
+			Expect(ro[0].ManifestDiffs[0].Changed).To(BeTrue())
+			_, _, state, _ := fakeSQLClient.UpdateTaskStateArgsForCall(0)
+			Expect(state).To(Equal(clouddriver.TaskStateSucceeded))
+		})
+
+		When("saving the results returns an error", func() {
+			BeforeEach(func() {
+				fakeSQLClient.UpdateTaskResultReturns(errors.New("error updating task"))
+			})
+
+			It("fails the task", func() {
+				_, _, state, message := fakeSQLClient.UpdateTaskStateArgsForCall(0)
+				Expect(state).To(Equal(clouddriver.TaskStateFailed))
+				Expect(message).To(Equal("error saving task result: error updating task"))
+			})
+		})
+	})
+
+	When("it succeeds", func() {
+		It("doesn't save any results with the task", func() {
+			Expect(fakeSQLClient.UpdateTaskResultCallCount()).To(Equal(0))
+		})
+
+		It("performs the operations in the context of the task", func() {
+			Expect(fakeKubeClient.ApplyCallCount()).To(Equal(1))
+			Expect(fakeSQLClient.DeleteKubernetesResourcesByTaskIDCallCount()).To(Equal(0))
+			Expect(fakeSQLClient.CreateKubernetesResourceCallCount()).To(Equal(1))
+			kr := fakeSQLClient.CreateKubernetesResourceArgsForCall(0)
+			Expect(kr.TaskID).To(Equal("test-task-id"))
+			Expect(kr.SpinnakerApp).To(Equal("test-app"))
+		})
+
+		It("succeeds the task", func() {
+			Expect(fakeSQLClient.UpdateTaskStateCallCount()).To(Equal(1))
+			id, _, state, message := fakeSQLClient.UpdateTaskStateArgsForCall(0)
+			Expect(id).To(Equal("test-task-id"))
+			Expect(state).To(Equal(clouddriver.TaskStateSucceeded))
+			Expect(message).To(BeEmpty())
+			_, owner, _, _ := fakeSQLClient.UpdateTaskStateArgsForCall(0)
+			Expect(owner).ToNot(BeEmpty())
+		})
+
+		It("records the task history", func() {
+			Expect(fakeSQLClient.CreateTaskHistoryCallCount()).To(Equal(4))
+			th := fakeSQLClient.CreateTaskHistoryArgsForCall(0)
+			Expect(th.TaskID).To(Equal("test-task-id"))
+			Expect(th.Phase).To(Equal("ORCHESTRATION"))
+			Expect(th.Status).To(Equal("Initializing Orchestration Task"))
+			th = fakeSQLClient.CreateTaskHistoryArgsForCall(3)
+			Expect(th.Phase).To(Equal("ORCHESTRATION"))
+			Expect(th.Status).To(Equal("Orchestration completed."))
+		})
+	})
+})
@@ -170,6 +170,55 @@ func getEvents(provider *kubernetes.Provider,
 	}
 }
 
+// GetManifestManagedFields returns the fields of a manifest owned by each of
+// its field managers for a given account (cluster), namespace, kind, and name.
+func (cc *Controller) GetManifestManagedFields(c *gin.Context) {
+	account := c.Param("account")
+	namespace := c.Param("location")
+	n := c.Param("kind")
+
+	a := strings.Split(n, " ")
+	if len(a) != 2 {
+		clouddriver.Error(c, http.StatusBadRequest, fmt.Errorf("invalid manifest name: %s", n))
+		return
+	}
+
+	kind := a[0]
+	name := a[1]
+
+	if strings.Contains(kind, ".") {
+		a2 := strings.Split(kind, ".")
+		kind = a2[0]
+	}
+
+	provider, err := cc.KubernetesProvider(account)
+	if err != nil {
+		clouddriver.Error(c, http.StatusBadRequest, err)
+		return
+	}
+
+	manifest, err := provider.Client.Get(kind, name, namespace)
+	if err != nil {
+		clouddriver.Error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	mfs, err := kubernetes.ListManagedFields(manifest)
+	if err != nil {
+		clouddriver.Error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	mmfr := ops.ManifestManagedFieldsResponse{
+		Account:       account,
+		Location:      namespace,
+		Name:          fmt.Sprintf("%s %s", kind, name),
+		ManagedFields: mfs,
+	}
+
+	c.JSON(http.StatusOK, mmfr)
+}
+
 func (cc *Controller) GetManifestByCriteria(c *gin.Context) {
 	account := c.Param("account")
 	application := c.Param("application")
@@ -203,6 +203,109 @@ var _ = Describe("Manifest", func() {
 		})
 	})
 
+	Describe("#GetManifestManagedFields", func() {
+		BeforeEach(func() {
+			setup()
+			uri = svr.URL + "/manifests/test-account/test-namespace/deployment test-deployment/managedFields"
+			createRequest(http.MethodGet)
+			u := &unstructured.Unstructured{Object: map[string]interface{}{}}
+			u.SetManagedFields([]metav1.ManagedFieldsEntry{
+				{
+					Manager:    "spinnaker",
+					Operation:  metav1.ManagedFieldsOperationApply,
+					APIVersion: "apps/v1",
+					FieldsType: "FieldsV1",
+					FieldsV1: &metav1.FieldsV1{
+						Raw: []byte(`{"f:spec":{"f:replicas":{},"f:template":{"f:spec":{"f:containers":{"k:{\"name\":\"test-container\"}":{".":{},"f:image":{}}}}}}}`),
+					},
+				},
+			})
+			fakeKubeClient.GetReturns(u, nil)
+		})
+
+		AfterEach(func() {
+			teardown()
+		})
+
+		JustBeforeEach(func() {
+			doRequest()
+		})
+
+		When("the manifest name is invalid", func() {
+			BeforeEach(func() {
+				uri = svr.URL + "/manifests/test-account/test-namespace/test-deployment/managedFields"
+				createRequest(http.MethodGet)
+			})
+
+			It("returns status bad request", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+				ce := getClouddriverError()
+				Expect(ce.Error).To(HavePrefix("Bad Request"))
+				Expect(ce.Message).To(Equal("invalid manifest name: test-deployment"))
+				Expect(ce.Status).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("getting the provider returns an error", func() {
+			BeforeEach(func() {
+				fakeSQLClient.GetKubernetesProviderReturns(kubernetes.Provider{}, errors.New("error getting provider"))
+			})
+
+			It("returns status bad request", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+				ce := getClouddriverError()
+				Expect(ce.Error).To(HavePrefix("Bad Request"))
+				Expect(ce.Message).To(Equal("internal: error getting kubernetes provider test-account: error getting provider"))
+				Expect(ce.Status).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("getting the manifest returns an error", func() {
+			BeforeEach(func() {
+				fakeKubeClient.GetReturns(nil, errors.New("error getting manifest"))
+			})
+
+			It("returns status internal server error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
+				ce := getClouddriverError()
+				Expect(ce.Error).To(HavePrefix("Internal Server Error"))
+				Expect(ce.Message).To(Equal("error getting manifest"))
+				Expect(ce.Status).To(Equal(http.StatusInternalServerError))
+			})
+		})
+
+		When("the managed fields cannot be decoded", func() {
+			BeforeEach(func() {
+				u := &unstructured.Unstructured{Object: map[string]interface{}{}}
+				u.SetManagedFields([]metav1.ManagedFieldsEntry{
+					{
+						Manager:  "spinnaker",
+						FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"unknown":{}}`)},
+					},
+				})
+				fakeKubeClient.GetReturns(u, nil)
+			})
+
+			It("returns status internal server error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
+				ce := getClouddriverError()
+				Expect(ce.Message).To(HavePrefix("error decoding fields of manager spinnaker: "))
+			})
+		})
+
+		When("it succeeds", func() {
+			It("returns the fields owned by each manager", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				Expect(fakeKubeClient.GetCallCount()).To(Equal(1))
+				kind, name, namespace := fakeKubeClient.GetArgsForCall(0)
+				Expect(kind).To(Equal("deployment"))
+				Expect(name).To(Equal("test-deployment"))
+				Expect(namespace).To(Equal("test-namespace"))
+				validateResponse(payloadManifestManagedFields)
+			})
+		})
+	})
+
 	Describe("#GetManifestByCriteria", func() {
 		var criteria string
 
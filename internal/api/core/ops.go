@@ -1,7 +1,9 @@
 package core
 
 import (
+	"encoding/json"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
@@ -9,6 +11,8 @@ import (
 	clouddriver "github.com/homedepot/go-clouddriver/pkg"
 )
 
+const headerSpinnakerPrefix = "X-Spinnaker-"
+
 // CreateKubernetesOperation is the main function that starts a kubernetes operation.
 //
 // Kubernetes operations are things like deploy/delete manifest or perform
@@ -16,8 +20,10 @@ import (
 // same endpoint (/kubernetes/ops), so we have to unmarshal and check which
 // kind of operation we are performing.
 //
-// The actual actions have been moved to the kubernetes subfolder to make
-// this function a bit more readable.
+// Operations are not performed during the request. They are stored
+// in the DB as a pending task, along with the Spinnaker headers of the
+// request, and executed in the background by a kubernetes.TaskWorker.
+// The status of the task is then reported by the Task API.
 func (cc *Controller) CreateKubernetesOperation(c *gin.Context) {
 	// All operations are bound to a task ID and stored in the database.
 	ko := kubernetes.Operations{}
@@ -28,55 +34,30 @@ func (cc *Controller) CreateKubernetesOperation(c *gin.Context) {
 		return
 	}
 
-	kc := kubernetes.Controller{
-		Controller: cc.Controller,
-	}
-	// Loop through each request in the kubernetes operations and perform
-	// each requested action.
-	for _, req := range ko {
-		if req.DeployManifest != nil {
-			kc.Deploy(c, *req.DeployManifest)
-		}
-
-		if req.DeleteManifest != nil {
-			kc.Delete(c, *req.DeleteManifest)
-		}
-
-		if req.DisableManifest != nil {
-			kc.Disable(c, *req.DisableManifest)
-		}
-
-		if req.EnableManifest != nil {
-			kc.Enable(c, *req.EnableManifest)
-		}
+	headers := http.Header{}
 
-		if req.ScaleManifest != nil {
-			kc.Scale(c, *req.ScaleManifest)
-		}
-
-		if req.CleanupArtifacts != nil {
-			kc.CleanupArtifacts(c, *req.CleanupArtifacts)
-		}
-
-		if req.RollingRestartManifest != nil {
-			kc.RollingRestart(c, *req.RollingRestartManifest)
-		}
-
-		if req.RunJob != nil {
-			kc.RunJob(c, *req.RunJob)
+	for key, values := range c.Request.Header {
+		if strings.HasPrefix(key, headerSpinnakerPrefix) {
+			headers[key] = values
 		}
+	}
 
-		if req.UndoRolloutManifest != nil {
-			kc.Rollback(c, *req.UndoRolloutManifest)
-		}
+	h, err := json.Marshal(headers)
+	if err != nil {
+		clouddriver.Error(c, http.StatusInternalServerError, err)
+		return
+	}
 
-		if req.PatchManifest != nil {
-			kc.Patch(c, *req.PatchManifest)
-		}
+	t := clouddriver.TaskRecord{
+		ID:      taskID,
+		Body:    string(c.MustGet(gin.BodyBytesKey).([]byte)),
+		Headers: string(h),
+	}
 
-		if c.Errors != nil && len(c.Errors) > 0 {
-			return
-		}
+	err = cc.SQLClient.CreateTask(t)
+	if err != nil {
+		clouddriver.Error(c, http.StatusInternalServerError, err)
+		return
 	}
 
 	or := kubernetes.OperationsResponse{
@@ -8,7 +8,6 @@ import (
 	"net/http"
 
 	kube "github.com/homedepot/go-clouddriver/internal/api/core/kubernetes"
-	"github.com/homedepot/go-clouddriver/internal/kubernetes"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -46,88 +45,6 @@ var _ = Describe("Kubernetes", func() {
 			})
 		})
 
-		When("deploying a manifest returns an error", func() {
-			BeforeEach(func() {
-				fakeSQLClient.GetKubernetesProviderReturns(kubernetes.Provider{}, errors.New("error getting kubernetes provider"))
-			})
-
-			It("returns an error", func() {
-				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
-				ce := getClouddriverError()
-				Expect(ce.Error).To(HavePrefix("Bad Request"))
-				Expect(ce.Message).To(Equal("internal: error getting kubernetes provider spin-cluster-account: error getting kubernetes provider"))
-				Expect(ce.Status).To(Equal(http.StatusBadRequest))
-			})
-		})
-
-		When("delete manifest returns an error", func() {
-			BeforeEach(func() {
-				body = &bytes.Buffer{}
-				body.Write([]byte(payloadRequestKubernetesOpsDeleteManifest))
-				createRequest(http.MethodPost)
-				fakeSQLClient.GetKubernetesProviderReturns(kubernetes.Provider{}, errors.New("error getting kubernetes provider"))
-			})
-
-			It("returns an error", func() {
-				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
-				ce := getClouddriverError()
-				Expect(ce.Error).To(HavePrefix("Bad Request"))
-				Expect(ce.Message).To(Equal("internal: error getting kubernetes provider spin-cluster-account: error getting kubernetes provider"))
-				Expect(ce.Status).To(Equal(http.StatusBadRequest))
-			})
-		})
-
-		When("disable manifest returns an error", func() {
-			BeforeEach(func() {
-				body = &bytes.Buffer{}
-				body.Write([]byte(payloadRequestKubernetesOpsDisableManifest))
-				createRequest(http.MethodPost)
-				fakeSQLClient.GetKubernetesProviderReturns(kubernetes.Provider{}, errors.New("error getting kubernetes provider"))
-			})
-
-			It("returns an error", func() {
-				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
-				ce := getClouddriverError()
-				Expect(ce.Error).To(HavePrefix("Bad Request"))
-				Expect(ce.Message).To(Equal("internal: error getting kubernetes provider spin-cluster-account: error getting kubernetes provider"))
-				Expect(ce.Status).To(Equal(http.StatusBadRequest))
-			})
-		})
-
-		When("enable manifest returns an error", func() {
-			BeforeEach(func() {
-				body = &bytes.Buffer{}
-				body.Write([]byte(payloadRequestKubernetesOpsEnableManifest))
-				createRequest(http.MethodPost)
-				fakeSQLClient.GetKubernetesProviderReturns(kubernetes.Provider{}, errors.New("error getting kubernetes provider"))
-			})
-
-			It("returns an error", func() {
-				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
-				ce := getClouddriverError()
-				Expect(ce.Error).To(HavePrefix("Bad Request"))
-				Expect(ce.Message).To(Equal("internal: error getting kubernetes provider spin-cluster-account: error getting kubernetes provider"))
-				Expect(ce.Status).To(Equal(http.StatusBadRequest))
-			})
-		})
-
-		When("scaling the manifest returns an error", func() {
-			BeforeEach(func() {
-				body = &bytes.Buffer{}
-				body.Write([]byte(payloadRequestKubernetesOpsScaleManifest))
-				createRequest(http.MethodPost)
-				fakeSQLClient.GetKubernetesProviderReturns(kubernetes.Provider{}, errors.New("error getting kubernetes provider"))
-			})
-
-			It("returns an error", func() {
-				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
-				ce := getClouddriverError()
-				Expect(ce.Error).To(HavePrefix("Bad Request"))
-				Expect(ce.Message).To(Equal("internal: error getting kubernetes provider spin-cluster-account: error getting kubernetes provider"))
-				Expect(ce.Status).To(Equal(http.StatusBadRequest))
-			})
-		})
-
 		When("cleaning up artifacts returns an error", func() {
 			BeforeEach(func() {
 				body = &bytes.Buffer{}
@@ -144,76 +61,42 @@ var _ = Describe("Kubernetes", func() {
 			})
 		})
 
-		When("a rolling restart returns an error", func() {
+		When("the operation is not supported", func() {
 			BeforeEach(func() {
 				body = &bytes.Buffer{}
-				body.Write([]byte(payloadRequestKubernetesOpsRollingRestartManifest))
+				body.Write([]byte(`[{ "resizeServerGroup": { "account": "spin-cluster-account" } }]`))
 				createRequest(http.MethodPost)
-				fakeSQLClient.GetKubernetesProviderReturns(kubernetes.Provider{}, errors.New("error getting kubernetes provider"))
 			})
 
 			It("returns an error", func() {
 				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
 				ce := getClouddriverError()
 				Expect(ce.Error).To(HavePrefix("Bad Request"))
-				Expect(ce.Message).To(Equal("internal: error getting kubernetes provider spin-cluster-account: error getting kubernetes provider"))
+				Expect(ce.Message).To(Equal("unsupported kubernetes operation: resizeServerGroup"))
 				Expect(ce.Status).To(Equal(http.StatusBadRequest))
+				Expect(fakeSQLClient.CreateTaskCallCount()).To(Equal(0))
 			})
 		})
 
-		When("a run job returns an error", func() {
+		When("creating the task returns an error", func() {
 			BeforeEach(func() {
-				body = &bytes.Buffer{}
-				body.Write([]byte(payloadRequestKubernetesOpsRunJob))
-				createRequest(http.MethodPost)
-				fakeSQLClient.GetKubernetesProviderReturns(kubernetes.Provider{}, errors.New("error getting kubernetes provider"))
+				fakeSQLClient.CreateTaskReturns(errors.New("error creating task"))
 			})
 
 			It("returns an error", func() {
-				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
 				ce := getClouddriverError()
-				Expect(ce.Error).To(HavePrefix("Bad Request"))
-				Expect(ce.Message).To(Equal("internal: error getting kubernetes provider spin-cluster-account: error getting kubernetes provider"))
-				Expect(ce.Status).To(Equal(http.StatusBadRequest))
+				Expect(ce.Error).To(HavePrefix("Internal Server Error"))
+				Expect(ce.Message).To(Equal("error creating task"))
+				Expect(ce.Status).To(Equal(http.StatusInternalServerError))
 			})
 		})
 
-		When("undo rollout returns an error", func() {
+		When("it succeeds", func() {
 			BeforeEach(func() {
-				body = &bytes.Buffer{}
-				body.Write([]byte(payloadRequestKubernetesOpsUndoRolloutManifest))
-				createRequest(http.MethodPost)
 				req.Header.Set("X-Spinnaker-Application", "test-app")
-				fakeSQLClient.GetKubernetesProviderReturns(kubernetes.Provider{}, errors.New("error getting kubernetes provider"))
-			})
-
-			It("returns an error", func() {
-				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
-				ce := getClouddriverError()
-				Expect(ce.Error).To(HavePrefix("Bad Request"))
-				Expect(ce.Message).To(Equal("internal: error getting kubernetes provider spin-cluster-account: error getting kubernetes provider"))
-				Expect(ce.Status).To(Equal(http.StatusBadRequest))
-			})
-		})
-
-		When("patch manifest returns an error", func() {
-			BeforeEach(func() {
-				body = &bytes.Buffer{}
-				body.Write([]byte(payloadRequestKubernetesOpsPatchManifest))
-				createRequest(http.MethodPost)
-				fakeSQLClient.GetKubernetesProviderReturns(kubernetes.Provider{}, errors.New("error getting kubernetes provider"))
-			})
-
-			It("returns an error", func() {
-				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
-				ce := getClouddriverError()
-				Expect(ce.Error).To(HavePrefix("Bad Request"))
-				Expect(ce.Message).To(Equal("internal: error getting kubernetes provider spin-cluster-account: error getting kubernetes provider"))
-				Expect(ce.Status).To(Equal(http.StatusBadRequest))
 			})
-		})
 
-		When("it succeeds", func() {
 			It("succeeds", func() {
 				Expect(res.StatusCode).To(Equal(http.StatusOK))
 				or := kube.OperationsResponse{}
@@ -223,6 +106,22 @@ var _ = Describe("Kubernetes", func() {
 				Expect(or.ID).To(HaveLen(uuidLen))
 				Expect(or.ResourceURI).To(HavePrefix("/task"))
 			})
+
+			It("queues the operations as a task", func() {
+				Expect(fakeSQLClient.CreateTaskCallCount()).To(Equal(1))
+				t := fakeSQLClient.CreateTaskArgsForCall(0)
+				Expect(t.ID).To(HaveLen(36))
+				Expect(t.Body).To(Equal(payloadRequestKubernetesOpsDeployManifest))
+				headers := http.Header{}
+				err := json.Unmarshal([]byte(t.Headers), &headers)
+				Expect(err).To(BeNil())
+				Expect(headers.Get("X-Spinnaker-Application")).To(Equal("test-app"))
+				Expect(headers.Get("Content-Type")).To(BeEmpty())
+			})
+
+			It("does not perform the operations", func() {
+				Expect(fakeKubeClient.ApplyCallCount()).To(Equal(0))
+			})
 		})
 	})
 })
@@ -3617,6 +3617,55 @@ const payloadGetServerGroup = `{
           }`
 
 const payloadGetJob = `{
+            "account": "test-account",
+            "completionDetails": {
+              "exitCode": "",
+              "message": "",
+              "reason": "",
+              "signal": ""
+            },
+            "createdTime": 1581603123000,
+            "jobState": "Running",
+            "location": "test-namespace",
+            "mostRecentPodName": "test-job1-def34",
+            "name": "test-job1",
+            "pods": [
+              {
+                "name": "test-job1-abc12",
+                "status": {
+                  "phase": "Failed",
+                  "containerStatuses": [
+                    {
+                      "name": "test-container",
+                      "state": {
+                        "terminated": {
+                          "exitCode": 2,
+                          "reason": "Error",
+                          "message": "test-message",
+                          "startedAt": null,
+                          "finishedAt": null
+                        }
+                      },
+                      "lastState": {},
+                      "ready": false,
+                      "restartCount": 0,
+                      "image": "",
+                      "imageID": ""
+                    }
+                  ]
+                }
+              },
+              {
+                "name": "test-job1-def34",
+                "status": {
+                  "phase": "Running"
+                }
+              }
+            ],
+            "provider": "kubernetes"
+          }`
+
+const payloadGetJobNoPods = `{
             "account": "test-account",
             "completionDetails": {
               "exitCode": "",
@@ -3632,16 +3681,54 @@ const payloadGetJob = `{
             "provider": "kubernetes"
           }`
 
-const payloadSearchDefault = `[
-							 {
-								 "pageNumber": 1,
-								 "pageSize": 500,
-								 "platform": "aws",
-								 "query": "default",
-								 "results": [],
-								 "totalMatches": 0
-							 }
-						 ]`
+const payloadGetJobFailed = `{
+            "account": "test-account",
+            "completionDetails": {
+              "exitCode": "2",
+              "message": "test-message",
+              "reason": "Error",
+              "signal": "0"
+            },
+            "createdTime": 1581603123000,
+            "jobState": "Failed",
+            "location": "test-namespace",
+            "mostRecentPodName": "test-job1-def34",
+            "name": "test-job1",
+            "pods": [
+              {
+                "name": "test-job1-abc12",
+                "status": {
+                  "phase": "Failed",
+                  "containerStatuses": [
+                    {
+                      "name": "test-container",
+                      "state": {
+                        "terminated": {
+                          "exitCode": 2,
+                          "reason": "Error",
+                          "message": "test-message",
+                          "startedAt": null,
+                          "finishedAt": null
+                        }
+                      },
+                      "lastState": {},
+                      "ready": false,
+                      "restartCount": 0,
+                      "image": "",
+                      "imageID": ""
+                    }
+                  ]
+                }
+              },
+              {
+                "name": "test-job1-def34",
+                "status": {
+                  "phase": "Running"
+                }
+              }
+            ],
+            "provider": "kubernetes"
+          }`
 
 const payloadSearchEmptyResponse = `[
 							 {
@@ -4014,6 +4101,28 @@ const payloadGetInstanceConsole = `{
             ]
           }`
 
+const payloadGetInstanceConsoleDeployment = `{
+            "output": [
+              {
+                "name": "test-pod1/test-container-name",
+                "output": "log output"
+              },
+              {
+                "name": "test-pod3/test-container-name",
+                "output": "log output"
+              }
+            ]
+          }`
+
+const payloadGetInstanceConsoleJob = `{
+            "output": [
+              {
+                "name": "test-job-pod1/test-container-name",
+                "output": "log output"
+              }
+            ]
+          }`
+
 const payloadListProjectClustersNoMatches = `[
 	{
 		"account": "test-account-1",
@@ -4196,6 +4305,7 @@ const payloadListProjectClusters = `[
 
 const payloadTaskIncomplete = `{
               "id": "task-id",
+              "history": [],
               "resultObjects": [
                 {
                   "boundArtifacts": [
@@ -4249,6 +4359,7 @@ const payloadTaskIncomplete = `{
                   ]
                 }
               ],
+              "sagaIds": [],
               "status": {
                 "complete": false,
                 "completed": false,
@@ -4261,6 +4372,7 @@ const payloadTaskIncomplete = `{
 
 const payloadTaskComplete = `{
               "id": "task-id",
+              "history": [],
               "resultObjects": [
                 {
                   "boundArtifacts": [
@@ -4307,6 +4419,7 @@ const payloadTaskComplete = `{
                   ]
                 }
               ],
+              "sagaIds": [],
               "status": {
                 "complete": true,
                 "completed": true,
@@ -4316,3 +4429,59 @@ const payloadTaskComplete = `{
                 "status": "Orchestration completed."
               }
             }`
+
+const payloadManifestManagedFields = `{
+  "account": "test-account",
+  "location": "test-namespace",
+  "name": "deployment test-deployment",
+  "managedFields": [
+    {
+      "manager": "spinnaker",
+      "operation": "Apply",
+      "apiVersion": "apps/v1",
+      "time": "0001-01-01T00:00:00Z",
+      "fields": [
+        ".spec.replicas",
+        ".spec.template.spec.containers[name=\"test-container\"].image"
+      ]
+    }
+  ]
+}`
+
+const payloadListPreconfiguredJobs = `[
+  {
+    "account": "test-account",
+    "application": "test-application",
+    "cloudProvider": "kubernetes",
+    "credentials": "test-account",
+    "description": "Runs a test job.",
+    "label": "Test Job",
+    "manifest": {
+      "kind": "Job"
+    },
+    "parameters": [
+      {
+        "name": "image",
+        "label": "Image",
+        "description": "",
+        "type": "string",
+        "mapping": "spec.template.spec.containers[0].image",
+        "defaultValue": "test-image:latest",
+        "order": 0
+      },
+      {
+        "name": "team",
+        "label": "Team",
+        "description": "",
+        "type": "",
+        "mapping": "metadata.labels.team",
+        "defaultValue": "",
+        "order": 1
+      }
+    ],
+    "producesArtifacts": true,
+    "propertyFile": "test-container",
+    "type": "test-job",
+    "waitForCompletion": true
+  }
+]`
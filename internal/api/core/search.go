@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -12,6 +14,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/homedepot/go-clouddriver/internal"
 	"github.com/homedepot/go-clouddriver/internal/kubernetes"
@@ -21,14 +24,16 @@ import (
 type SearchResponse []Page
 
 type Page struct {
-	PageNumber   int          `json:"pageNumber"`
-	PageSize     int          `json:"pageSize"`
-	Query        string       `json:"query"`
-	Results      []PageResult `json:"results"`
-	TotalMatches int          `json:"totalMatches"`
-	Platform     string       `json:"platform,omitempty"`
+	PageNumber int    `json:"pageNumber"`
+	PageSize   int    `json:"pageSize"`
+	Query      string `json:"query"`
+	// Results are of type PageResult, ApplicationResult or ProjectResult.
+	Results      []interface{} `json:"results"`
+	TotalMatches int           `json:"totalMatches"`
+	Platform     string        `json:"platform,omitempty"`
 }
 
+// PageResult is a Kubernetes resource found by a search.
 type PageResult struct {
 	Account        string `json:"account"`
 	Group          string `json:"group"`
@@ -40,19 +45,46 @@ type PageResult struct {
 	Type           string `json:"type"`
 	Application    string `json:"application,omitempty"`
 	Cluster        string `json:"cluster,omitempty"`
+	InstanceID     string `json:"instanceId,omitempty"`
+	LoadBalancer   string `json:"loadBalancer,omitempty"`
+	ServerGroup    string `json:"serverGroup,omitempty"`
 }
 
-var unsupportedKinds = []string{
-	"applications",
-	"instances",
-	"loadBalancers",
-	"projects",
-	"securityGroups",
+// ApplicationResult is a Spinnaker application found by a search.
+type ApplicationResult struct {
+	Accounts    string `json:"accounts"`
+	Application string `json:"application"`
+	Name        string `json:"name"`
+	Provider    string `json:"provider"`
+	Type        string `json:"type"`
+	URL         string `json:"url"`
 }
 
-// Search is the generic search endpoint. It ignores the `pageSize` query parameter
-// and lists resources for a kind and namespace across all accounts the user
-// has access to concurrently.
+// ProjectResult is a Spinnaker project found by a search.
+type ProjectResult struct {
+	Applications []string `json:"applications"`
+	Email        string   `json:"email"`
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	Type         string   `json:"type"`
+	URL          string   `json:"url"`
+}
+
+const (
+	defaultSearchPage     = 1
+	defaultSearchPageSize = 10
+)
+
+// Search is the generic search endpoint.
+//
+// If the `type` query parameter is a kind, it ignores the `pageSize` query parameter
+// and lists resources for the kind in the namespace given by the `q` query parameter
+// across all accounts the user has access to concurrently.
+//
+// If `type` is not provided or is a Spinnaker type, such as "serverGroups", it
+// searches all accounts the user has access to for resources matching `q`,
+// see searchResources. The "instances", "applications" and "projects" types
+// search pods, Spinnaker applications and Spinnaker projects respectively.
 func (cc *Controller) Search(c *gin.Context) {
 	pageSize, _ := strconv.Atoi(c.Query("pageSize"))
 	namespace := c.Query("q")
@@ -61,28 +93,27 @@ func (cc *Controller) Search(c *gin.Context) {
 	// Get all accounts the user has access to.
 	accounts := strings.Split(c.GetHeader("X-Spinnaker-Accounts"), ",")
 
-	if kind == "" || namespace == "" {
+	if namespace == "" {
 		clouddriver.Error(c, http.StatusBadRequest,
-			errors.New("must provide query params 'q' to specify the namespace and 'type' to specify the kind"))
+			errors.New("must provide query param 'q' to specify the search term, or the namespace if 'type' specifies a kind"))
 		return
 	}
 
-	results := []PageResult{}
-	// Ignore requests for unsupported kinds
-	if containsIgnoreCase(unsupportedKinds, kind) {
-		sr := SearchResponse{}
-		page := Page{
-			PageNumber:   1,
-			PageSize:     pageSize,
-			Platform:     "aws",
-			Query:        namespace,
-			Results:      results,
-			TotalMatches: 0,
-		}
-		sr = append(sr, page)
-
-		c.JSON(http.StatusOK, sr)
+	switch {
+	case strings.EqualFold(kind, "instances"):
+		cc.searchInstances(c, namespace, accounts)
+		return
+	case strings.EqualFold(kind, "applications"):
+		cc.searchApplications(c, namespace, accounts)
+		return
+	case strings.EqualFold(kind, "projects"):
+		cc.searchProjects(c, namespace)
+		return
+	}
 
+	// Search resources of all kinds, or of the kinds of a Spinnaker type.
+	if kinds := kindsOfType(kind); kind == "" || len(kinds) > 0 {
+		cc.searchResources(c, namespace, kinds, accounts)
 		return
 	}
 
@@ -119,6 +150,8 @@ func (cc *Controller) Search(c *gin.Context) {
 		t = spinnakerKindMap[kind]
 	}
 
+	results := []interface{}{}
+
 	for _, an := range accountNames {
 		result := PageResult{
 			Account:        an.account,
@@ -147,6 +180,359 @@ func (cc *Controller) Search(c *gin.Context) {
 	c.JSON(http.StatusOK, sr)
 }
 
+// searchResources searches the resources recorded in the given accounts for
+// those whose name, Spinnaker application, cluster or searchable labels contain
+// the query, see searchMatcher. If any kinds are given only resources of those
+// kinds are searched.
+//
+// It returns the page of results given by the `page` and `pageSize` query parameters.
+func (cc *Controller) searchResources(c *gin.Context, query string, kinds, accounts []string) {
+	page, pageSize := searchPage(c)
+
+	resources, total, err := cc.SQLClient.SearchKubernetesResources(accounts, kinds,
+		likePattern(query), (page-1)*pageSize, pageSize)
+	if err != nil {
+		clouddriver.Error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	results := []interface{}{}
+
+	for _, kr := range resources {
+		kind := lowercaseFirst(kr.Kind)
+
+		t := "unclassified"
+		if _, ok := spinnakerKindMap[kind]; ok {
+			t = spinnakerKindMap[kind]
+		}
+
+		result := PageResult{
+			Account:        kr.AccountName,
+			Group:          kind,
+			KubernetesKind: kind,
+			Name:           fmt.Sprintf("%s %s", kind, kr.Name),
+			Namespace:      kr.Namespace,
+			Provider:       "kubernetes",
+			Region:         kr.Namespace,
+			Type:           t,
+			Application:    kr.SpinnakerApp,
+			Cluster:        kr.Cluster,
+		}
+
+		if t == "loadBalancers" {
+			result.LoadBalancer = result.Name
+		}
+
+		results = append(results, result)
+	}
+
+	c.JSON(http.StatusOK, searchResponse(query, page, pageSize, results, int(total)))
+}
+
+// searchInstances lists the pods managed by Spinnaker in the given accounts
+// concurrently and searches them for those whose name, Spinnaker application,
+// cluster or searchable labels contain the query, see searchMatcher.
+//
+// It returns the page of results given by the `page` and `pageSize` query parameters.
+func (cc *Controller) searchInstances(c *gin.Context, query string, accounts []string) {
+	page, pageSize := searchPage(c)
+
+	providers, err := cc.AllKubernetesProvidersWithTimeout(time.Second * internal.DefaultListTimeoutSeconds)
+	if err != nil {
+		clouddriver.Error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	providers = filterProviders(providers, accounts)
+
+	wg := &sync.WaitGroup{}
+	rc := make(chan resource, internal.DefaultChanSize)
+	match := searchMatcher(query)
+
+	wg.Add(len(providers))
+	// Search the pods of each account concurrently.
+	for _, provider := range providers {
+		go cc.searchPods(wg, provider, match, rc)
+	}
+
+	go func() {
+		wg.Wait()
+		close(rc)
+	}()
+
+	pods := []resource{}
+	for r := range rc {
+		pods = append(pods, r)
+	}
+
+	sort.Slice(pods, func(i, j int) bool {
+		if pods[i].account != pods[j].account {
+			return pods[i].account < pods[j].account
+		}
+
+		if pods[i].u.GetNamespace() != pods[j].u.GetNamespace() {
+			return pods[i].u.GetNamespace() < pods[j].u.GetNamespace()
+		}
+
+		return pods[i].u.GetName() < pods[j].u.GetName()
+	})
+
+	start, end := pageBounds(len(pods), page, pageSize)
+	results := []interface{}{}
+
+	for _, pod := range pods[start:end] {
+		result := PageResult{
+			Account:        pod.account,
+			Group:          "pod",
+			KubernetesKind: "pod",
+			Name:           fmt.Sprintf("pod %s", pod.u.GetName()),
+			Namespace:      pod.u.GetNamespace(),
+			Provider:       "kubernetes",
+			Region:         pod.u.GetNamespace(),
+			Type:           "instances",
+			Application:    pod.application,
+			Cluster:        pod.u.GetAnnotations()[kubernetes.AnnotationSpinnakerMonikerCluster],
+			InstanceID:     pod.u.GetName(),
+		}
+
+		if ownerReferences := pod.u.GetOwnerReferences(); len(ownerReferences) > 0 {
+			result.ServerGroup = fmt.Sprintf("%s %s", lowercaseFirst(ownerReferences[0].Kind), ownerReferences[0].Name)
+		}
+
+		results = append(results, result)
+	}
+
+	c.JSON(http.StatusOK, searchResponse(query, page, pageSize, results, len(pods)))
+}
+
+// searchPods lists the pods managed by Spinnaker in a provider's namespaces
+// and writes the matching pods to a channel of resource.
+func (cc *Controller) searchPods(wg *sync.WaitGroup, provider *kubernetes.Provider,
+	match func(string) bool, rc chan resource) {
+	// Increment the wait group counter when we're done here.
+	defer wg.Done()
+
+	items, err := cc.listManaged(provider, "pod")
+	if err != nil {
+		clouddriver.Log(err)
+		return
+	}
+
+	for _, u := range items {
+		application := kubernetes.SpinnakerMonikerApplication(u)
+
+		if match(u.GetName()) || match(application) ||
+			match(u.GetAnnotations()[kubernetes.AnnotationSpinnakerMonikerCluster]) ||
+			match(kubernetes.SearchableLabels(u)) {
+			rc <- resource{
+				account:     provider.Name,
+				application: application,
+				u:           u,
+			}
+		}
+	}
+}
+
+// searchApplications searches the Spinnaker applications with resources
+// recorded in the given accounts for those whose name contains the query,
+// see searchMatcher.
+//
+// It returns the page of results given by the `page` and `pageSize` query parameters.
+func (cc *Controller) searchApplications(c *gin.Context, query string, accounts []string) {
+	page, pageSize := searchPage(c)
+
+	rs, err := cc.SQLClient.ListKubernetesResourcesByFields("account_name", "spinnaker_app")
+	if err != nil {
+		clouddriver.Error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	allowed := map[string]bool{}
+	for _, account := range accounts {
+		allowed[account] = true
+	}
+
+	match := searchMatcher(query)
+	// Map each matching application to its accounts.
+	applications := map[string][]string{}
+
+	for _, r := range rs {
+		if r.SpinnakerApp == "" || !allowed[r.AccountName] || !match(r.SpinnakerApp) {
+			continue
+		}
+
+		applications[r.SpinnakerApp] = append(applications[r.SpinnakerApp], r.AccountName)
+	}
+
+	names := []string{}
+	for name := range applications {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	start, end := pageBounds(len(names), page, pageSize)
+	results := []interface{}{}
+
+	for _, name := range names[start:end] {
+		sort.Strings(applications[name])
+
+		result := ApplicationResult{
+			Accounts:    strings.Join(applications[name], ","),
+			Application: name,
+			Name:        name,
+			Provider:    "kubernetes",
+			Type:        "applications",
+			URL:         "/applications/" + name,
+		}
+
+		results = append(results, result)
+	}
+
+	c.JSON(http.StatusOK, searchResponse(query, page, pageSize, results, len(names)))
+}
+
+// searchProjects searches the Spinnaker projects for those whose name
+// or applications contain the query, see searchMatcher.
+//
+// It returns the page of results given by the `page` and `pageSize` query parameters.
+func (cc *Controller) searchProjects(c *gin.Context, query string) {
+	page, pageSize := searchPage(c)
+
+	projects, err := cc.Front50Client.Projects()
+	if err != nil {
+		clouddriver.Error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	match := searchMatcher(query)
+	matches := []ProjectResult{}
+
+	for _, project := range projects {
+		if !match(project.Name) && !matchAny(match, project.Config.Applications) {
+			continue
+		}
+
+		result := ProjectResult{
+			Applications: project.Config.Applications,
+			Email:        project.Email,
+			ID:           project.ID,
+			Name:         project.Name,
+			Type:         "projects",
+			URL:          "/projects/" + project.ID,
+		}
+
+		if result.Applications == nil {
+			result.Applications = []string{}
+		}
+
+		matches = append(matches, result)
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].Name < matches[j].Name
+	})
+
+	start, end := pageBounds(len(matches), page, pageSize)
+	results := []interface{}{}
+
+	for _, result := range matches[start:end] {
+		results = append(results, result)
+	}
+
+	c.JSON(http.StatusOK, searchResponse(query, page, pageSize, results, len(matches)))
+}
+
+// searchPage returns the page and page size given by
+// the `page` and `pageSize` query parameters.
+func searchPage(c *gin.Context) (int, int) {
+	page, err := strconv.Atoi(c.Query("page"))
+	if err != nil || page < 1 {
+		page = defaultSearchPage
+	}
+
+	pageSize, err := strconv.Atoi(c.Query("pageSize"))
+	if err != nil || pageSize < 1 {
+		pageSize = defaultSearchPageSize
+	}
+
+	return page, pageSize
+}
+
+// pageBounds returns the start and end indexes of a page of n results.
+func pageBounds(n, page, pageSize int) (int, int) {
+	start := (page - 1) * pageSize
+	if start > n {
+		start = n
+	}
+
+	end := start + pageSize
+	if end > n {
+		end = n
+	}
+
+	return start, end
+}
+
+// searchResponse returns a response with a page of search results.
+func searchResponse(query string, page, pageSize int, results []interface{}, total int) SearchResponse {
+	return SearchResponse{
+		{
+			PageNumber:   page,
+			PageSize:     pageSize,
+			Query:        query,
+			Results:      results,
+			TotalMatches: total,
+		},
+	}
+}
+
+// searchMatcher returns a function that returns true if a value contains
+// a search query, ignoring case, where '*' in the query matches any
+// characters and '?' matches any single character.
+func searchMatcher(query string) func(string) bool {
+	r := strings.NewReplacer(`\*`, ".*", `\?`, ".")
+	re := regexp.MustCompile("(?i)" + r.Replace(regexp.QuoteMeta(query)))
+
+	return re.MatchString
+}
+
+// matchAny returns true if any of the values match.
+func matchAny(match func(string) bool, values []string) bool {
+	for _, value := range values {
+		if match(value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// kindsOfType returns the kinds, in lowercase, whose Spinnaker type
+// is the given type, such as "serverGroups".
+func kindsOfType(t string) []string {
+	kinds := []string{}
+
+	for kind, spinnakerKind := range spinnakerKindMap {
+		if spinnakerKind == t {
+			kinds = append(kinds, strings.ToLower(kind))
+		}
+	}
+
+	sort.Strings(kinds)
+
+	return kinds
+}
+
+// likePattern returns a LIKE pattern escaped by '!' that matches values
+// containing a search query, where '*' in the query matches any characters
+// and '?' matches any single character.
+func likePattern(query string) string {
+	r := strings.NewReplacer("!", "!!", "%", "!%", "_", "!_", "*", "%", "?", "_")
+
+	return "%" + r.Replace(query) + "%"
+}
+
 // accountName defines a Kubernetes resource's account and name.
 type accountName struct {
 	account string
@@ -171,17 +557,12 @@ func (cc *Controller) search(wg *sync.WaitGroup, provider *kubernetes.Provider,
 		return
 	}
 
-	// Declare a context with timeout.
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*internal.DefaultListTimeoutSeconds)
-	defer cancel()
-
-	// List resources with the context.
-	ul, err := provider.Client.ListResourcesByKindAndNamespaceWithContext(ctx, kind, namespace, metav1.ListOptions{})
+	items, err := cc.searchList(provider, kind, namespace)
 	if err != nil {
 		return
 	}
 
-	for _, u := range ul.Items {
+	for _, u := range items {
 		an := accountName{
 			account: provider.Name,
 			name:    u.GetName(),
@@ -190,6 +571,22 @@ func (cc *Controller) search(wg *sync.WaitGroup, provider *kubernetes.Provider,
 	}
 }
 
+// searchList lists all objects of a kind in a namespace from the cluster.
+// The resource cache only holds objects managed by Spinnaker, so it is not read.
+func (cc *Controller) searchList(provider *kubernetes.Provider, kind, namespace string) ([]unstructured.Unstructured, error) {
+	// Declare a context with timeout.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*internal.DefaultListTimeoutSeconds)
+	defer cancel()
+
+	// List resources with the context.
+	ul, err := provider.Client.ListResourcesByKindAndNamespaceWithContext(ctx, kind, namespace, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return ul.Items, nil
+}
+
 // filterProviders returns a list of providers filtered by the allowe account names passed in.
 func filterProviders(providers []*kubernetes.Provider, allowedAccounts []string) []*kubernetes.Provider {
 	ps := []*kubernetes.Provider{}
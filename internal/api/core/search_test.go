@@ -11,7 +11,10 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/homedepot/go-clouddriver/internal/api/core"
+	"github.com/homedepot/go-clouddriver/internal/front50"
 	"github.com/homedepot/go-clouddriver/internal/kubernetes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 var _ = Describe("Search", func() {
@@ -38,80 +41,340 @@ var _ = Describe("Search", func() {
 			doRequest()
 		})
 
-		When("kind and namespace are not provided", func() {
+		When("the query is not provided", func() {
 			BeforeEach(func() {
-				uri = svr.URL + "/search?pageSize=500"
+				uri = svr.URL + "/search?pageSize=500&type=pod"
 			})
 
 			It("returns status bad request", func() {
 				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
 				ce := getClouddriverError()
 				Expect(ce.Error).To(HavePrefix("Bad Request"))
-				Expect(ce.Message).To(Equal("must provide query params 'q' to specify the namespace and 'type' to specify the kind"))
+				Expect(ce.Message).To(Equal("must provide query param 'q' to specify the search term, or the namespace if 'type' specifies a kind"))
 				Expect(ce.Status).To(Equal(http.StatusBadRequest))
 			})
 		})
 
-		Context("unsupported kinds", func() {
-			When("kind is applications", func() {
+		Context("when the kind is not provided", func() {
+			BeforeEach(func() {
+				uri = svr.URL + "/search?q=test*app&page=2&pageSize=1"
+				accountsHeader = "account1,account2"
+				fakeSQLClient.SearchKubernetesResourcesReturns([]kubernetes.Resource{
+					{
+						AccountName:  "account2",
+						Kind:         "Deployment",
+						Name:         "test-deployment",
+						Namespace:    "test-namespace",
+						SpinnakerApp: "test-application",
+						Cluster:      "deployment test-deployment",
+					},
+				}, 3, nil)
+			})
+
+			When("searching the resources returns an error", func() {
 				BeforeEach(func() {
-					uri = svr.URL + "/search?pageSize=500&q=default&type=applications"
+					fakeSQLClient.SearchKubernetesResourcesReturns(nil, 0, errors.New("error searching resources"))
 				})
 
-				It("returns the default response", func() {
-					Expect(res.StatusCode).To(Equal(http.StatusOK))
-					validateResponse(payloadSearchDefault)
-					Expect(fakeKubeClient.ListResourcesByKindAndNamespaceWithContextCallCount()).To(BeZero())
+				It("returns internal server error", func() {
+					Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
+					ce := getClouddriverError()
+					Expect(ce.Message).To(Equal("error searching resources"))
 				})
 			})
 
-			When("kind is instances", func() {
+			When("the page is not provided", func() {
 				BeforeEach(func() {
-					uri = svr.URL + "/search?pageSize=500&q=default&type=instances"
+					uri = svr.URL + "/search?q=test"
 				})
 
-				It("returns the default response", func() {
+				It("returns the first page", func() {
 					Expect(res.StatusCode).To(Equal(http.StatusOK))
-					validateResponse(payloadSearchDefault)
-					Expect(fakeKubeClient.ListResourcesByKindAndNamespaceWithContextCallCount()).To(BeZero())
+					_, _, _, offset, limit := fakeSQLClient.SearchKubernetesResourcesArgsForCall(0)
+					Expect(offset).To(Equal(0))
+					Expect(limit).To(Equal(10))
 				})
 			})
 
-			When("kind is loadBalancers", func() {
+			When("the type is a Spinnaker type", func() {
 				BeforeEach(func() {
-					uri = svr.URL + "/search?pageSize=500&q=default&type=loadBalancers"
+					uri = svr.URL + "/search?q=test&type=serverGroups"
 				})
 
-				It("returns the default response", func() {
+				It("searches the kinds of the type", func() {
 					Expect(res.StatusCode).To(Equal(http.StatusOK))
-					validateResponse(payloadSearchDefault)
-					Expect(fakeKubeClient.ListResourcesByKindAndNamespaceWithContextCallCount()).To(BeZero())
+					_, kinds, _, _, _ := fakeSQLClient.SearchKubernetesResourcesArgsForCall(0)
+					Expect(kinds).To(Equal([]string{"cronjob", "daemonset", "job", "replicaset", "statefulset"}))
 				})
 			})
 
-			When("kind is projects", func() {
+			It("returns the requested page of matching resources", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				Expect(fakeKubeClient.ListResourcesByKindAndNamespaceWithContextCallCount()).To(BeZero())
+				accounts, kinds, pattern, offset, limit := fakeSQLClient.SearchKubernetesResourcesArgsForCall(0)
+				Expect(accounts).To(Equal([]string{"account1", "account2"}))
+				Expect(kinds).To(BeEmpty())
+				Expect(pattern).To(Equal("%test%app%"))
+				Expect(offset).To(Equal(1))
+				Expect(limit).To(Equal(1))
+				validateResponse(`[
+					{
+						"pageNumber": 2,
+						"pageSize": 1,
+						"query": "test*app",
+						"results": [
+							{
+								"account": "account2",
+								"group": "deployment",
+								"kubernetesKind": "deployment",
+								"name": "deployment test-deployment",
+								"namespace": "test-namespace",
+								"provider": "kubernetes",
+								"region": "test-namespace",
+								"type": "serverGroupManagers",
+								"application": "test-application",
+								"cluster": "deployment test-deployment"
+							}
+						],
+						"totalMatches": 3
+					}
+				]`)
+			})
+		})
+
+		Context("when the type is securityGroups", func() {
+			BeforeEach(func() {
+				uri = svr.URL + "/search?q=test&type=securityGroups"
+			})
+
+			It("searches network policies", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				_, kinds, _, _, _ := fakeSQLClient.SearchKubernetesResourcesArgsForCall(0)
+				Expect(kinds).To(Equal([]string{"networkpolicy"}))
+			})
+		})
+
+		Context("when the type is loadBalancers", func() {
+			BeforeEach(func() {
+				uri = svr.URL + "/search?q=test&type=loadBalancers"
+				fakeSQLClient.SearchKubernetesResourcesReturns([]kubernetes.Resource{
+					{
+						AccountName:  "account1",
+						Kind:         "Service",
+						Name:         "test-service",
+						Namespace:    "test-namespace",
+						SpinnakerApp: "test-application",
+					},
+				}, 1, nil)
+			})
+
+			It("returns the matching services and ingresses", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				_, kinds, _, _, _ := fakeSQLClient.SearchKubernetesResourcesArgsForCall(0)
+				Expect(kinds).To(Equal([]string{"ingress", "service"}))
+				validateResponse(`[
+					{
+						"pageNumber": 1,
+						"pageSize": 10,
+						"query": "test",
+						"results": [
+							{
+								"account": "account1",
+								"group": "service",
+								"kubernetesKind": "service",
+								"name": "service test-service",
+								"namespace": "test-namespace",
+								"provider": "kubernetes",
+								"region": "test-namespace",
+								"type": "loadBalancers",
+								"application": "test-application",
+								"loadBalancer": "service test-service"
+							}
+						],
+						"totalMatches": 1
+					}
+				]`)
+			})
+		})
+
+		Context("when the type is instances", func() {
+			BeforeEach(func() {
+				uri = svr.URL + "/search?q=test-app*&type=instances&pageSize=1"
+				pod := func(name, application string) unstructured.Unstructured {
+					u := unstructured.Unstructured{Object: map[string]interface{}{}}
+					u.SetName(name)
+					u.SetNamespace("test-namespace")
+					u.SetAnnotations(map[string]string{
+						kubernetes.AnnotationSpinnakerMonikerApplication: application,
+						kubernetes.AnnotationSpinnakerMonikerCluster:     "deployment test-deployment",
+					})
+					u.SetOwnerReferences([]metav1.OwnerReference{{Kind: "ReplicaSet", Name: "test-rs"}})
+
+					return u
+				}
+				fakeKubeClient.ListResourcesByKindAndNamespaceWithContextReturns(&unstructured.UnstructuredList{
+					Items: []unstructured.Unstructured{
+						pod("pod-b", "test-application"),
+						pod("pod-a", "test-application"),
+						pod("pod-c", "other-application"),
+					},
+				}, nil)
+			})
+
+			When("listing pods returns an error", func() {
 				BeforeEach(func() {
-					uri = svr.URL + "/search?pageSize=500&q=default&type=projects"
+					fakeKubeClient.ListResourcesByKindAndNamespaceWithContextReturns(nil, errors.New("error listing pods"))
 				})
 
-				It("returns the default response", func() {
+				It("returns an empty page", func() {
 					Expect(res.StatusCode).To(Equal(http.StatusOK))
-					validateResponse(payloadSearchDefault)
-					Expect(fakeKubeClient.ListResourcesByKindAndNamespaceWithContextCallCount()).To(BeZero())
+					validateResponse(`[{"pageNumber":1,"pageSize":1,"query":"test-app*","results":[],"totalMatches":0}]`)
 				})
 			})
 
-			When("kind is securityGroups", func() {
+			It("returns the requested page of matching pods", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				_, kind, namespace, lo := fakeKubeClient.ListResourcesByKindAndNamespaceWithContextArgsForCall(0)
+				Expect(kind).To(Equal("pod"))
+				Expect(namespace).To(BeEmpty())
+				Expect(lo.LabelSelector).To(Equal(kubernetes.DefaultLabelSelector()))
+				validateResponse(`[
+					{
+						"pageNumber": 1,
+						"pageSize": 1,
+						"query": "test-app*",
+						"results": [
+							{
+								"account": "account1",
+								"group": "pod",
+								"kubernetesKind": "pod",
+								"name": "pod pod-a",
+								"namespace": "test-namespace",
+								"provider": "kubernetes",
+								"region": "test-namespace",
+								"type": "instances",
+								"application": "test-application",
+								"cluster": "deployment test-deployment",
+								"instanceId": "pod-a",
+								"serverGroup": "replicaSet test-rs"
+							}
+						],
+						"totalMatches": 2
+					}
+				]`)
+			})
+		})
+
+		Context("when the type is applications", func() {
+			BeforeEach(func() {
+				uri = svr.URL + "/search?q=TEST&type=applications"
+				accountsHeader = "account1,account2"
+				fakeSQLClient.ListKubernetesResourcesByFieldsReturns([]kubernetes.Resource{
+					{AccountName: "account2", SpinnakerApp: "test-application"},
+					{AccountName: "account1", SpinnakerApp: "test-application"},
+					{AccountName: "account1", SpinnakerApp: "other-application"},
+					{AccountName: "account3", SpinnakerApp: "test-forbidden"},
+				}, nil)
+			})
+
+			When("listing the applications returns an error", func() {
 				BeforeEach(func() {
-					uri = svr.URL + "/search?pageSize=500&q=default&type=securityGroups"
+					fakeSQLClient.ListKubernetesResourcesByFieldsReturns(nil, errors.New("error listing applications"))
 				})
 
-				It("returns the default response", func() {
-					Expect(res.StatusCode).To(Equal(http.StatusOK))
-					validateResponse(payloadSearchDefault)
-					Expect(fakeKubeClient.ListResourcesByKindAndNamespaceWithContextCallCount()).To(BeZero())
+				It("returns internal server error", func() {
+					Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
+					ce := getClouddriverError()
+					Expect(ce.Message).To(Equal("error listing applications"))
+				})
+			})
+
+			It("returns the matching applications in the user's accounts", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				Expect(fakeSQLClient.ListKubernetesResourcesByFieldsArgsForCall(0)).To(Equal([]string{"account_name", "spinnaker_app"}))
+				validateResponse(`[
+					{
+						"pageNumber": 1,
+						"pageSize": 10,
+						"query": "TEST",
+						"results": [
+							{
+								"accounts": "account1,account2",
+								"application": "test-application",
+								"name": "test-application",
+								"provider": "kubernetes",
+								"type": "applications",
+								"url": "/applications/test-application"
+							}
+						],
+						"totalMatches": 1
+					}
+				]`)
+			})
+		})
+
+		Context("when the type is projects", func() {
+			BeforeEach(func() {
+				uri = svr.URL + "/search?q=test&type=projects"
+				fakeFront50Client.ProjectsReturns([]front50.Response{
+					{
+						ID:   "test-id",
+						Name: "test-project",
+					},
+					{
+						ID:     "other-id",
+						Name:   "other-project",
+						Email:  "test@example.com",
+						Config: front50.Config{Applications: []string{"test-application"}},
+					},
+					{
+						ID:   "unmatched-id",
+						Name: "unmatched-project",
+					},
+				}, nil)
+			})
+
+			When("listing the projects returns an error", func() {
+				BeforeEach(func() {
+					fakeFront50Client.ProjectsReturns(nil, errors.New("error listing projects"))
+				})
+
+				It("returns internal server error", func() {
+					Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
+					ce := getClouddriverError()
+					Expect(ce.Message).To(Equal("error listing projects"))
 				})
 			})
+
+			It("returns the projects matching by name or application", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				validateResponse(`[
+					{
+						"pageNumber": 1,
+						"pageSize": 10,
+						"query": "test",
+						"results": [
+							{
+								"applications": ["test-application"],
+								"email": "test@example.com",
+								"id": "other-id",
+								"name": "other-project",
+								"type": "projects",
+								"url": "/projects/other-id"
+							},
+							{
+								"applications": [],
+								"email": "",
+								"id": "test-id",
+								"name": "test-project",
+								"type": "projects",
+								"url": "/projects/test-id"
+							}
+						],
+						"totalMatches": 2
+					}
+				]`)
+			})
 		})
 
 		When("grabbing all providers returns an error", func() {
@@ -181,6 +444,20 @@ var _ = Describe("Search", func() {
 			})
 		})
 
+		When("the resource cache is enabled", func() {
+			BeforeEach(func() {
+				internalController.KubernetesResourceCache = fakeResourceCache
+			})
+
+			It("lists the kind from the cluster", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				validateResponse(payloadSearch)
+				Expect(fakeKubeClient.ListResourcesByKindAndNamespaceWithContextCallCount()).To(Equal(1))
+				Expect(fakeResourceCache.ListCallCount()).To(BeZero())
+				Expect(fakeResourceCache.WatchCallCount()).To(BeZero())
+			})
+		})
+
 		When("listing providers returns accounts the user does not have access to", func() {
 			BeforeEach(func() {
 				providers := []kubernetes.Provider{
@@ -1,54 +1,383 @@
 package core
 
 import (
+	"fmt"
 	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/homedepot/go-clouddriver/internal"
+	"github.com/homedepot/go-clouddriver/internal/kubernetes"
+	clouddriver "github.com/homedepot/go-clouddriver/pkg"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
-// I'm not sure what this endpoint is supposed to do or what Spinnaker does with it,
-// but here is an example of the response. I found that returning an empty object "{}"
-// satisfies Deck.
+const kindNetworkPolicy = "networkPolicy"
+
+// SecurityGroups maps account names to cloud providers to regions (namespaces)
+// to the security groups (NetworkPolicies) in each region.
+type SecurityGroups map[string]map[string]map[string][]SecurityGroupSummary
+
+// SecurityGroupSummary identifies a security group.
+type SecurityGroupSummary struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// SecurityGroup is a NetworkPolicy, which Deck displays as a firewall.
+type SecurityGroup struct {
+	Account       string                 `json:"account"`
+	Application   string                 `json:"application"`
+	CloudProvider string                 `json:"cloudProvider"`
+	DisplayName   string                 `json:"displayName"`
+	ID            string                 `json:"id"`
+	InboundRules  []SecurityGroupRule    `json:"inboundRules"`
+	Kind          string                 `json:"kind"`
+	Labels        map[string]string      `json:"labels,omitempty"`
+	Manifest      map[string]interface{} `json:"manifest"`
+	Moniker       Moniker                `json:"moniker"`
+	Name          string                 `json:"name"`
+	Namespace     string                 `json:"namespace"`
+	OutboundRules []SecurityGroupRule    `json:"outboundRules"`
+	Region        string                 `json:"region"`
+	Type          string                 `json:"type"`
+}
+
+// SecurityGroupRule is an ingress or egress rule of a NetworkPolicy for a peer.
+// The range is only set for peers that are IP blocks.
+type SecurityGroupRule struct {
+	PortRanges []SecurityGroupPortRange `json:"portRanges"`
+	Protocol   string                   `json:"protocol"`
+	Range      *SecurityGroupRange      `json:"range,omitempty"`
+}
+
+type SecurityGroupPortRange struct {
+	StartPort int32 `json:"startPort"`
+	EndPort   int32 `json:"endPort"`
+}
+
+// SecurityGroupRange is a CIDR split into its IP and prefix, such as "10.0.0.0" and "/16".
+type SecurityGroupRange struct {
+	IP   string `json:"ip"`
+	CIDR string `json:"cidr"`
+}
+
+// ListSecurityGroups lists the security groups (NetworkPolicies) managed by Spinnaker
+// across all accounts the user has access to concurrently.
 //
-//	{
-//	  "label": "securityGroups",
-//	  "name": "applications",
-//	  "profiles": [
-//	    "smoketests"
-//	  ],
-//	  "propertySources": [
-//	    {
-//	      "name": "vault:spinnaker",
-//	      "source": {
-//	        "kubernetes.accounts[0].cacheIntervalSeconds": 3600,
-//	        "kubernetes.accounts[0].cacheThreads": 4,
-//	        "kubernetes.accounts[0].cachingPolicies": "",
-//	        "kubernetes.accounts[0].checkPermissionsOnStartup": false,
-//	        "kubernetes.accounts[0].configureImagePullSecrets": true,
-//	        "kubernetes.accounts[0].context": "...",
-//	        "kubernetes.accounts[0].dockerRegistries[0].accountName": "docker-registry",
-//	        "kubernetes.accounts[0].dockerRegistries[0].namespaces": "",
-//	        "kubernetes.accounts[0].kubeconfigContents": "...",
-//	        "kubernetes.accounts[0].liveManifestCalls": true,
-//	        "kubernetes.accounts[0].metrics": false,
-//	        "kubernetes.accounts[0].name": "...",
-//	        "kubernetes.accounts[0].namespaces": "",
-//	        "kubernetes.accounts[0].oAuthScopes": "",
-//	        "kubernetes.accounts[0].omitKinds[0]": "podPreset",
-//	        "kubernetes.accounts[0].omitNamespaces[0]": "kube-public",
-//	        "kubernetes.accounts[0].omitNamespaces[1]": "kube-node-lease",
-//	        "kubernetes.accounts[0].onlySpinnakerManaged": true,
-//	        "kubernetes.accounts[0].permissions.READ[0]": "...",
-//	        "kubernetes.accounts[0].permissions.READ[1]": "...",
-//	        "kubernetes.accounts[0].permissions.WRITE[0]": "...",
-//	        "kubernetes.accounts[0].providerVersion": "V2",
-//	        "kubernetes.accounts[0].requiredGroupMembership": "",
-//	      }
-//	    }
-//	  ]
-//	}
-func ListSecurityGroups(c *gin.Context) {
-	var empty struct{}
-
-	c.JSON(http.StatusOK, empty)
+// See https://github.com/spinnaker/clouddriver/blob/master/clouddriver-web/src/main/groovy/com/netflix/spinnaker/clouddriver/controllers/SecurityGroupController.groovy
+func (cc *Controller) ListSecurityGroups(c *gin.Context) {
+	// Get all accounts the user has access to.
+	accounts := strings.Split(c.GetHeader("X-Spinnaker-Accounts"), ",")
+
+	providers, err := cc.AllKubernetesProvidersWithTimeout(time.Second * internal.DefaultListTimeoutSeconds)
+	if err != nil {
+		clouddriver.Error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	providers = filterProviders(providers, accounts)
+
+	wg := &sync.WaitGroup{}
+	rc := make(chan resource, internal.DefaultChanSize)
+
+	wg.Add(len(providers))
+	// List the NetworkPolicies of each account concurrently.
+	for _, provider := range providers {
+		go cc.listNetworkPolicies(wg, provider, rc)
+	}
+
+	go func() {
+		wg.Wait()
+		close(rc)
+	}()
+
+	response := SecurityGroups{}
+
+	for r := range rc {
+		if _, ok := response[r.account]; !ok {
+			response[r.account] = map[string]map[string][]SecurityGroupSummary{
+				typeKubernetes: {},
+			}
+		}
+
+		addSecurityGroupSummary(response[r.account][typeKubernetes], r.u)
+	}
+
+	for _, regions := range response {
+		sortSecurityGroupSummaries(regions[typeKubernetes])
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ListSecurityGroupsByAccount lists the security groups (NetworkPolicies)
+// managed by Spinnaker in an account.
+func (cc *Controller) ListSecurityGroupsByAccount(c *gin.Context) {
+	account := c.Param("account")
+
+	provider, err := cc.KubernetesProviderWithTimeout(account, time.Second*internal.DefaultListTimeoutSeconds)
+	if err != nil {
+		clouddriver.Error(c, http.StatusBadRequest, err)
+		return
+	}
+
+	items, err := cc.listManaged(provider, kindNetworkPolicy)
+	if err != nil {
+		clouddriver.Error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	regions := map[string][]SecurityGroupSummary{}
+
+	for _, u := range items {
+		addSecurityGroupSummary(regions, u)
+	}
+
+	sortSecurityGroupSummaries(regions)
+
+	c.JSON(http.StatusOK, map[string]map[string][]SecurityGroupSummary{
+		typeKubernetes: regions,
+	})
+}
+
+// GetSecurityGroup gets a security group (NetworkPolicy) by its account, region (namespace)
+// and name, which may be in the format 'networkPolicy name' or just the name.
+func (cc *Controller) GetSecurityGroup(c *gin.Context) {
+	account := c.Param("account")
+	region := c.Param("region")
+	name := c.Param("name")
+
+	if a := strings.Split(name, " "); len(a) == 2 {
+		if !strings.EqualFold(a[0], kindNetworkPolicy) {
+			clouddriver.Error(c, http.StatusBadRequest,
+				fmt.Errorf("security group kind must be %s, got: %s", kindNetworkPolicy, a[0]))
+			return
+		}
+
+		name = a[1]
+	}
+
+	provider, err := cc.KubernetesProviderWithTimeout(account, time.Second*internal.DefaultListTimeoutSeconds)
+	if err != nil {
+		clouddriver.Error(c, http.StatusBadRequest, err)
+		return
+	}
+
+	u, err := provider.Client.Get(kindNetworkPolicy, name, region)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			clouddriver.Error(c, http.StatusNotFound, err)
+			return
+		}
+
+		clouddriver.Error(c, http.StatusInternalServerError, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, newSecurityGroup(*u, account))
+}
+
+// ListApplicationFirewalls lists the security groups (NetworkPolicies) of an application
+// in the accounts the user has access to.
+func (cc *Controller) ListApplicationFirewalls(c *gin.Context) {
+	response := []SecurityGroup{}
+	application := c.Param("application")
+	// List all accounts associated with the given Spinnaker app.
+	accounts, err := cc.SQLClient.ListKubernetesAccountsBySpinnakerApp(application)
+	if err != nil {
+		clouddriver.Error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	allowed := map[string]bool{}
+	for _, account := range strings.Split(c.GetHeader("X-Spinnaker-Accounts"), ",") {
+		allowed[account] = true
+	}
+
+	readable := []string{}
+
+	for _, account := range accounts {
+		if allowed[account] {
+			readable = append(readable, account)
+		}
+	}
+
+	rs, err := cc.listApplicationResources(c, []string{"networkPolicies"}, readable, []string{application})
+	if err != nil {
+		clouddriver.Error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	for _, r := range rs {
+		response = append(response, newSecurityGroup(r.u, r.account))
+	}
+
+	// Sort by account (cluster), then region (namespace), then name.
+	sort.Slice(response, func(i, j int) bool {
+		if response[i].Account != response[j].Account {
+			return response[i].Account < response[j].Account
+		}
+
+		if response[i].Region != response[j].Region {
+			return response[i].Region < response[j].Region
+		}
+
+		return response[i].Name < response[j].Name
+	})
+
+	c.JSON(http.StatusOK, response)
+}
+
+// listNetworkPolicies lists the NetworkPolicies managed by Spinnaker in a
+// provider's namespaces and writes them to a channel of resource.
+func (cc *Controller) listNetworkPolicies(wg *sync.WaitGroup, provider *kubernetes.Provider, rc chan resource) {
+	// Increment the wait group counter when we're done here.
+	defer wg.Done()
+
+	items, err := cc.listManaged(provider, kindNetworkPolicy)
+	if err != nil {
+		clouddriver.Log(err)
+		return
+	}
+
+	for _, u := range items {
+		rc <- resource{
+			account:     provider.Name,
+			application: kubernetes.SpinnakerMonikerApplication(u),
+			u:           u,
+		}
+	}
+}
+
+// addSecurityGroupSummary adds the summary of a NetworkPolicy to its region (namespace).
+func addSecurityGroupSummary(regions map[string][]SecurityGroupSummary, u unstructured.Unstructured) {
+	name := fmt.Sprintf("%s %s", kindNetworkPolicy, u.GetName())
+	regions[u.GetNamespace()] = append(regions[u.GetNamespace()], SecurityGroupSummary{
+		ID:   name,
+		Name: name,
+	})
+}
+
+func sortSecurityGroupSummaries(regions map[string][]SecurityGroupSummary) {
+	for _, summaries := range regions {
+		sort.Slice(summaries, func(i, j int) bool {
+			return summaries[i].Name < summaries[j].Name
+		})
+	}
+}
+
+// newSecurityGroup returns the security group of a NetworkPolicy.
+func newSecurityGroup(u unstructured.Unstructured, account string) SecurityGroup {
+	name := fmt.Sprintf("%s %s", kindNetworkPolicy, u.GetName())
+	application := kubernetes.SpinnakerMonikerApplication(u)
+	np := kubernetes.NewNetworkPolicy(u.Object).Object()
+
+	inboundRules := []SecurityGroupRule{}
+	for _, rule := range np.Spec.Ingress {
+		inboundRules = append(inboundRules, newSecurityGroupRules(rule.From, rule.Ports)...)
+	}
+
+	outboundRules := []SecurityGroupRule{}
+	for _, rule := range np.Spec.Egress {
+		outboundRules = append(outboundRules, newSecurityGroupRules(rule.To, rule.Ports)...)
+	}
+
+	return SecurityGroup{
+		Account:       account,
+		Application:   application,
+		CloudProvider: typeKubernetes,
+		DisplayName:   u.GetName(),
+		ID:            name,
+		InboundRules:  inboundRules,
+		Kind:          kindNetworkPolicy,
+		Labels:        u.GetLabels(),
+		Manifest:      u.Object,
+		Moniker: Moniker{
+			App:     application,
+			Cluster: name,
+		},
+		Name:          name,
+		Namespace:     u.GetNamespace(),
+		OutboundRules: outboundRules,
+		Region:        u.GetNamespace(),
+		Type:          typeKubernetes,
+	}
+}
+
+// newSecurityGroupRules returns a rule for each peer of a NetworkPolicy rule and
+// each protocol of its ports. A rule without peers applies to all peers and a rule
+// without ports applies to all ports.
+func newSecurityGroupRules(peers []networkingv1.NetworkPolicyPeer,
+	ports []networkingv1.NetworkPolicyPort) []SecurityGroupRule {
+	// Group the port ranges by protocol, keeping the order the protocols appear in.
+	protocols := []string{}
+	portRanges := map[string][]SecurityGroupPortRange{}
+
+	for _, port := range ports {
+		protocol := "TCP"
+		if port.Protocol != nil {
+			protocol = string(*port.Protocol)
+		}
+
+		if _, ok := portRanges[protocol]; !ok {
+			protocols = append(protocols, protocol)
+			portRanges[protocol] = []SecurityGroupPortRange{}
+		}
+
+		// Named ports cannot be resolved without the pods they select.
+		if port.Port == nil || port.Port.IntVal == 0 {
+			continue
+		}
+
+		pr := SecurityGroupPortRange{
+			StartPort: port.Port.IntVal,
+			EndPort:   port.Port.IntVal,
+		}
+
+		if port.EndPort != nil {
+			pr.EndPort = *port.EndPort
+		}
+
+		portRanges[protocol] = append(portRanges[protocol], pr)
+	}
+
+	if len(protocols) == 0 {
+		protocols = []string{"TCP"}
+		portRanges["TCP"] = []SecurityGroupPortRange{}
+	}
+
+	if len(peers) == 0 {
+		peers = []networkingv1.NetworkPolicyPeer{{}}
+	}
+
+	rules := []SecurityGroupRule{}
+
+	for _, peer := range peers {
+		var r *SecurityGroupRange
+
+		if peer.IPBlock != nil {
+			ip, prefix, _ := strings.Cut(peer.IPBlock.CIDR, "/")
+			r = &SecurityGroupRange{
+				IP:   ip,
+				CIDR: "/" + prefix,
+			}
+		}
+
+		for _, protocol := range protocols {
+			rules = append(rules, SecurityGroupRule{
+				PortRanges: portRanges[protocol],
+				Protocol:   protocol,
+				Range:      r,
+			})
+		}
+	}
+
+	return rules
 }
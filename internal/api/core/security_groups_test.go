@@ -0,0 +1,383 @@
+package core_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/homedepot/go-clouddriver/internal/api/core"
+	"github.com/homedepot/go-clouddriver/internal/kubernetes"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var _ = Describe("SecurityGroups", func() {
+	networkPolicy := func(name, namespace string) unstructured.Unstructured {
+		return unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"kind":       "NetworkPolicy",
+				"apiVersion": "networking.k8s.io/v1",
+				"metadata": map[string]interface{}{
+					"name":      name,
+					"namespace": namespace,
+					"annotations": map[string]interface{}{
+						kubernetes.AnnotationSpinnakerMonikerApplication: "test-application",
+					},
+				},
+				"spec": map[string]interface{}{
+					"ingress": []interface{}{
+						map[string]interface{}{
+							"from": []interface{}{
+								map[string]interface{}{
+									"ipBlock": map[string]interface{}{
+										"cidr": "10.0.0.0/16",
+									},
+								},
+								map[string]interface{}{
+									"podSelector": map[string]interface{}{},
+								},
+							},
+							"ports": []interface{}{
+								map[string]interface{}{
+									"port": 8080,
+								},
+								map[string]interface{}{
+									"port":     53,
+									"endPort":  54,
+									"protocol": "UDP",
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		setup()
+	})
+
+	AfterEach(func() {
+		teardown()
+	})
+
+	Describe("#ListSecurityGroups", func() {
+		BeforeEach(func() {
+			uri = svr.URL + "/securityGroups"
+			createRequest(http.MethodGet)
+			req.Header.Add("X-Spinnaker-Accounts", "account1")
+			fakeSQLClient.ListKubernetesProvidersReturns([]kubernetes.Provider{
+				{Name: "account1"},
+				{Name: "account2"},
+			}, nil)
+			fakeKubeClient.ListResourcesByKindAndNamespaceWithContextReturns(&unstructured.UnstructuredList{
+				Items: []unstructured.Unstructured{
+					networkPolicy("test-policy2", "test-namespace"),
+					networkPolicy("test-policy1", "test-namespace"),
+				},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			doRequest()
+		})
+
+		When("listing providers returns an error", func() {
+			BeforeEach(func() {
+				fakeSQLClient.ListKubernetesProvidersReturns(nil, errors.New("error listing providers"))
+			})
+
+			It("returns status internal server error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+
+		When("listing network policies returns an error", func() {
+			BeforeEach(func() {
+				fakeKubeClient.ListResourcesByKindAndNamespaceWithContextReturns(nil, errors.New("error listing"))
+			})
+
+			It("omits the account", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				validateResponse(`{}`)
+			})
+		})
+
+		It("lists the security groups of the user's accounts", func() {
+			Expect(res.StatusCode).To(Equal(http.StatusOK))
+			Expect(fakeKubeClient.ListResourcesByKindAndNamespaceWithContextCallCount()).To(Equal(1))
+			_, kind, namespace, lo := fakeKubeClient.ListResourcesByKindAndNamespaceWithContextArgsForCall(0)
+			Expect(kind).To(Equal("networkPolicy"))
+			Expect(namespace).To(BeEmpty())
+			Expect(lo.LabelSelector).To(Equal(kubernetes.DefaultLabelSelector()))
+			validateResponse(`{
+				"account1": {
+					"kubernetes": {
+						"test-namespace": [
+							{
+								"id": "networkPolicy test-policy1",
+								"name": "networkPolicy test-policy1"
+							},
+							{
+								"id": "networkPolicy test-policy2",
+								"name": "networkPolicy test-policy2"
+							}
+						]
+					}
+				}
+			}`)
+		})
+	})
+
+	Describe("#ListSecurityGroupsByAccount", func() {
+		BeforeEach(func() {
+			uri = svr.URL + "/securityGroups/test-account"
+			createRequest(http.MethodGet)
+			fakeSQLClient.GetKubernetesProviderReturns(kubernetes.Provider{
+				Name:       "test-account",
+				Namespaces: []string{"namespace1", "namespace2"},
+			}, nil)
+			fakeKubeClient.ListResourcesByKindAndNamespaceWithContextReturnsOnCall(0, &unstructured.UnstructuredList{
+				Items: []unstructured.Unstructured{networkPolicy("test-policy1", "namespace1")},
+			}, nil)
+			fakeKubeClient.ListResourcesByKindAndNamespaceWithContextReturnsOnCall(1, &unstructured.UnstructuredList{
+				Items: []unstructured.Unstructured{networkPolicy("test-policy2", "namespace2")},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			doRequest()
+		})
+
+		When("getting the provider returns an error", func() {
+			BeforeEach(func() {
+				fakeSQLClient.GetKubernetesProviderReturns(kubernetes.Provider{}, errors.New("error getting provider"))
+			})
+
+			It("returns status bad request", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("listing network policies returns an error", func() {
+			BeforeEach(func() {
+				fakeKubeClient.ListResourcesByKindAndNamespaceWithContextReturnsOnCall(1, nil, errors.New("error listing"))
+			})
+
+			It("returns status internal server error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
+				ce := getClouddriverError()
+				Expect(ce.Message).To(Equal("error listing"))
+			})
+		})
+
+		It("lists the security groups in each namespace of the account", func() {
+			Expect(res.StatusCode).To(Equal(http.StatusOK))
+			validateResponse(`{
+				"kubernetes": {
+					"namespace1": [
+						{
+							"id": "networkPolicy test-policy1",
+							"name": "networkPolicy test-policy1"
+						}
+					],
+					"namespace2": [
+						{
+							"id": "networkPolicy test-policy2",
+							"name": "networkPolicy test-policy2"
+						}
+					]
+				}
+			}`)
+		})
+	})
+
+	Describe("#GetSecurityGroup", func() {
+		BeforeEach(func() {
+			uri = svr.URL + "/securityGroups/test-account/kubernetes/test-namespace/networkPolicy%20test-policy"
+			createRequest(http.MethodGet)
+			np := networkPolicy("test-policy", "test-namespace")
+			fakeKubeClient.GetReturns(&np, nil)
+		})
+
+		JustBeforeEach(func() {
+			doRequest()
+		})
+
+		When("the kind is not networkPolicy", func() {
+			BeforeEach(func() {
+				uri = svr.URL + "/securityGroups/test-account/kubernetes/test-namespace/service%20test-policy"
+				createRequest(http.MethodGet)
+			})
+
+			It("returns status bad request", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+				ce := getClouddriverError()
+				Expect(ce.Message).To(Equal("security group kind must be networkPolicy, got: service"))
+			})
+		})
+
+		When("the network policy does not exist", func() {
+			BeforeEach(func() {
+				fakeKubeClient.GetReturns(nil, k8serrors.NewNotFound(schema.GroupResource{}, "test-policy"))
+			})
+
+			It("returns status not found", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		When("getting the network policy returns an error", func() {
+			BeforeEach(func() {
+				fakeKubeClient.GetReturns(nil, errors.New("error getting"))
+			})
+
+			It("returns status internal server error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+
+		When("the name has no kind", func() {
+			BeforeEach(func() {
+				uri = svr.URL + "/securityGroups/test-account/kubernetes/test-namespace/test-policy"
+				createRequest(http.MethodGet)
+			})
+
+			It("gets the network policy", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				kind, name, namespace := fakeKubeClient.GetArgsForCall(0)
+				Expect(kind).To(Equal("networkPolicy"))
+				Expect(name).To(Equal("test-policy"))
+				Expect(namespace).To(Equal("test-namespace"))
+			})
+		})
+
+		It("describes the network policy", func() {
+			Expect(res.StatusCode).To(Equal(http.StatusOK))
+			kind, name, namespace := fakeKubeClient.GetArgsForCall(0)
+			Expect(kind).To(Equal("networkPolicy"))
+			Expect(name).To(Equal("test-policy"))
+			Expect(namespace).To(Equal("test-namespace"))
+			validateResponse(`{
+				"account": "test-account",
+				"application": "test-application",
+				"cloudProvider": "kubernetes",
+				"displayName": "test-policy",
+				"id": "networkPolicy test-policy",
+				"inboundRules": [
+					{
+						"portRanges": [{"startPort": 8080, "endPort": 8080}],
+						"protocol": "TCP",
+						"range": {"ip": "10.0.0.0", "cidr": "/16"}
+					},
+					{
+						"portRanges": [{"startPort": 53, "endPort": 54}],
+						"protocol": "UDP",
+						"range": {"ip": "10.0.0.0", "cidr": "/16"}
+					},
+					{
+						"portRanges": [{"startPort": 8080, "endPort": 8080}],
+						"protocol": "TCP"
+					},
+					{
+						"portRanges": [{"startPort": 53, "endPort": 54}],
+						"protocol": "UDP"
+					}
+				],
+				"kind": "networkPolicy",
+				"manifest": {
+					"apiVersion": "networking.k8s.io/v1",
+					"kind": "NetworkPolicy",
+					"metadata": {
+						"annotations": {
+							"moniker.spinnaker.io/application": "test-application"
+						},
+						"name": "test-policy",
+						"namespace": "test-namespace"
+					},
+					"spec": {
+						"ingress": [
+							{
+								"from": [
+									{"ipBlock": {"cidr": "10.0.0.0/16"}},
+									{"podSelector": {}}
+								],
+								"ports": [
+									{"port": 8080},
+									{"endPort": 54, "port": 53, "protocol": "UDP"}
+								]
+							}
+						]
+					}
+				},
+				"moniker": {
+					"app": "test-application",
+					"cluster": "networkPolicy test-policy"
+				},
+				"name": "networkPolicy test-policy",
+				"namespace": "test-namespace",
+				"outboundRules": [],
+				"region": "test-namespace",
+				"type": "kubernetes"
+			}`)
+		})
+	})
+
+	Describe("#ListApplicationFirewalls", func() {
+		BeforeEach(func() {
+			uri = svr.URL + "/applications/test-application/firewalls"
+			createRequest(http.MethodGet)
+			req.Header.Add("X-Spinnaker-Accounts", "account1")
+			fakeSQLClient.ListKubernetesAccountsBySpinnakerAppReturns([]string{"account1", "account2"}, nil)
+			fakeSQLClient.ListKubernetesProvidersReturns([]kubernetes.Provider{
+				{Name: "account1"},
+				{Name: "account2"},
+			}, nil)
+			np := networkPolicy("test-policy", "test-namespace")
+			other := networkPolicy("other-policy", "test-namespace")
+			other.SetAnnotations(map[string]string{
+				kubernetes.AnnotationSpinnakerMonikerApplication: "other-application",
+			})
+			fakeKubeClient.ListResourceWithContextReturns(&unstructured.UnstructuredList{
+				Items: []unstructured.Unstructured{np, other},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			doRequest()
+		})
+
+		When("listing the accounts returns an error", func() {
+			BeforeEach(func() {
+				fakeSQLClient.ListKubernetesAccountsBySpinnakerAppReturns(nil, errors.New("error listing accounts"))
+			})
+
+			It("returns status internal server error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
+				ce := getClouddriverError()
+				Expect(ce.Message).To(Equal("error listing accounts"))
+			})
+		})
+
+		It("lists the application's security groups in the user's accounts", func() {
+			Expect(res.StatusCode).To(Equal(http.StatusOK))
+			Expect(fakeKubeClient.ListResourceWithContextCallCount()).To(Equal(1))
+			_, resource, _ := fakeKubeClient.ListResourceWithContextArgsForCall(0)
+			Expect(resource).To(Equal("networkPolicies"))
+			b, _ := io.ReadAll(res.Body)
+			sgs := []core.SecurityGroup{}
+			err := json.Unmarshal(b, &sgs)
+			Expect(err).To(BeNil())
+			Expect(sgs).To(HaveLen(1))
+			Expect(sgs[0].Account).To(Equal("account1"))
+			Expect(sgs[0].Name).To(Equal("networkPolicy test-policy"))
+			Expect(sgs[0].InboundRules).To(HaveLen(4))
+		})
+	})
+})
@@ -1,11 +1,14 @@
 package core
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 
 	clouddriver "github.com/homedepot/go-clouddriver/pkg"
+	"gorm.io/gorm"
 
 	"github.com/gin-gonic/gin"
 	"github.com/homedepot/go-clouddriver/internal/artifact"
@@ -14,42 +17,115 @@ import (
 )
 
 // GetTask gets a task - currently only associated with kubernetes 'tasks'.
+//
+// Tasks are queued and executed in the background, so the task's state
+// is checked first, and the history recorded while executing the task
+// is returned along with it. Pending, running, and failed tasks are returned as is,
+// while succeeded tasks have their result built from the resources
+// recorded for the task.
+//
+// Tasks created before tasks were queued have no state and are built
+// from their resources only.
 func (cc *Controller) GetTask(c *gin.Context) {
 	id := c.Param("id")
 	task := clouddriver.NewDefaultTask(id)
 	manifests := []map[string]interface{}{}
 
-	resources, err := cc.SQLClient.ListKubernetesResourcesByTaskID(id)
-	if err != nil {
+	record, err := cc.SQLClient.GetTask(id)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		task.Status.Failed = true
 		task.Status.Retryable = true
-		task.Status.Status = fmt.Sprintf("Error listing resources for task (id: %s): %v", id, err)
+		task.Status.Status = fmt.Sprintf("Error getting task (id: %s): %v", id, err)
 		c.JSON(http.StatusInternalServerError, task)
 
 		return
 	}
 
-	if len(resources) == 0 {
+	history, err := cc.SQLClient.ListTaskHistoryByTaskID(id)
+	if err != nil {
 		task.Status.Failed = true
-		task.Status.Status = fmt.Sprintf("Task not found (id: %s)", id)
-		c.JSON(http.StatusNotFound, task)
+		task.Status.Retryable = true
+		task.Status.Status = fmt.Sprintf("Error listing history for task (id: %s): %v", id, err)
+		c.JSON(http.StatusInternalServerError, task)
 
 		return
 	}
 
-	accountName := resources[0].AccountName
+	task.History = append(task.History, history...)
+
+	// Some operations, such as a dry run deploy, save their results
+	// with the task instead of recording resources. Failed tasks may also
+	// save results describing the failure, such as server-side apply conflicts.
+	results := []clouddriver.TaskResultObject{}
+	if record.Result != "" {
+		err = json.Unmarshal([]byte(record.Result), &results)
+		if err != nil {
+			task.Status.Failed = true
+			task.Status.Retryable = true
+			task.Status.Status = fmt.Sprintf("Error decoding result for task (id: %s): %v", id, err)
+			c.JSON(http.StatusInternalServerError, task)
+
+			return
+		}
+	}
+
+	if record.State != "" {
+		task.Status.Phase = record.State
+		task.StartTimeMs = record.CreatedAt.UnixMilli()
+	}
+
+	switch record.State {
+	case clouddriver.TaskStatePending:
+		task.Status.Complete = false
+		task.Status.Completed = false
+		task.Status.Status = "Orchestration pending."
+		c.JSON(http.StatusOK, task)
 
-	provider, err := cc.KubernetesProvider(accountName)
+		return
+	case clouddriver.TaskStateRunning:
+		task.Status.Complete = false
+		task.Status.Completed = false
+		task.Status.Status = "Orchestration in progress."
+		c.JSON(http.StatusOK, task)
+
+		return
+	case clouddriver.TaskStateFailed:
+		task.Status.Failed = true
+		task.Status.Status = record.Message
+		task.ResultObjects = append(task.ResultObjects, results...)
+		c.JSON(http.StatusOK, task)
+
+		return
+	}
+
+	resources, err := cc.SQLClient.ListKubernetesResourcesByTaskID(id)
 	if err != nil {
 		task.Status.Failed = true
 		task.Status.Retryable = true
-		task.Status.Status = fmt.Sprintf("Error getting kubernetes provider %s for task (id: %s): %v",
-			accountName, id, err)
+		task.Status.Status = fmt.Sprintf("Error listing resources for task (id: %s): %v", id, err)
 		c.JSON(http.StatusInternalServerError, task)
 
 		return
 	}
 
+	if len(resources) == 0 {
+		// A task can succeed without recording any resources.
+		if record.State == clouddriver.TaskStateSucceeded {
+			task.ResultObjects = append(task.ResultObjects, results...)
+			c.JSON(http.StatusOK, task)
+			return
+		}
+
+		task.Status.Failed = true
+		task.Status.Status = fmt.Sprintf("Task not found (id: %s)", id)
+		c.JSON(http.StatusNotFound, task)
+
+		return
+	}
+
+	// A task, such as a fan out, can record resources in more than one account.
+	providers := map[string]*kubernetes.Provider{}
+
 	for _, r := range resources {
 		// Ignore getting the manifest if task type is "cleanup" or "noop".
 		if strings.EqualFold(r.TaskType, clouddriver.TaskTypeCleanup) ||
@@ -59,6 +135,22 @@ func (cc *Controller) GetTask(c *gin.Context) {
 			continue
 		}
 
+		provider, ok := providers[r.AccountName]
+		if !ok {
+			provider, err = cc.KubernetesProvider(r.AccountName)
+			if err != nil {
+				task.Status.Failed = true
+				task.Status.Retryable = true
+				task.Status.Status = fmt.Sprintf("Error getting kubernetes provider %s for task (id: %s): %v",
+					r.AccountName, id, err)
+				c.JSON(http.StatusInternalServerError, task)
+
+				return
+			}
+
+			providers[r.AccountName] = provider
+		}
+
 		result, err := provider.Client.Get(r.Resource, r.Name, r.Namespace)
 		if err != nil {
 			// If the task type is "delete" and the resource was not found,
@@ -98,7 +190,7 @@ func (cc *Controller) GetTask(c *gin.Context) {
 		ManifestNamesByNamespaceToRefresh: mnr,
 	}
 
-	task.ResultObjects = []clouddriver.TaskResultObject{ro}
+	task.ResultObjects = append([]clouddriver.TaskResultObject{ro}, results...)
 
 	c.JSON(http.StatusOK, task)
 }
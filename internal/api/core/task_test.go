@@ -8,10 +8,13 @@ import (
 	"time"
 
 	"github.com/homedepot/go-clouddriver/internal/kubernetes"
+	"github.com/homedepot/go-clouddriver/internal/kubernetes/kubernetesfakes"
 	clouddriver "github.com/homedepot/go-clouddriver/pkg"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/rest"
 )
 
 var _ = Describe("Task", func() {
@@ -30,6 +33,231 @@ var _ = Describe("Task", func() {
 			doRequest()
 		})
 
+		When("getting the task returns an error", func() {
+			BeforeEach(func() {
+				fakeSQLClient.GetTaskReturns(clouddriver.TaskRecord{}, errors.New("error getting task"))
+			})
+
+			It("returns a failed task", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
+				t := clouddriver.Task{}
+				b, _ := io.ReadAll(res.Body)
+				err := json.Unmarshal(b, &t)
+				Expect(err).To(BeNil())
+				Expect(t.Status.Failed).To(BeTrue())
+				Expect(t.Status.Retryable).To(BeTrue())
+				Expect(t.Status.Status).To(Equal("Error getting task (id: task-id): error getting task"))
+			})
+		})
+
+		When("the task is not found", func() {
+			BeforeEach(func() {
+				fakeSQLClient.GetTaskReturns(clouddriver.TaskRecord{}, gorm.ErrRecordNotFound)
+			})
+
+			It("builds the task from its resources", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				Expect(fakeSQLClient.ListKubernetesResourcesByTaskIDCallCount()).To(Equal(1))
+			})
+		})
+
+		When("listing the task history returns an error", func() {
+			BeforeEach(func() {
+				fakeSQLClient.ListTaskHistoryByTaskIDReturns(nil, errors.New("error listing history"))
+			})
+
+			It("returns a failed task", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
+				t := clouddriver.Task{}
+				b, _ := io.ReadAll(res.Body)
+				err := json.Unmarshal(b, &t)
+				Expect(err).To(BeNil())
+				Expect(t.Status.Failed).To(BeTrue())
+				Expect(t.Status.Retryable).To(BeTrue())
+				Expect(t.Status.Status).To(Equal("Error listing history for task (id: task-id): error listing history"))
+			})
+		})
+
+		When("the task is pending", func() {
+			BeforeEach(func() {
+				fakeSQLClient.GetTaskReturns(clouddriver.TaskRecord{
+					ID:    "task-id",
+					State: clouddriver.TaskStatePending,
+				}, nil)
+			})
+
+			It("returns an incomplete task", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				t := clouddriver.Task{}
+				b, _ := io.ReadAll(res.Body)
+				err := json.Unmarshal(b, &t)
+				Expect(err).To(BeNil())
+				Expect(t.Status.Completed).To(BeFalse())
+				Expect(t.Status.Failed).To(BeFalse())
+				Expect(t.Status.Phase).To(Equal(clouddriver.TaskStatePending))
+				Expect(t.Status.Status).To(Equal("Orchestration pending."))
+				Expect(fakeSQLClient.ListKubernetesResourcesByTaskIDCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the task is running", func() {
+			BeforeEach(func() {
+				fakeSQLClient.GetTaskReturns(clouddriver.TaskRecord{
+					ID:    "task-id",
+					State: clouddriver.TaskStateRunning,
+				}, nil)
+			})
+
+			It("returns an incomplete task", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				t := clouddriver.Task{}
+				b, _ := io.ReadAll(res.Body)
+				err := json.Unmarshal(b, &t)
+				Expect(err).To(BeNil())
+				Expect(t.Status.Completed).To(BeFalse())
+				Expect(t.Status.Failed).To(BeFalse())
+				Expect(t.Status.Phase).To(Equal(clouddriver.TaskStateRunning))
+				Expect(t.Status.Status).To(Equal("Orchestration in progress."))
+			})
+		})
+
+		When("the task failed", func() {
+			BeforeEach(func() {
+				fakeSQLClient.GetTaskReturns(clouddriver.TaskRecord{
+					ID:        "task-id",
+					State:     clouddriver.TaskStateFailed,
+					Message:   "error applying manifest",
+					CreatedAt: time.UnixMilli(1609459200000),
+				}, nil)
+				fakeSQLClient.ListTaskHistoryByTaskIDReturns([]clouddriver.TaskHistory{
+					{
+						Phase:  "ORCHESTRATION",
+						Status: "Initializing Orchestration Task",
+					},
+					{
+						Phase:  "DEPLOY_KUBERNETES_MANIFEST",
+						Status: "error applying manifest",
+					},
+				}, nil)
+			})
+
+			It("returns the history of the task", func() {
+				Expect(fakeSQLClient.ListTaskHistoryByTaskIDArgsForCall(0)).To(Equal("task-id"))
+				t := clouddriver.Task{}
+				b, _ := io.ReadAll(res.Body)
+				err := json.Unmarshal(b, &t)
+				Expect(err).To(BeNil())
+				Expect(t.StartTimeMs).To(Equal(int64(1609459200000)))
+				Expect(t.History).To(HaveLen(2))
+				Expect(t.History[1].Phase).To(Equal("DEPLOY_KUBERNETES_MANIFEST"))
+				Expect(t.History[1].Status).To(Equal("error applying manifest"))
+			})
+
+			It("returns a failed task", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				t := clouddriver.Task{}
+				b, _ := io.ReadAll(res.Body)
+				err := json.Unmarshal(b, &t)
+				Expect(err).To(BeNil())
+				Expect(t.Status.Completed).To(BeTrue())
+				Expect(t.Status.Failed).To(BeTrue())
+				Expect(t.Status.Retryable).To(BeFalse())
+				Expect(t.Status.Phase).To(Equal(clouddriver.TaskStateFailed))
+				Expect(t.Status.Status).To(Equal("error applying manifest"))
+			})
+		})
+
+		When("the task succeeded without recording resources", func() {
+			BeforeEach(func() {
+				fakeSQLClient.GetTaskReturns(clouddriver.TaskRecord{
+					ID:    "task-id",
+					State: clouddriver.TaskStateSucceeded,
+				}, nil)
+				fakeSQLClient.ListKubernetesResourcesByTaskIDReturns([]kubernetes.Resource{}, nil)
+			})
+
+			It("returns a complete task", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				t := clouddriver.Task{}
+				b, _ := io.ReadAll(res.Body)
+				err := json.Unmarshal(b, &t)
+				Expect(err).To(BeNil())
+				Expect(t.Status.Completed).To(BeTrue())
+				Expect(t.Status.Failed).To(BeFalse())
+				Expect(t.Status.Phase).To(Equal(clouddriver.TaskStateSucceeded))
+			})
+		})
+
+		When("the task has a saved result", func() {
+			BeforeEach(func() {
+				fakeSQLClient.GetTaskReturns(clouddriver.TaskRecord{
+					ID:     "task-id",
+					State:  clouddriver.TaskStateSucceeded,
+					Result: `[{"manifests":[],"manifestDiffs":[{"kind":"Deployment","name":"test-deployment","changed":true,"diff":{"spec":{"replicas":3}}}]}]`,
+				}, nil)
+				fakeSQLClient.ListKubernetesResourcesByTaskIDReturns([]kubernetes.Resource{}, nil)
+			})
+
+			It("returns the result", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				t := clouddriver.Task{}
+				b, _ := io.ReadAll(res.Body)
+				err := json.Unmarshal(b, &t)
+				Expect(err).To(BeNil())
+				Expect(t.ResultObjects).To(HaveLen(1))
+				Expect(t.ResultObjects[0].ManifestDiffs).To(HaveLen(1))
+				Expect(t.ResultObjects[0].ManifestDiffs[0].Name).To(Equal("test-deployment"))
+				Expect(t.ResultObjects[0].ManifestDiffs[0].Changed).To(BeTrue())
+			})
+		})
+
+		When("the task failed with a saved result", func() {
+			BeforeEach(func() {
+				fakeSQLClient.GetTaskReturns(clouddriver.TaskRecord{
+					ID:      "task-id",
+					State:   clouddriver.TaskStateFailed,
+					Message: "server-side apply failed with 1 conflict(s): .spec.replicas (managers: kubectl-client-side-apply)",
+					Result:  `[{"manifests":[],"fieldConflicts":[{"field":".spec.replicas","managers":["kubectl-client-side-apply"]}]}]`,
+				}, nil)
+			})
+
+			It("returns the result with the failed task", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				t := clouddriver.Task{}
+				b, _ := io.ReadAll(res.Body)
+				err := json.Unmarshal(b, &t)
+				Expect(err).To(BeNil())
+				Expect(t.Status.Failed).To(BeTrue())
+				Expect(t.ResultObjects).To(HaveLen(1))
+				Expect(t.ResultObjects[0].FieldConflicts).To(Equal([]clouddriver.FieldConflict{
+					{
+						Field:    ".spec.replicas",
+						Managers: []string{"kubectl-client-side-apply"},
+					},
+				}))
+			})
+		})
+
+		When("the saved result cannot be decoded", func() {
+			BeforeEach(func() {
+				fakeSQLClient.GetTaskReturns(clouddriver.TaskRecord{
+					ID:     "task-id",
+					State:  clouddriver.TaskStateSucceeded,
+					Result: "{}",
+				}, nil)
+			})
+
+			It("returns a failed task", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
+				t := clouddriver.Task{}
+				b, _ := io.ReadAll(res.Body)
+				err := json.Unmarshal(b, &t)
+				Expect(err).To(BeNil())
+				Expect(t.Status.Failed).To(BeTrue())
+				Expect(t.Status.Status).To(HavePrefix("Error decoding result for task (id: task-id): "))
+			})
+		})
+
 		When("listing the resources returns an error", func() {
 			BeforeEach(func() {
 				fakeSQLClient.ListKubernetesResourcesByTaskIDReturns(nil, errors.New("error listing resources"))
@@ -190,6 +418,43 @@ var _ = Describe("Task", func() {
 			})
 		})
 
+		When("the task recorded resources in more than one account", func() {
+			var otherKubeClient *kubernetesfakes.FakeClient
+
+			BeforeEach(func() {
+				otherKubeClient = &kubernetesfakes.FakeClient{}
+				otherKubeClient.GetReturns(&unstructured.Unstructured{Object: map[string]interface{}{}}, nil)
+				fakeSQLClient.ListKubernetesResourcesByTaskIDReturns([]kubernetes.Resource{
+					{AccountName: "account1", Resource: "deployment", Name: "test-name1", Namespace: "test-namespace"},
+					{AccountName: "account2", Resource: "deployment", Name: "test-name2", Namespace: "test-namespace"},
+					{AccountName: "account1", Resource: "service", Name: "test-name3", Namespace: "test-namespace"},
+				}, nil)
+				fakeSQLClient.GetKubernetesProviderStub = func(name string) (kubernetes.Provider, error) {
+					return kubernetes.Provider{Name: name, Host: "http://" + name}, nil
+				}
+				fakeKubeController.NewClientStub = func(config *rest.Config) (kubernetes.Client, error) {
+					if config.Host == "http://account2" {
+						return otherKubeClient, nil
+					}
+
+					return fakeKubeClient, nil
+				}
+			})
+
+			It("gets each resource from its own account", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				Expect(fakeSQLClient.GetKubernetesProviderCallCount()).To(Equal(2))
+				Expect(fakeKubeClient.GetCallCount()).To(Equal(2))
+				_, name, _ := fakeKubeClient.GetArgsForCall(0)
+				Expect(name).To(Equal("test-name1"))
+				_, name, _ = fakeKubeClient.GetArgsForCall(1)
+				Expect(name).To(Equal("test-name3"))
+				Expect(otherKubeClient.GetCallCount()).To(Equal(1))
+				_, name, _ = otherKubeClient.GetArgsForCall(0)
+				Expect(name).To(Equal("test-name2"))
+			})
+		})
+
 		When("it succeeds", func() {
 			It("succeeds", func() {
 				Expect(res.StatusCode).To(Equal(http.StatusOK))
@@ -1,9 +1,13 @@
 package api
 
 import (
+	"context"
+	"time"
+
 	"github.com/gin-gonic/gin"
 	"github.com/homedepot/go-clouddriver/internal"
 	"github.com/homedepot/go-clouddriver/internal/api/core"
+	"github.com/homedepot/go-clouddriver/internal/api/core/kubernetes"
 	v1 "github.com/homedepot/go-clouddriver/internal/api/v1"
 	"github.com/homedepot/go-clouddriver/internal/middleware"
 )
@@ -20,6 +24,12 @@ type Server struct {
 	e *gin.Engine
 	// v is verbose request logging.
 	v bool
+	// w is the number of workers executing kubernetes operations.
+	w int
+	// ri is the interval the resources table is reconciled on.
+	ri time.Duration
+	// hi is the interval accounts are health checked on.
+	hi time.Duration
 }
 
 // NewServer returns a new instance of Server.
@@ -39,6 +49,24 @@ func (s *Server) WithVerboseRequestLogging() {
 	s.v = true
 }
 
+// WithTaskWorkers sets the number of background workers that
+// execute queued kubernetes operations.
+func (s *Server) WithTaskWorkers(n int) {
+	s.w = n
+}
+
+// WithResourceReconcileInterval sets the interval on which the resources
+// table is reconciled with the resources in each provider's cluster.
+func (s *Server) WithResourceReconcileInterval(d time.Duration) {
+	s.ri = d
+}
+
+// WithAccountHealthCheckInterval sets the interval on which
+// the cluster of each account is health checked.
+func (s *Server) WithAccountHealthCheckInterval(d time.Duration) {
+	s.hi = d
+}
+
 // Setup sets any global middlewares then initializes the API.
 func (s *Server) Setup() {
 	s.e.Use(middleware.HandleError())
@@ -61,11 +89,13 @@ func (s *Server) Setup() {
 		api.GET("/health", core.OK)
 
 		// Force cache refresh.
-		api.POST("/cache/kubernetes/manifest", core.OK)
+		api.POST("/cache/kubernetes/manifest", mc.AuthAccount("READ"), c.RefreshManifestCache)
+		api.GET("/cache/kubernetes/manifest", c.ListPendingManifestCacheRefreshes)
 
 		// Credentials API controller.
 		api.GET("/credentials", middleware.CacheControl(cacheControlMaxAge60), c.ListCredentials)
 		api.GET("/credentials/:account", c.GetAccountCredentials)
+		api.GET("/credentials/:account/status", c.GetAccountStatus)
 
 		// Applications API controller.
 		//
@@ -83,6 +113,10 @@ func (s *Server) Setup() {
 		// https: //github.com/spinnaker/clouddriver/blob/master/clouddriver-web/src/main/groovy/com/netflix/spinnaker/clouddriver/controllers/LoadBalancerController.groovy#L42
 		api.GET("/applications/:application/loadBalancers", middleware.CacheControl(cacheControlMaxAge30), c.ListLoadBalancers)
 
+		// Firewalls are the security groups (NetworkPolicies) of an application.
+		api.GET("/applications/:application/firewalls", mc.AuthApplication("READ"),
+			middleware.Vary(headerXSpinnakerAccounts), c.ListApplicationFirewalls)
+
 		// https://github.com/spinnaker/clouddriver/blob/master/clouddriver-web/src/main/groovy/com/netflix/spinnaker/clouddriver/controllers/ServerGroupController.groovy#L75
 		// @PreAuthorize("hasPermission(#account, 'ACCOUNT', 'READ')")
 		// @PostAuthorize("hasPermission(returnObject?.moniker?.app, 'APPLICATION', 'READ')")
@@ -101,20 +135,33 @@ func (s *Server) Setup() {
 		// @PreAuthorize("hasPermission(#application, 'APPLICATION', 'READ') and hasPermission(#account, 'ACCOUNT', 'READ')")
 		// @ApiOperation(value = "Collect a JobStatus", notes = "Collects the output of the job.")
 		api.GET("/applications/:application/jobs/:account/:location/:name", mc.AuthApplication("READ"), mc.AuthAccount("READ"), c.GetJob)
-		// Delete job always fails, so we do not need to pass through the auth middlewares.
-		api.DELETE("/applications/:application/jobs/:account/:location/:name", core.DeleteJob)
+		// https://github.com/spinnaker/clouddriver/blob/master/clouddriver-web/src/main/groovy/com/netflix/spinnaker/clouddriver/controllers/JobController.groovy
+		// @PreAuthorize("hasPermission(#application, 'APPLICATION', 'READ') and hasPermission(#account, 'ACCOUNT', 'READ')")
+		// @ApiOperation(value = "Collect a file from a job", notes = "Collects the file result of a job.")
+		api.GET("/applications/:application/jobs/:account/:location/:name/:fileName",
+			mc.AuthApplication("READ"), mc.AuthAccount("READ"), c.GetJobFileContents)
+		// https://github.com/spinnaker/clouddriver/blob/master/clouddriver-web/src/main/groovy/com/netflix/spinnaker/clouddriver/controllers/JobController.groovy
+		// @PreAuthorize("hasPermission(#application, 'APPLICATION', 'EXECUTE') and hasPermission(#account, 'ACCOUNT', 'WRITE')")
+		// @ApiOperation(value = "Cancel a Job", notes = "Cancels the job.")
+		api.DELETE("/applications/:application/jobs/:account/:location/:name",
+			mc.AuthApplication("EXECUTE"), mc.AuthAccount("WRITE"), middleware.TaskID(), c.DeleteJob)
+
+		// Preconfigured jobs, run by the 'runPreconfiguredJob' kubernetes operation.
+		api.GET("/jobs/preconfigured", c.ListPreconfiguredJobs)
 
 		// Create a kubernetes operation - deploy/delete/scale manifest.
 		api.POST("/kubernetes/ops", mc.AuthOps(), middleware.TaskID(), c.CreateKubernetesOperation)
 
 		// Manifests API controller.
 		api.GET("/manifests/:account/:location/:kind", c.GetManifest)
+		api.GET("/manifests/:account/:location/:kind/managedFields", c.GetManifestManagedFields)
 		api.GET("/manifests/:account/:location/:kind/cluster/:application/:cluster", c.ListManifestsByCluster)
 		api.GET("/manifests/:account/:location/:kind/cluster/:application/:cluster/dynamic/:criteria", c.GetManifestByCriteria)
 
 		// Instances API controller.
 		api.GET("/instances/:account/:location/:name", c.GetInstance)
 		api.GET("/instances/:account/:location/:name/console", c.GetInstanceConsole)
+		api.GET("/instances/:account/:location/:name/logs", mc.AuthAccount("READ"), c.StreamInstanceLogs)
 
 		// Get results for a task triggered in CreateKubernetesOperation.
 		api.GET("/task/:id", c.GetTask)
@@ -123,11 +170,13 @@ func (s *Server) Setup() {
 		// https://github.com/spinnaker/clouddriver/blob/0524d08f6bcf775c469a0576a79b2679b5653325/clouddriver-web/src/main/groovy/com/netflix/spinnaker/clouddriver/controllers/SearchController.groovy#L55
 		api.GET("/search", middleware.CacheControl(cacheControlMaxAge30), middleware.Vary(headerXSpinnakerAccounts), c.Search)
 
-		// Not implemented.
-		//
+		// Security groups API controller, where security groups are NetworkPolicies.
+		// https://github.com/spinnaker/clouddriver/blob/master/clouddriver-web/src/main/groovy/com/netflix/spinnaker/clouddriver/controllers/SecurityGroupController.groovy
 		// @PreAuthorize("@fiatPermissionEvaluator.storeWholePermission()")
 		// @PostAuthorize("@authorizationSupport.filterForAccounts(returnObject)")
-		api.GET("/securityGroups", core.ListSecurityGroups)
+		api.GET("/securityGroups", middleware.Vary(headerXSpinnakerAccounts), c.ListSecurityGroups)
+		api.GET("/securityGroups/:account", mc.AuthAccount("READ"), c.ListSecurityGroupsByAccount)
+		api.GET("/securityGroups/:account/:type/:region/:name", mc.AuthAccount("READ"), c.GetSecurityGroup)
 
 		// Artifacts API controller.
 		api.GET("/artifacts/credentials", c.ListArtifactCredentials)
@@ -136,7 +185,7 @@ func (s *Server) Setup() {
 		api.PUT("/artifacts/fetch/", c.GetArtifact)
 
 		// Features.
-		api.GET("/features/stages", core.ListStages)
+		api.GET("/features/stages", c.ListStages)
 
 		// Projects API controller.
 		// https://github.com/spinnaker/clouddriver/blob/master/clouddriver-web/src/main/groovy/com/netflix/spinnaker/clouddriver/controllers/ProjectController.groovy
@@ -155,8 +204,24 @@ func (s *Server) Setup() {
 		api.POST("/kubernetes/providers", c.CreateKubernetesProvider)
 		api.PUT("/kubernetes/providers", c.CreateOrReplaceKubernetesProvider)
 		api.DELETE("/kubernetes/providers/:name", c.DeleteKubernetesProvider)
+		api.PATCH("/kubernetes/providers/:name", c.PatchKubernetesProvider)
 		// Resources endpoint for kubernetes.
 		api.PUT("/kubernetes/providers/:name/resources", c.LoadKubernetesResources)
 		api.DELETE("/kubernetes/providers/:name/resources", c.DeleteKubernetesResources)
 	}
+
+	// Start the workers that execute tasks created by the /kubernetes/ops endpoint.
+	if s.w > 0 {
+		kubernetes.NewTaskWorker(s.c, s.w).Start(context.Background())
+	}
+
+	// Keep the resources table in sync with the resources in each provider's cluster.
+	if s.ri > 0 {
+		v1.NewResourceReconciler(s.c, s.ri).Start(context.Background())
+	}
+
+	// Record the health of each account, exposed by the credentials endpoints.
+	if s.hi > 0 {
+		v1.NewAccountHealthChecker(s.c, s.hi).Start(context.Background())
+	}
 }
@@ -0,0 +1,89 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/homedepot/go-clouddriver/internal"
+	"github.com/homedepot/go-clouddriver/internal/kubernetes"
+	clouddriver "github.com/homedepot/go-clouddriver/pkg"
+)
+
+// AccountHealthChecker periodically checks that the cluster of each
+// provider is reachable with the provider's credentials, recording the result
+// of each check in the account health store and as metrics.
+type AccountHealthChecker struct {
+	*Controller
+	interval time.Duration
+}
+
+// NewAccountHealthChecker returns a health checker that checks
+// every account on the given interval.
+func NewAccountHealthChecker(ic *internal.Controller, interval time.Duration) *AccountHealthChecker {
+	return &AccountHealthChecker{
+		Controller: &Controller{ic},
+		interval:   interval,
+	}
+}
+
+// Start starts checking accounts in the background until the context is done.
+func (h *AccountHealthChecker) Start(ctx context.Context) {
+	go h.run(ctx)
+}
+
+func (h *AccountHealthChecker) run(ctx context.Context) {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	for {
+		h.check()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check checks every account concurrently and forgets
+// the health of accounts whose provider was deleted.
+func (h *AccountHealthChecker) check() {
+	providers, err := h.SQLClient.ListKubernetesProviders()
+	if err != nil {
+		clouddriver.Log(fmt.Errorf("error listing kubernetes providers to health check: %w", err))
+		return
+	}
+
+	accounts := map[string]bool{}
+	wg := &sync.WaitGroup{}
+
+	for _, provider := range providers {
+		accounts[provider.Name] = true
+
+		wg.Add(1)
+
+		go func(provider kubernetes.Provider) {
+			defer wg.Done()
+
+			health := h.RecordKubernetesAccountHealth(provider)
+			if health.Error != "" {
+				clouddriver.Log(fmt.Errorf("health check of account %s failed: %s", provider.Name, health.Error))
+			}
+		}(provider)
+	}
+
+	wg.Wait()
+
+	if h.KubernetesAccountHealth == nil {
+		return
+	}
+
+	for _, account := range h.KubernetesAccountHealth.Accounts() {
+		if !accounts[account] {
+			h.ForgetKubernetesAccountHealth(account)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package v1_test
+
+import (
+	"context"
+	"io"
+	"log"
+	"time"
+
+	"github.com/homedepot/arcade/pkg/arcadefakes"
+	"github.com/homedepot/go-clouddriver/internal"
+	. "github.com/homedepot/go-clouddriver/internal/api/v1"
+	"github.com/homedepot/go-clouddriver/internal/kubernetes"
+	"github.com/homedepot/go-clouddriver/internal/kubernetes/kubernetesfakes"
+	"github.com/homedepot/go-clouddriver/internal/sql/sqlfakes"
+	clouddriver "github.com/homedepot/go-clouddriver/pkg"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AccountHealthChecker", func() {
+	var (
+		fakeSQLClient      *sqlfakes.FakeClient
+		fakeKubeClientset  *kubernetesfakes.FakeClientset
+		fakeKubeController *kubernetesfakes.FakeController
+		accountHealthStore kubernetes.AccountHealthStore
+		cancel             context.CancelFunc
+	)
+
+	BeforeEach(func() {
+		log.SetOutput(io.Discard)
+
+		fakeKubeClientset = &kubernetesfakes.FakeClientset{}
+		fakeKubeClientset.ServerVersionReturns("v1.26.15", nil)
+
+		fakeKubeController = &kubernetesfakes.FakeController{}
+		fakeKubeController.NewClientReturns(&kubernetesfakes.FakeClient{}, nil)
+		fakeKubeController.NewClientsetReturns(fakeKubeClientset, nil)
+
+		fakeSQLClient = &sqlfakes.FakeClient{}
+		fakeSQLClient.ListKubernetesProvidersReturns([]kubernetes.Provider{
+			{Name: "test-account1"},
+			{Name: "test-account2"},
+		}, nil)
+
+		// Health of an account whose provider was deleted.
+		accountHealthStore = kubernetes.NewAccountHealthStore()
+		accountHealthStore.Set(clouddriver.AccountHealth{Account: "deleted-account"})
+	})
+
+	JustBeforeEach(func() {
+		var ctx context.Context
+		ctx, cancel = context.WithCancel(context.Background())
+
+		NewAccountHealthChecker(&internal.Controller{
+			ArcadeClient:            &arcadefakes.FakeClient{},
+			KubernetesAccountHealth: accountHealthStore,
+			KubernetesController:    fakeKubeController,
+			SQLClient:               fakeSQLClient,
+		}, time.Hour).Start(ctx)
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	When("it starts", func() {
+		It("checks every account", func() {
+			Eventually(accountHealthStore.Accounts).Should(Equal([]string{"test-account1", "test-account2"}))
+
+			h, _ := accountHealthStore.Get("test-account1")
+			Expect(h.Reachable).To(BeTrue())
+			Expect(h.ServerVersion).To(Equal("v1.26.15"))
+		})
+	})
+})
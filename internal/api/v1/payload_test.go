@@ -111,6 +111,26 @@ const payloadRequestKubernetesProvidersMissingReadGroup = `{
 					}
 				}`
 
+const payloadRequestKubernetesProvidersMissingBearerToken = `{
+					"name": "test-name",
+					"host": "test-host",
+					"caData": "dGVzdC1jYS1kYXRhCg==",
+					"tokenProvider": "bearerToken",
+					"permissions": {
+						"read": [
+							"gg_test"
+						],
+						"write": [
+							"gg_test"
+						]
+					}
+				}`
+
+const payloadRequestKubernetesProviderPatch = `{
+					"host": "new-host",
+					"namespaces": ["n1"]
+				}`
+
 const payloadConflictRequest = `{
             "error": "provider already exists"
           }`
@@ -135,6 +155,26 @@ const payloadErrorGettingToken = `{
 					"error": "error getting token: unsupported token provider"
 				}`
 
+const payloadErrorMissingBearerToken = `{
+					"error": "bearer token required for token provider bearerToken"
+				}`
+
+const payloadErrorIfMatchRequired = `{
+					"error": "If-Match header required"
+				}`
+
+const payloadErrorInvalidIfMatch = `{
+					"error": "invalid If-Match header *"
+				}`
+
+const payloadErrorProviderModified = `{
+					"error": "provider has been modified"
+				}`
+
+const payloadErrorUpdatingProvider = `{
+					"error": "error updating provider"
+				}`
+
 const payloadErrorMissingReadGroup = `{
 					"error": "error in permissions: write group 'gg_test2' must be included as a read group"
 				}`
@@ -157,6 +197,21 @@ const payloadKubernetesProviderCreated = `{
             }
           }`
 
+const payloadKubernetesProviderPatched = `{
+            "name": "test-name",
+            "host": "new-host",
+            "namespaces": ["n1"],
+            "caData": "dGVzdC1jYS1kYXRhCg==",
+            "permissions": {
+              "read": [
+                "gg_test"
+              ],
+              "write": [
+                "gg_test"
+              ]
+            }
+          }`
+
 const payloadKubernetesProviderCreatedNoNamespace = `{
             "name": "test-name",
             "host": "test-host",
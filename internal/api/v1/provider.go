@@ -2,12 +2,15 @@ package v1
 
 import (
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/homedepot/go-clouddriver/internal/kubernetes"
+	"github.com/homedepot/go-clouddriver/internal/sql"
 	"gorm.io/gorm"
 )
 
@@ -48,12 +51,15 @@ func (cc *Controller) CreateKubernetesProvider(c *gin.Context) {
 		p.Namespace = nil
 	}
 
+	p.Version = 1
+
 	err = cc.SQLClient.CreateKubernetesProvider(p)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	c.Header("ETag", etag(p.Version))
 	c.JSON(http.StatusCreated, p)
 }
 
@@ -79,6 +85,9 @@ func (cc *Controller) DeleteKubernetesProvider(c *gin.Context) {
 		return
 	}
 
+	cc.ForgetKubernetesAccountHealth(name)
+	cc.ForgetKubernetesResourceCache(name)
+
 	c.JSON(http.StatusNoContent, nil)
 }
 
@@ -98,6 +107,7 @@ func (cc *Controller) GetKubernetesProvider(c *gin.Context) {
 		return
 	}
 
+	c.Header("ETag", etag(p.Version))
 	c.JSON(http.StatusOK, p)
 }
 
@@ -124,7 +134,8 @@ func (cc *Controller) ListKubernetesProvider(c *gin.Context) {
 }
 
 // CreateOrReplaceKubernetesProvider creates the kubernetes account (provider),
-// or if existing account, replaces it.
+// or if existing account, replaces it. If the request sends the ETag of the
+// provider in the If-Match header, the provider is only replaced at that version.
 func (cc *Controller) CreateOrReplaceKubernetesProvider(c *gin.Context) {
 	p := kubernetes.Provider{}
 
@@ -150,41 +161,168 @@ func (cc *Controller) CreateOrReplaceKubernetesProvider(c *gin.Context) {
 		p.Namespace = nil
 	}
 
-	err = cc.SQLClient.DeleteKubernetesProvider(p.Name)
+	// The version of the provider being replaced, or 0 if it does not exist.
+	version := int64(0)
+
+	existing, err := cc.SQLClient.GetKubernetesProviderAndPermissions(p.Name)
+	if err == nil {
+		version = existing.Version
+	} else if err != gorm.ErrRecordNotFound {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if ifMatch := c.GetHeader("If-Match"); ifMatch != "" {
+		expected, err := parseETag(ifMatch)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if expected != version {
+			if version > 0 {
+				c.Header("ETag", etag(version))
+			}
+
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": sql.ErrProviderVersionConflict.Error()})
+
+			return
+		}
+	}
+
+	// Keep incrementing the version of a replaced provider,
+	// so that its previous ETags no longer match.
+	p.Version = version + 1
+
+	err = cc.SQLClient.ReplaceKubernetesProvider(p, version)
 	if err != nil {
+		if errors.Is(err, sql.ErrProviderVersionConflict) {
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+			return
+		}
+
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+
 		return
 	}
 
-	err = cc.SQLClient.CreateKubernetesProvider(p)
+	cc.ForgetKubernetesResourceCache(p.Name)
+
+	c.Header("ETag", etag(p.Version))
+	c.JSON(http.StatusOK, p)
+}
+
+// PatchKubernetesProvider updates the given fields of the kubernetes account (provider).
+// The request must send the ETag of the provider in the If-Match header, so that
+// concurrent updates of the same provider can not overwrite each other.
+func (cc *Controller) PatchKubernetesProvider(c *gin.Context) {
+	name := c.Param("name")
+	patch := kubernetes.ProviderPatch{}
+
+	err := c.ShouldBindJSON(&patch)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ifMatch := c.GetHeader("If-Match")
+	if ifMatch == "" {
+		c.JSON(http.StatusPreconditionRequired, gin.H{"error": "If-Match header required"})
+		return
+	}
+
+	version, err := parseETag(ifMatch)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	p, err := cc.SQLClient.GetKubernetesProviderAndPermissions(name)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "provider not found"})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	if p.Version != version {
+		c.Header("ETag", etag(p.Version))
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": sql.ErrProviderVersionConflict.Error()})
+
+		return
+	}
+
+	p = patch.Apply(p)
+
+	err = validateCAData(p.CAData)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = validatePermissions(p.Permissions)
 	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = cc.SQLClient.UpdateKubernetesProvider(name, version, patch)
+	if err != nil {
+		if errors.Is(err, sql.ErrProviderVersionConflict) {
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+			return
+		}
+
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+
 		return
 	}
 
+	cc.ForgetKubernetesResourceCache(name)
+
+	p.Version = version + 1
+
+	c.Header("ETag", etag(p.Version))
 	c.JSON(http.StatusOK, p)
 }
 
 // validates verifies the provider's data.  Validations performed:
 // - the CAData is base64 encoded
-// - the TokenProvider is known/supported by arcade
+// - the credential source selected by the TokenProvider can provide credentials
 // - every Permissions.Write entry exists in Permissions.Read
 func (cc *Controller) validate(p kubernetes.Provider) error {
-	_, err := base64.StdEncoding.DecodeString(p.CAData)
+	err := validateCAData(p.CAData)
 	if err != nil {
-		return fmt.Errorf("error decoding base64 CA data: %s", err.Error())
+		return err
 	}
 
-	_, err = cc.ArcadeClient.Token(p.TokenProvider)
+	err = kubernetes.NewCredentialSource(p.TokenProvider, cc.ArcadeClient, cc.CredentialPolicy).Validate(p)
 	if err != nil {
-		return fmt.Errorf("error getting token: %s", err.Error())
+		return err
+	}
+
+	return validatePermissions(p.Permissions)
+}
+
+func validateCAData(caData string) error {
+	_, err := base64.StdEncoding.DecodeString(caData)
+	if err != nil {
+		return fmt.Errorf("error decoding base64 CA data: %s", err.Error())
 	}
 
-	// Verify that each write group is included as a read group
-	for _, wg := range p.Permissions.Write {
+	return nil
+}
+
+// validatePermissions verifies that each write group is included as a read group.
+func validatePermissions(permissions kubernetes.ProviderPermissions) error {
+	for _, wg := range permissions.Write {
 		found := false
 
-		for _, rg := range p.Permissions.Read {
+		for _, rg := range permissions.Read {
 			if strings.EqualFold(wg, rg) {
 				found = true
 				break
@@ -198,3 +336,20 @@ func (cc *Controller) validate(p kubernetes.Provider) error {
 
 	return nil
 }
+
+// etag returns the ETag of a provider's version.
+func etag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// parseETag returns the provider version of an ETag.
+func parseETag(s string) (int64, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "W/")
+
+	version, err := strconv.ParseInt(strings.Trim(s, `"`), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid If-Match header %s", s)
+	}
+
+	return version, nil
+}
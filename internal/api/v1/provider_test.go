@@ -7,6 +7,7 @@ import (
 
 	// . "github.com/homedepot/go-clouddriver/internal/api/v1"
 	"github.com/homedepot/go-clouddriver/internal/kubernetes"
+	"github.com/homedepot/go-clouddriver/internal/sql"
 	"gorm.io/gorm"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -69,6 +70,20 @@ var _ = Describe("Provider", func() {
 			})
 		})
 
+		When("the token provider's credentials are missing", func() {
+			BeforeEach(func() {
+				body = &bytes.Buffer{}
+				body.Write([]byte(payloadRequestKubernetesProvidersMissingBearerToken))
+				createRequest(http.MethodPost)
+			})
+
+			It("returns status bad request", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+				validateResponse(payloadErrorMissingBearerToken)
+				Expect(fakeArcadeClient.TokenCallCount()).To(Equal(0))
+			})
+		})
+
 		When("the a write permission group is not a read permission group", func() {
 			BeforeEach(func() {
 				body = &bytes.Buffer{}
@@ -107,6 +122,7 @@ var _ = Describe("Provider", func() {
 		When("it succeeds", func() {
 			It("returns status created", func() {
 				Expect(res.StatusCode).To(Equal(http.StatusCreated))
+				Expect(res.Header.Get("ETag")).To(Equal(`"1"`))
 				validateResponse(payloadKubernetesProviderCreated)
 			})
 		})
@@ -230,20 +246,56 @@ var _ = Describe("Provider", func() {
 			})
 		})
 
-		When("deleting the kubernetes provider returns an error", func() {
+		When("getting the existing provider returns an error", func() {
 			BeforeEach(func() {
-				fakeSQLClient.DeleteKubernetesProviderReturns(errors.New("error deleting provider"))
+				fakeSQLClient.GetKubernetesProviderAndPermissionsReturns(kubernetes.Provider{}, errors.New("error getting provider"))
 			})
 
 			It("returns status internal server error", func() {
 				Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
-				validateResponse(payloadKubernetesProviderDeleteGenericError)
+				validateResponse(payloadKubernetesProviderGetGenericError)
 			})
 		})
 
-		When("creating the kubernetes provider returns an error", func() {
+		When("the If-Match header is invalid", func() {
 			BeforeEach(func() {
-				fakeSQLClient.CreateKubernetesProviderReturns(errors.New("error creating provider"))
+				req.Header.Set("If-Match", "*")
+			})
+
+			It("returns status bad request", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(fakeSQLClient.ReplaceKubernetesProviderCallCount()).To(BeZero())
+			})
+		})
+
+		When("the provider has been modified", func() {
+			BeforeEach(func() {
+				fakeSQLClient.GetKubernetesProviderAndPermissionsReturns(kubernetes.Provider{Version: 2}, nil)
+				req.Header.Set("If-Match", `"1"`)
+			})
+
+			It("returns status precondition failed and the current ETag", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusPreconditionFailed))
+				Expect(res.Header.Get("ETag")).To(Equal(`"2"`))
+				Expect(fakeSQLClient.ReplaceKubernetesProviderCallCount()).To(BeZero())
+				validateResponse(payloadErrorProviderModified)
+			})
+		})
+
+		When("the provider is modified during the replace", func() {
+			BeforeEach(func() {
+				fakeSQLClient.ReplaceKubernetesProviderReturns(sql.ErrProviderVersionConflict)
+			})
+
+			It("returns status precondition failed", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusPreconditionFailed))
+				validateResponse(payloadErrorProviderModified)
+			})
+		})
+
+		When("replacing the kubernetes provider returns an error", func() {
+			BeforeEach(func() {
+				fakeSQLClient.ReplaceKubernetesProviderReturns(errors.New("error creating provider"))
 			})
 
 			It("returns status internal server error", func() {
@@ -254,23 +306,48 @@ var _ = Describe("Provider", func() {
 
 		When("it succeeds", func() {
 			When("the provider does not exist", func() {
+				BeforeEach(func() {
+					fakeSQLClient.GetKubernetesProviderAndPermissionsReturns(kubernetes.Provider{}, gorm.ErrRecordNotFound)
+				})
+
 				It("returns ok and the provider is created", func() {
 					Expect(res.StatusCode).To(Equal(http.StatusOK))
+					Expect(res.Header.Get("ETag")).To(Equal(`"1"`))
 					validateResponse(payloadKubernetesProviderCreated)
 				})
 			})
 
 			When("the provider already exists", func() {
 				BeforeEach(func() {
-					fakeSQLClient.GetKubernetesProviderReturns(kubernetes.Provider{}, nil)
+					fakeSQLClient.GetKubernetesProviderAndPermissionsReturns(kubernetes.Provider{Version: 2}, nil)
 				})
 
-				It("returns ok and the provider is replaced", func() {
+				It("returns ok and the provider is replaced with the next version", func() {
 					Expect(res.StatusCode).To(Equal(http.StatusOK))
+					Expect(res.Header.Get("ETag")).To(Equal(`"3"`))
+					p, version := fakeSQLClient.ReplaceKubernetesProviderArgsForCall(0)
+					Expect(p.Version).To(Equal(int64(3)))
+					Expect(version).To(Equal(int64(2)))
+					Expect(fakeResourceCache.ForgetCallCount()).To(Equal(1))
+					Expect(fakeResourceCache.ForgetArgsForCall(0)).To(Equal("test-name"))
 					validateResponse(payloadKubernetesProviderCreated)
 				})
 			})
 
+			When("the If-Match header matches the provider", func() {
+				BeforeEach(func() {
+					fakeSQLClient.GetKubernetesProviderAndPermissionsReturns(kubernetes.Provider{Version: 2}, nil)
+					req.Header.Set("If-Match", `"2"`)
+				})
+
+				It("replaces the provider at its version", func() {
+					Expect(res.StatusCode).To(Equal(http.StatusOK))
+					Expect(res.Header.Get("ETag")).To(Equal(`"3"`))
+					_, version := fakeSQLClient.ReplaceKubernetesProviderArgsForCall(0)
+					Expect(version).To(Equal(int64(2)))
+				})
+			})
+
 			When("the namespace is empty string", func() {
 				BeforeEach(func() {
 					body = &bytes.Buffer{}
@@ -337,6 +414,7 @@ var _ = Describe("Provider", func() {
 					Read:  []string{"gg_test"},
 					Write: []string{"gg_test"},
 				},
+				Version: 2,
 			}
 
 			fakeSQLClient.GetKubernetesProviderAndPermissionsReturns(testProvider, nil)
@@ -377,11 +455,179 @@ var _ = Describe("Provider", func() {
 		When("it succeeds", func() {
 			It("returns ok and the provider", func() {
 				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				Expect(res.Header.Get("ETag")).To(Equal(`"2"`))
 				validateResponse(payloadKubernetesProviderCreated)
 			})
 		})
 	})
 
+	Describe("#PatchKubernetesProvider", func() {
+		BeforeEach(func() {
+			setup()
+			testProvider := kubernetes.Provider{
+				Name:       "test-name",
+				Host:       "test-host",
+				Namespaces: []string{"ns1", "ns2"},
+				CAData:     "dGVzdC1jYS1kYXRhCg==",
+				Permissions: kubernetes.ProviderPermissions{
+					Read:  []string{"gg_test"},
+					Write: []string{"gg_test"},
+				},
+				Version: 2,
+			}
+
+			fakeSQLClient.GetKubernetesProviderAndPermissionsReturns(testProvider, nil)
+			uri = svr.URL + "/v1/kubernetes/providers/test-name"
+			body.Write([]byte(payloadRequestKubernetesProviderPatch))
+			createRequest(http.MethodPatch)
+			req.Header.Set("If-Match", `"2"`)
+		})
+
+		AfterEach(func() {
+			teardown()
+		})
+
+		JustBeforeEach(func() {
+			doRequest()
+		})
+
+		When("the request body is bad data", func() {
+			BeforeEach(func() {
+				body = &bytes.Buffer{}
+				body.Write([]byte("dasdf[]dsf;;"))
+				createRequest(http.MethodPatch)
+			})
+
+			It("returns status bad request", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+				validateResponse(payloadBadRequest)
+			})
+		})
+
+		When("the If-Match header is missing", func() {
+			BeforeEach(func() {
+				req.Header.Del("If-Match")
+			})
+
+			It("returns status precondition required", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusPreconditionRequired))
+				validateResponse(payloadErrorIfMatchRequired)
+			})
+		})
+
+		When("the If-Match header is invalid", func() {
+			BeforeEach(func() {
+				req.Header.Set("If-Match", "*")
+			})
+
+			It("returns status bad request", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+				validateResponse(payloadErrorInvalidIfMatch)
+			})
+		})
+
+		When("the record is not found", func() {
+			BeforeEach(func() {
+				fakeSQLClient.GetKubernetesProviderAndPermissionsReturns(kubernetes.Provider{}, gorm.ErrRecordNotFound)
+			})
+
+			It("returns status not found", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusNotFound))
+				validateResponse(payloadKubernetesProviderNotFound)
+			})
+		})
+
+		When("getting the provider returns a generic error", func() {
+			BeforeEach(func() {
+				fakeSQLClient.GetKubernetesProviderAndPermissionsReturns(kubernetes.Provider{}, errors.New("error getting provider"))
+			})
+
+			It("returns status internal server error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
+				validateResponse(payloadKubernetesProviderGetGenericError)
+			})
+		})
+
+		When("the provider has been modified", func() {
+			BeforeEach(func() {
+				req.Header.Set("If-Match", `"1"`)
+			})
+
+			It("returns status precondition failed and the current ETag", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusPreconditionFailed))
+				Expect(res.Header.Get("ETag")).To(Equal(`"2"`))
+				Expect(fakeSQLClient.UpdateKubernetesProviderCallCount()).To(BeZero())
+				validateResponse(payloadErrorProviderModified)
+			})
+		})
+
+		When("the ca data in the request is bad", func() {
+			BeforeEach(func() {
+				body = &bytes.Buffer{}
+				body.Write([]byte(`{"caData": "test-ca-data"}`))
+				createRequest(http.MethodPatch)
+				req.Header.Set("If-Match", `"2"`)
+			})
+
+			It("returns status bad request", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+				validateResponse(payloadErrorDecodingBase64)
+			})
+		})
+
+		When("a write permission group is not a read permission group", func() {
+			BeforeEach(func() {
+				body = &bytes.Buffer{}
+				body.Write([]byte(`{"permissions": {"write": ["gg_test2"]}}`))
+				createRequest(http.MethodPatch)
+				req.Header.Set("If-Match", `"2"`)
+			})
+
+			It("returns status bad request", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+				validateResponse(payloadErrorMissingReadGroup)
+			})
+		})
+
+		When("the provider is modified during the update", func() {
+			BeforeEach(func() {
+				fakeSQLClient.UpdateKubernetesProviderReturns(sql.ErrProviderVersionConflict)
+			})
+
+			It("returns status precondition failed", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusPreconditionFailed))
+				validateResponse(payloadErrorProviderModified)
+			})
+		})
+
+		When("updating the provider returns an error", func() {
+			BeforeEach(func() {
+				fakeSQLClient.UpdateKubernetesProviderReturns(errors.New("error updating provider"))
+			})
+
+			It("returns status internal server error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
+				validateResponse(payloadErrorUpdatingProvider)
+			})
+		})
+
+		When("it succeeds", func() {
+			It("updates the provider at its version and returns the next ETag", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				Expect(res.Header.Get("ETag")).To(Equal(`"3"`))
+				Expect(fakeSQLClient.UpdateKubernetesProviderCallCount()).To(Equal(1))
+				name, version, patch := fakeSQLClient.UpdateKubernetesProviderArgsForCall(0)
+				Expect(name).To(Equal("test-name"))
+				Expect(version).To(Equal(int64(2)))
+				Expect(*patch.Host).To(Equal("new-host"))
+				Expect(patch.CAData).To(BeNil())
+				Expect(fakeResourceCache.ForgetCallCount()).To(Equal(1))
+				Expect(fakeResourceCache.ForgetArgsForCall(0)).To(Equal("test-name"))
+				validateResponse(payloadKubernetesProviderPatched)
+			})
+		})
+	})
+
 	Describe("#ListKubernetesProvider", func() {
 		BeforeEach(func() {
 			setup()
@@ -510,8 +756,10 @@ var _ = Describe("Provider", func() {
 		})
 
 		When("it succeeds", func() {
-			It("returns status no content", func() {
+			It("returns status no content and stops watching the account", func() {
 				Expect(res.StatusCode).To(Equal(http.StatusNoContent))
+				Expect(fakeResourceCache.ForgetCallCount()).To(Equal(1))
+				Expect(fakeResourceCache.ForgetArgsForCall(0)).To(Equal("test-name"))
 			})
 		})
 	})
@@ -0,0 +1,360 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/homedepot/go-clouddriver/internal"
+	"github.com/homedepot/go-clouddriver/internal/kubernetes"
+	clouddriver "github.com/homedepot/go-clouddriver/pkg"
+)
+
+const (
+	// defaultReconcileEventDelay batches the watch events of an account,
+	// as a rollout changes many resources at once.
+	defaultReconcileEventDelay = 5 * time.Second
+	// defaultReconcileGracePeriod keeps entries recorded by operations
+	// whose resources may not have reached the watch caches yet.
+	defaultReconcileGracePeriod = time.Minute
+	// reconcilerLease is the lease held by the replica that reconciles.
+	reconcilerLease = "resource-reconciler"
+)
+
+// ResourceReconciler keeps the Kubernetes resources table in sync with the
+// resources deployed by Spinnaker, including those created or deleted
+// outside of Spinnaker.
+//
+// It watches the infrastructure kinds of each provider and reconciles
+// an account shortly after its resources change, as well as every
+// account on each interval to pick up new providers and repair
+// any drift the watches missed.
+//
+// Only the replica holding the reconciler lease watches and reconciles
+// accounts, so that replicas do not race to repair the same drift.
+// The lease is renewed on each interval and expires after two, when
+// another replica takes over.
+type ResourceReconciler struct {
+	*Controller
+	owner       string
+	interval    time.Duration
+	eventDelay  time.Duration
+	gracePeriod time.Duration
+	queue       workqueue.DelayingInterface
+	mu          sync.Mutex
+	watches     map[string]*accountWatch
+}
+
+// accountWatch holds the informers watching the infrastructure kinds of an account.
+type accountWatch struct {
+	provider  *kubernetes.Provider
+	resources []watchedResource
+	stop      chan struct{}
+}
+
+type watchedResource struct {
+	gvr      schema.GroupVersionResource
+	informer cache.SharedIndexInformer
+}
+
+// NewResourceReconciler returns a reconciler that reconciles
+// every account on the given interval.
+func NewResourceReconciler(ic *internal.Controller, interval time.Duration) *ResourceReconciler {
+	hostname, _ := os.Hostname()
+
+	return &ResourceReconciler{
+		Controller:  &Controller{ic},
+		owner:       hostname + "-" + uuid.New().String(),
+		interval:    interval,
+		eventDelay:  defaultReconcileEventDelay,
+		gracePeriod: defaultReconcileGracePeriod,
+		queue:       workqueue.NewDelayingQueue(),
+		watches:     map[string]*accountWatch{},
+	}
+}
+
+// WithEventDelay sets how long to wait after a watch event
+// before reconciling the account.
+func (r *ResourceReconciler) WithEventDelay(d time.Duration) *ResourceReconciler {
+	r.eventDelay = d
+	return r
+}
+
+// WithGracePeriod sets how long entries are kept after being recorded
+// even if their resource is not found.
+func (r *ResourceReconciler) WithGracePeriod(d time.Duration) *ResourceReconciler {
+	r.gracePeriod = d
+	return r
+}
+
+// Start starts reconciling accounts in the background until the context is done.
+func (r *ResourceReconciler) Start(ctx context.Context) {
+	go r.work()
+	go r.run(ctx)
+}
+
+func (r *ResourceReconciler) run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if r.lead() {
+			r.refresh()
+		} else {
+			r.unwatchAll()
+		}
+
+		select {
+		case <-ctx.Done():
+			r.queue.ShutDown()
+			r.unwatchAll()
+
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// lead acquires or renews the reconciler lease,
+// returning true if this replica holds it.
+func (r *ResourceReconciler) lead() bool {
+	acquired, err := r.SQLClient.AcquireLease(reconcilerLease, r.owner, internal.CurrentTimeUTC().Add(2*r.interval))
+	if err != nil {
+		clouddriver.Log(fmt.Errorf("error acquiring reconciler lease: %w", err))
+		return false
+	}
+
+	return acquired
+}
+
+func (r *ResourceReconciler) work() {
+	for {
+		item, shutdown := r.queue.Get()
+		if shutdown {
+			return
+		}
+
+		r.reconcile(item.(string))
+		r.queue.Done(item)
+	}
+}
+
+// refresh watches the accounts of new providers, stops watching the accounts
+// of deleted providers, and queues every account to be reconciled.
+func (r *ResourceReconciler) refresh() {
+	providers, err := r.SQLClient.ListKubernetesProviders()
+	if err != nil {
+		clouddriver.Log(fmt.Errorf("error listing kubernetes providers to reconcile: %w", err))
+		return
+	}
+
+	accounts := map[string]bool{}
+
+	for _, provider := range providers {
+		accounts[provider.Name] = true
+
+		err = r.watch(provider.Name)
+		if err != nil {
+			clouddriver.Log(fmt.Errorf("error watching resources of account %s: %w", provider.Name, err))
+			continue
+		}
+
+		r.queue.Add(provider.Name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for account, w := range r.watches {
+		if !accounts[account] {
+			close(w.stop)
+			delete(r.watches, account)
+		}
+	}
+}
+
+// watch starts watching the infrastructure kinds of an account,
+// unless they are already watched.
+func (r *ResourceReconciler) watch(account string) error {
+	r.mu.Lock()
+	_, ok := r.watches[account]
+	r.mu.Unlock()
+
+	if ok {
+		return nil
+	}
+
+	// Watches outlive any timeout, so do not set one.
+	provider, err := r.KubernetesProvider(account)
+	if err != nil {
+		return err
+	}
+
+	// Discover the API before getting the GVR of each kind, see LoadKubernetesResources.
+	err = provider.Client.Discover()
+	if err != nil {
+		return err
+	}
+
+	w := &accountWatch{
+		provider: provider,
+		stop:     make(chan struct{}),
+	}
+
+	namespaces := provider.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{""}
+	}
+
+	for _, kind := range infrastructureKinds {
+		gvr, err := provider.Client.GVRForKind(kind)
+		if err != nil {
+			// The kind is not served by this cluster, so its entries are left untouched.
+			clouddriver.Log(fmt.Errorf("error getting resource of kind %s in account %s: %w", kind, account, err))
+			continue
+		}
+
+		for _, namespace := range namespaces {
+			informer := provider.Client.NewInformer(gvr, namespace, kubernetes.DefaultLabelSelector())
+
+			_, _ = informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+				AddFunc: func(interface{}) { r.queue.AddAfter(account, r.eventDelay) },
+				UpdateFunc: func(old, new interface{}) {
+					if entryChanged(old, new) {
+						r.queue.AddAfter(account, r.eventDelay)
+					}
+				},
+				DeleteFunc: func(interface{}) { r.queue.AddAfter(account, r.eventDelay) },
+			})
+
+			// The client's credentials are fixed when it is created, so once they expire
+			// stop watching the account until the next refresh watches it with a new client.
+			_ = informer.SetWatchErrorHandler(func(_ *cache.Reflector, err error) {
+				if errors.IsUnauthorized(err) || errors.IsForbidden(err) {
+					clouddriver.Log(fmt.Errorf("stopped watching resources of account %s: %w", account, err))
+					r.unwatch(account, w)
+				}
+			})
+
+			w.resources = append(w.resources, watchedResource{gvr: gvr, informer: informer})
+		}
+	}
+
+	r.mu.Lock()
+	r.watches[account] = w
+	r.mu.Unlock()
+
+	for _, wr := range w.resources {
+		go wr.informer.Run(w.stop)
+	}
+
+	return nil
+}
+
+// unwatch stops watching an account, unless it is already watched again.
+func (r *ResourceReconciler) unwatch(account string, w *accountWatch) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.watches[account] != w {
+		return
+	}
+
+	close(w.stop)
+	delete(r.watches, account)
+}
+
+func (r *ResourceReconciler) unwatchAll() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for account, w := range r.watches {
+		close(w.stop)
+		delete(r.watches, account)
+	}
+}
+
+// reconcile reconciles the resources table with the watched resources of an account.
+func (r *ResourceReconciler) reconcile(account string) {
+	r.mu.Lock()
+	w, ok := r.watches[account]
+	r.mu.Unlock()
+
+	if !ok {
+		return
+	}
+
+	live := []kubernetes.Resource{}
+	resources := map[string]bool{}
+
+	for _, wr := range w.resources {
+		// Reconciling with a partial cache would remove the entries of
+		// the resources not listed yet, so wait for the watches to sync.
+		if !wr.informer.HasSynced() {
+			r.queue.AddAfter(account, r.eventDelay)
+			return
+		}
+
+		resources[wr.gvr.Resource] = true
+
+		for _, obj := range wr.informer.GetStore().List() {
+			if u, ok := obj.(*unstructured.Unstructured); ok {
+				live = append(live, kubernetes.NewResource(account, wr.gvr, *u))
+			}
+		}
+	}
+
+	_, err := r.reconcileResources(w.provider, live, resources, internal.CurrentTimeUTC().Add(-r.gracePeriod))
+	if err != nil {
+		clouddriver.Log(fmt.Errorf("error reconciling resources of account %s: %w", account, err))
+	}
+}
+
+// entryChanged returns true if an update to an object changed its
+// Spinnaker application or searchable labels, the only attributes
+// of an entry in the resources table that an update can change.
+func entryChanged(old, new interface{}) bool {
+	o, ok := old.(*unstructured.Unstructured)
+	if !ok {
+		return true
+	}
+
+	n, ok := new.(*unstructured.Unstructured)
+	if !ok {
+		return true
+	}
+
+	return kubernetes.SpinnakerMonikerApplication(*o) != kubernetes.SpinnakerMonikerApplication(*n) ||
+		kubernetes.SearchableLabels(*o) != kubernetes.SearchableLabels(*n)
+}
+
+// reconcileResources reconciles the entries of the given resources, such as
+// "deployments", with the live objects of a provider's account. Entries in
+// namespaces the provider cannot access are left untouched. It returns the
+// entries added.
+func (cc *Controller) reconcileResources(provider *kubernetes.Provider, live []kubernetes.Resource,
+	resources map[string]bool, since time.Time) ([]kubernetes.Resource, error) {
+	entries, err := cc.SQLClient.ListKubernetesResourcesByAccountName(provider.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	recorded := []kubernetes.Resource{}
+
+	for _, kr := range entries {
+		if resources[kr.Resource] && provider.ValidateNamespaceAccess(kr.Namespace) == nil {
+			recorded = append(recorded, kr)
+		}
+	}
+
+	return cc.ReconcileKubernetesResources(provider.Name, recorded, live, since)
+}
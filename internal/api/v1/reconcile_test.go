@@ -0,0 +1,238 @@
+package v1_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/homedepot/arcade/pkg/arcadefakes"
+	"github.com/homedepot/go-clouddriver/internal"
+	. "github.com/homedepot/go-clouddriver/internal/api/v1"
+	"github.com/homedepot/go-clouddriver/internal/kubernetes"
+	"github.com/homedepot/go-clouddriver/internal/kubernetes/kubernetesfakes"
+	"github.com/homedepot/go-clouddriver/internal/sql/sqlfakes"
+	clouddriver "github.com/homedepot/go-clouddriver/pkg"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+var _ = Describe("ResourceReconciler", func() {
+	var (
+		fakeSQLClient      *sqlfakes.FakeClient
+		fakeKubeClient     *kubernetesfakes.FakeClient
+		fakeKubeController *kubernetesfakes.FakeController
+		dynamicClient      *fake.FakeDynamicClient
+		deployments        schema.GroupVersionResource
+		cancel             context.CancelFunc
+		mu                 sync.Mutex
+		recorded           []kubernetes.Resource
+	)
+
+	deployment := func(name string, labels map[string]string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{Object: map[string]interface{}{}}
+		u.SetAPIVersion("apps/v1")
+		u.SetKind("Deployment")
+		u.SetName(name)
+		u.SetNamespace("test-namespace")
+		u.SetLabels(labels)
+		u.SetAnnotations(map[string]string{kubernetes.AnnotationSpinnakerMonikerApplication: "test-application"})
+
+		return u
+	}
+
+	created := func() []string {
+		names := []string{}
+		for i := 0; i < fakeSQLClient.CreateKubernetesResourceCallCount(); i++ {
+			names = append(names, fakeSQLClient.CreateKubernetesResourceArgsForCall(i).Name)
+		}
+
+		return names
+	}
+
+	BeforeEach(func() {
+		log.SetOutput(io.Discard)
+
+		deployments = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+		listKinds := map[schema.GroupVersionResource]string{}
+
+		for _, resource := range []string{"daemonsets", "deployments", "ingresses", "replicasets", "services", "statefulsets"} {
+			listKinds[schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: resource}] = "List"
+		}
+
+		dynamicClient = fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds,
+			deployment("test-deployment1", map[string]string{kubernetes.LabelKubernetesManagedBy: "spinnaker"}),
+			deployment("test-deployment2", nil),
+		)
+
+		fakeKubeClient = &kubernetesfakes.FakeClient{}
+		fakeKubeClient.GVRForKindStub = func(kind string) (schema.GroupVersionResource, error) {
+			return schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: strings.ToLower(kind)}, nil
+		}
+		fakeKubeClient.NewInformerStub = func(gvr schema.GroupVersionResource, namespace, labelSelector string) cache.SharedIndexInformer {
+			return dynamicinformer.NewFilteredDynamicInformer(dynamicClient, gvr, namespace, 0, cache.Indexers{},
+				func(lo *metav1.ListOptions) { lo.LabelSelector = labelSelector }).Informer()
+		}
+
+		fakeKubeController = &kubernetesfakes.FakeController{}
+		fakeKubeController.NewClientReturns(fakeKubeClient, nil)
+
+		fakeSQLClient = &sqlfakes.FakeClient{}
+		fakeSQLClient.AcquireLeaseReturns(true, nil)
+		fakeSQLClient.ListKubernetesProvidersReturns([]kubernetes.Provider{{Name: "test-account"}}, nil)
+		fakeSQLClient.GetKubernetesProviderReturns(kubernetes.Provider{Name: "test-account"}, nil)
+
+		// Keep the recorded resources in memory like the DB would.
+		recorded = nil
+		fakeSQLClient.CreateKubernetesResourceStub = func(kr kubernetes.Resource) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			recorded = append(recorded, kr)
+
+			return nil
+		}
+		fakeSQLClient.DeleteKubernetesResourceStub = func(id string) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			for i, kr := range recorded {
+				if kr.ID == id {
+					recorded = append(recorded[:i], recorded[i+1:]...)
+					break
+				}
+			}
+
+			return nil
+		}
+		fakeSQLClient.ListKubernetesResourcesByAccountNameStub = func(string) ([]kubernetes.Resource, error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			return append([]kubernetes.Resource{}, recorded...), nil
+		}
+	})
+
+	JustBeforeEach(func() {
+		var ctx context.Context
+		ctx, cancel = context.WithCancel(context.Background())
+
+		NewResourceReconciler(&internal.Controller{
+			ArcadeClient:         &arcadefakes.FakeClient{},
+			KubernetesController: fakeKubeController,
+			SQLClient:            fakeSQLClient,
+		}, time.Hour).WithEventDelay(10 * time.Millisecond).WithGracePeriod(0).Start(ctx)
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	When("it starts", func() {
+		It("records the resources managed by Spinnaker", func() {
+			Eventually(created).Should(Equal([]string{"test-deployment1"}))
+			Expect(fakeKubeClient.NewInformerCallCount()).To(Equal(6))
+			kr := fakeSQLClient.CreateKubernetesResourceArgsForCall(0)
+			Expect(kr.AccountName).To(Equal("test-account"))
+			Expect(kr.Resource).To(Equal("deployments"))
+			Expect(kr.SpinnakerApp).To(Equal("test-application"))
+			Expect(kr.Cluster).To(Equal("deployment test-deployment1"))
+			Expect(kr.ID).ToNot(BeEmpty())
+			Expect(kr.TaskID).ToNot(BeEmpty())
+		})
+	})
+
+	When("a resource is created outside of Spinnaker", func() {
+		JustBeforeEach(func() {
+			Eventually(created).Should(HaveLen(1))
+
+			_, err := dynamicClient.Resource(deployments).Namespace("test-namespace").Create(context.Background(),
+				deployment("test-deployment3", map[string]string{kubernetes.LabelKubernetesManagedBy: "spinnaker"}),
+				metav1.CreateOptions{})
+			Expect(err).To(BeNil())
+		})
+
+		It("records the resource when it is watched", func() {
+			Eventually(created).Should(Equal([]string{"test-deployment1", "test-deployment3"}))
+		})
+	})
+
+	When("a resource is deleted outside of Spinnaker", func() {
+		JustBeforeEach(func() {
+			Eventually(created).Should(HaveLen(1))
+
+			err := dynamicClient.Resource(deployments).Namespace("test-namespace").Delete(context.Background(),
+				"test-deployment1", metav1.DeleteOptions{})
+			Expect(err).To(BeNil())
+		})
+
+		It("removes the resource when it is watched", func() {
+			Eventually(fakeSQLClient.DeleteKubernetesResourceCallCount).Should(Equal(1))
+			Expect(fakeSQLClient.DeleteKubernetesResourceArgsForCall(0)).To(Equal(fakeSQLClient.CreateKubernetesResourceArgsForCall(0).ID))
+			Consistently(created).Should(HaveLen(1))
+		})
+	})
+
+	When("a task recorded an entry for a resource that is not found", func() {
+		BeforeEach(func() {
+			recorded = []kubernetes.Resource{
+				{
+					ID:          "test-task-entry",
+					AccountName: "test-account",
+					Resource:    "deployments",
+					Namespace:   "test-namespace",
+					Name:        "test-deployment4",
+					TaskType:    clouddriver.TaskTypeDelete,
+				},
+			}
+		})
+
+		It("keeps the entry", func() {
+			Eventually(created).Should(Equal([]string{"test-deployment1"}))
+			Consistently(fakeSQLClient.DeleteKubernetesResourceCallCount).Should(Equal(0))
+		})
+	})
+
+	When("another replica holds the reconciler lease", func() {
+		BeforeEach(func() {
+			fakeSQLClient.AcquireLeaseReturns(false, nil)
+		})
+
+		It("does not watch any account", func() {
+			Consistently(fakeKubeClient.NewInformerCallCount).Should(Equal(0))
+			name, owner, _ := fakeSQLClient.AcquireLeaseArgsForCall(0)
+			Expect(name).To(Equal("resource-reconciler"))
+			Expect(owner).ToNot(BeEmpty())
+		})
+	})
+
+	When("acquiring the reconciler lease returns an error", func() {
+		BeforeEach(func() {
+			fakeSQLClient.AcquireLeaseReturns(false, errors.New("error acquiring lease"))
+		})
+
+		It("does not watch any account", func() {
+			Consistently(fakeKubeClient.NewInformerCallCount).Should(Equal(0))
+		})
+	})
+
+	When("listing the providers returns an error", func() {
+		BeforeEach(func() {
+			fakeSQLClient.ListKubernetesProvidersReturns(nil, errors.New("error listing providers"))
+		})
+
+		It("does not watch any account", func() {
+			Consistently(fakeKubeClient.NewInformerCallCount).Should(Equal(0))
+		})
+	})
+})
@@ -3,11 +3,9 @@ package v1
 import (
 	"context"
 	"net/http"
-	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 	"gorm.io/gorm"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -29,10 +27,12 @@ var (
 	}
 )
 
-// LoadKubernetesResources populates the Kubernetes resources table
-// by querying the cluster for resources deployed by Spinnaker.
+// LoadKubernetesResources reconciles the Kubernetes resources table
+// with the resources deployed by Spinnaker in the cluster, adding, updating
+// and removing entries as described in ReconcileKubernetesResources. It responds
+// with the entries added for resources that were missing from the table.
 //
-// The only resources populated are Kubernetes kinds that show in
+// The only resources reconciled are Kubernetes kinds that show in
 // the infrastructure pages (clusters, load balancers, firewalls).
 //   - daemonSets
 //   - deployments
@@ -40,6 +40,9 @@ var (
 //   - replicaSets
 //   - statefulSets
 //   - services
+//
+// The ResourceReconciler keeps the table reconciled in the background,
+// so this is only needed to force a reconcile of an account.
 func (cc *Controller) LoadKubernetesResources(c *gin.Context) {
 	account := c.Param("name")
 
@@ -63,68 +66,51 @@ func (cc *Controller) LoadKubernetesResources(c *gin.Context) {
 		return
 	}
 
-	// Declare a waitgroup to wait on concurrent resource listing.
-	wg := &sync.WaitGroup{}
-	// Add the number of kinds we will be listing concurrently.
-	wg.Add(len(infrastructureKinds))
-	// Create channel of unstructured objects (manifests) to send to.
-	uc := make(chan unstructured.Unstructured, internal.DefaultChanSize)
+	// Entries recorded after this point may be for resources
+	// the listing missed, so they are never removed.
+	since := internal.CurrentTimeUTC()
+	// Create channel of listed kinds to send to.
+	kc := make(chan listedKind, len(infrastructureKinds))
 	// List all required kinds concurrently.
 	for _, kind := range infrastructureKinds {
-		go listKinds(wg, uc, provider, kind)
+		go func(kind string) {
+			items, err := listKind(provider, kind)
+			kc <- listedKind{kind: kind, items: items, err: err}
+		}(kind)
 	}
 
-	go func() {
-		wg.Wait()
-		close(uc)
-	}()
-
-	// Remove existing entries from the DB.
-	err = cc.SQLClient.DeleteKubernetesResourcesByAccountName(account)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
+	live := []kubernetes.Resource{}
+	resources := map[string]bool{}
 
-	// Use the same task ID for all entries.
-	taskID := uuid.New().String()
-	resources := []kubernetes.Resource{}
+	for range infrastructureKinds {
+		lk := <-kc
+		// Leave the entries of a kind that failed to list untouched
+		// rather than removing them as if the resources were deleted.
+		if lk.err != nil {
+			clouddriver.Log(lk.err)
+			continue
+		}
 
-	// Create a resource entry in the DB for each unstructured object (manifest).
-	for u := range uc {
-		gvr, err := provider.Client.GVRForKind(u.GetKind())
+		gvr, err := provider.Client.GVRForKind(lk.kind)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
-		// Map values to kubernetes.Resource.
-		nameWithoutVersion := kubernetes.NameWithoutVersion(u.GetName())
-		kr := kubernetes.Resource{
-			AccountName:  account,
-			ID:           uuid.New().String(),
-			TaskID:       taskID,
-			Timestamp:    internal.CurrentTimeUTC(),
-			APIGroup:     gvr.Group,
-			Name:         u.GetName(),
-			ArtifactName: nameWithoutVersion,
-			Namespace:    u.GetNamespace(),
-			Resource:     gvr.Resource,
-			Version:      gvr.Version,
-			Kind:         u.GetKind(),
-			SpinnakerApp: kubernetes.SpinnakerMonikerApplication(u),
-			Cluster:      kubernetes.Cluster(u.GetKind(), nameWithoutVersion),
-		}
-		// Insert row into kubernetes_resources table.
-		err = cc.SQLClient.CreateKubernetesResource(kr)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-			return
+
+		resources[gvr.Resource] = true
+
+		for _, u := range lk.items {
+			live = append(live, kubernetes.NewResource(account, gvr, u))
 		}
+	}
 
-		resources = append(resources, kr)
+	added, err := cc.reconcileResources(provider, live, resources, since)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
 	}
 
-	c.JSON(http.StatusOK, resources)
+	c.JSON(http.StatusOK, added)
 }
 
 // DeleteKubernetesResources deletes the resources from the database
@@ -153,13 +139,18 @@ func (cc *Controller) DeleteKubernetesResources(c *gin.Context) {
 	c.JSON(http.StatusNoContent, nil)
 }
 
-// listKinds lists a given kind and sends to a channel of unstructured.Unstructured.
-// It uses a context with a timeout of 10 seconds.
-func listKinds(wg *sync.WaitGroup, uc chan unstructured.Unstructured,
-	provider *kubernetes.Provider, kind string) {
-	// Finish the wait group when we're done here.
-	defer wg.Done()
+// listedKind holds the Spinnaker-managed objects of a kind,
+// or the error listing them.
+type listedKind struct {
+	kind  string
+	items []unstructured.Unstructured
+	err   error
+}
 
+// listKind lists the Spinnaker-managed objects of a kind in each namespace
+// of the provider, or in all namespaces if the provider is not namespace-scoped.
+// It uses a context with a timeout of 10 seconds.
+func listKind(provider *kubernetes.Provider, kind string) ([]unstructured.Unstructured, error) {
 	// Declare server side filtering options.
 	lo := metav1.ListOptions{
 		LabelSelector: kubernetes.DefaultLabelSelector(),
@@ -174,8 +165,7 @@ func listKinds(wg *sync.WaitGroup, uc chan unstructured.Unstructured,
 	if len(provider.Namespaces) == 0 {
 		ul, err := provider.Client.ListResourceWithContext(ctx, kind, lo)
 		if err != nil {
-			clouddriver.Log(err)
-			return
+			return nil, err
 		}
 
 		items = append(items, ul.Items...)
@@ -184,15 +174,11 @@ func listKinds(wg *sync.WaitGroup, uc chan unstructured.Unstructured,
 	for _, ns := range provider.Namespaces {
 		ul, err := provider.Client.ListResourcesByKindAndNamespaceWithContext(ctx, kind, ns, lo)
 		if err != nil {
-			clouddriver.Log(err)
-			return
+			return nil, err
 		}
 
 		items = append(items, ul.Items...)
 	}
 
-	// Send all unstructured objects to the channel.
-	for _, u := range items {
-		uc <- u
-	}
+	return items, nil
 }
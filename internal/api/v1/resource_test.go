@@ -1,15 +1,19 @@
 package v1_test
 
 import (
+	"context"
 	"errors"
 	"io"
 	"log"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/homedepot/go-clouddriver/internal/kubernetes"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"gorm.io/gorm"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
@@ -18,7 +22,6 @@ var _ = Describe("Resource", func() {
 	Describe("#LoadKubernetesResources", func() {
 		BeforeEach(func() {
 			setup()
-			fakeSQLClient.DeleteKubernetesResourcesByAccountNameReturns(nil)
 			fakeKubeClient.ListResourceWithContextReturns(&unstructured.UnstructuredList{}, nil)
 			log.SetOutput(io.Discard)
 
@@ -65,9 +68,9 @@ var _ = Describe("Resource", func() {
 			})
 		})
 
-		When("deleting kubernetes resources errors", func() {
+		When("listing the recorded resources errors", func() {
 			BeforeEach(func() {
-				fakeSQLClient.DeleteKubernetesResourcesByAccountNameReturns(errors.New("error deleting resources"))
+				fakeSQLClient.ListKubernetesResourcesByAccountNameReturns(nil, errors.New("error listing resources"))
 			})
 
 			It("returns internal server error", func() {
@@ -80,8 +83,10 @@ var _ = Describe("Resource", func() {
 				fakeKubeClient.ListResourceWithContextReturns(&unstructured.UnstructuredList{}, nil)
 			})
 
-			It("deletes existing resources and returns status OK", func() {
-				Expect(fakeSQLClient.DeleteKubernetesResourcesByAccountNameCallCount()).To(Equal(1))
+			It("does not change the recorded resources and returns status OK", func() {
+				Expect(fakeSQLClient.ListKubernetesResourcesByAccountNameCallCount()).To(Equal(1))
+				Expect(fakeSQLClient.CreateKubernetesResourceCallCount()).To(Equal(0))
+				Expect(fakeSQLClient.DeleteKubernetesResourceCallCount()).To(Equal(0))
 				Expect(res.StatusCode).To(Equal(http.StatusOK))
 			})
 		})
@@ -128,7 +133,7 @@ var _ = Describe("Resource", func() {
 			It("returns status OK", func() {
 				Expect(res.StatusCode).To(Equal(http.StatusOK))
 				Expect(fakeKubeClient.ListResourceWithContextCallCount()).To(Equal(6))
-				Expect(fakeSQLClient.DeleteKubernetesResourcesByAccountNameCallCount()).To(Equal(1))
+				Expect(fakeSQLClient.ListKubernetesResourcesByAccountNameCallCount()).To(Equal(1))
 				Expect(fakeSQLClient.CreateKubernetesResourceCallCount()).To(Equal(1))
 
 				kr := fakeSQLClient.CreateKubernetesResourceArgsForCall(0)
@@ -163,7 +168,7 @@ var _ = Describe("Resource", func() {
 			It("returns status OK", func() {
 				Expect(res.StatusCode).To(Equal(http.StatusOK))
 				Expect(fakeKubeClient.ListResourceWithContextCallCount()).To(Equal(6))
-				Expect(fakeSQLClient.DeleteKubernetesResourcesByAccountNameCallCount()).To(Equal(1))
+				Expect(fakeSQLClient.ListKubernetesResourcesByAccountNameCallCount()).To(Equal(1))
 				Expect(fakeSQLClient.CreateKubernetesResourceCallCount()).To(Equal(2))
 
 				kr := fakeSQLClient.CreateKubernetesResourceArgsForCall(0)
@@ -214,7 +219,7 @@ var _ = Describe("Resource", func() {
 			It("returns status OK", func() {
 				Expect(res.StatusCode).To(Equal(http.StatusOK))
 				Expect(fakeKubeClient.ListResourceWithContextCallCount()).To(Equal(6))
-				Expect(fakeSQLClient.DeleteKubernetesResourcesByAccountNameCallCount()).To(Equal(1))
+				Expect(fakeSQLClient.ListKubernetesResourcesByAccountNameCallCount()).To(Equal(1))
 				Expect(fakeSQLClient.CreateKubernetesResourceCallCount()).To(Equal(1))
 
 				kr := fakeSQLClient.CreateKubernetesResourceArgsForCall(0)
@@ -249,7 +254,7 @@ var _ = Describe("Resource", func() {
 			It("returns status OK and NameWithoutVersion is called", func() {
 				Expect(res.StatusCode).To(Equal(http.StatusOK))
 				Expect(fakeKubeClient.ListResourceWithContextCallCount()).To(Equal(6))
-				Expect(fakeSQLClient.DeleteKubernetesResourcesByAccountNameCallCount()).To(Equal(1))
+				Expect(fakeSQLClient.ListKubernetesResourcesByAccountNameCallCount()).To(Equal(1))
 				Expect(fakeSQLClient.CreateKubernetesResourceCallCount()).To(Equal(1))
 
 				kr := fakeSQLClient.CreateKubernetesResourceArgsForCall(0)
@@ -284,7 +289,7 @@ var _ = Describe("Resource", func() {
 			It("returns status OK", func() {
 				Expect(res.StatusCode).To(Equal(http.StatusOK))
 				Expect(fakeKubeClient.ListResourceWithContextCallCount()).To(Equal(6))
-				Expect(fakeSQLClient.DeleteKubernetesResourcesByAccountNameCallCount()).To(Equal(1))
+				Expect(fakeSQLClient.ListKubernetesResourcesByAccountNameCallCount()).To(Equal(1))
 				Expect(fakeSQLClient.CreateKubernetesResourceCallCount()).To(Equal(1))
 
 				kr := fakeSQLClient.CreateKubernetesResourceArgsForCall(0)
@@ -319,7 +324,7 @@ var _ = Describe("Resource", func() {
 			It("returns status OK", func() {
 				Expect(res.StatusCode).To(Equal(http.StatusOK))
 				Expect(fakeKubeClient.ListResourceWithContextCallCount()).To(Equal(6))
-				Expect(fakeSQLClient.DeleteKubernetesResourcesByAccountNameCallCount()).To(Equal(1))
+				Expect(fakeSQLClient.ListKubernetesResourcesByAccountNameCallCount()).To(Equal(1))
 				Expect(fakeSQLClient.CreateKubernetesResourceCallCount()).To(Equal(1))
 
 				kr := fakeSQLClient.CreateKubernetesResourceArgsForCall(0)
@@ -340,6 +345,57 @@ var _ = Describe("Resource", func() {
 			})
 		})
 
+		When("the recorded resources have drifted from the cluster", func() {
+			BeforeEach(func() {
+				fakeKubeClient.ListResourceWithContextStub = func(_ context.Context, kind string,
+					_ metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+					switch kind {
+					case "deployments":
+						return fakeDeployments, nil
+					case "services":
+						return nil, errors.New("error listing services")
+					}
+
+					return &unstructured.UnstructuredList{}, nil
+				}
+				fakeKubeClient.GVRForKindStub = func(kind string) (schema.GroupVersionResource, error) {
+					return schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: strings.ToLower(kind)}, nil
+				}
+				before := time.Now().Add(-time.Hour)
+				fakeSQLClient.ListKubernetesResourcesByAccountNameReturns([]kubernetes.Resource{
+					// Up to date.
+					{ID: "id-1", Resource: "deployments", Namespace: "test-namespace1", Name: "test-deployment1",
+						SpinnakerApp: "test-application1", Cluster: "deployment test-deployment1", Timestamp: before},
+					// Moved to another application.
+					{ID: "id-2", Resource: "deployments", Namespace: "test-namespace2", Name: "test-deployment2",
+						SpinnakerApp: "old-application", Cluster: "deployment test-deployment2", Timestamp: before},
+					// Deleted outside of Spinnaker.
+					{ID: "id-3", Resource: "deployments", Namespace: "test-namespace1", Name: "test-deployment3",
+						SpinnakerApp: "test-application1", Cluster: "deployment test-deployment3", Timestamp: before},
+					// Recorded while reconciling.
+					{ID: "id-4", Resource: "deployments", Namespace: "test-namespace1", Name: "test-deployment4",
+						SpinnakerApp: "test-application1", Cluster: "deployment test-deployment4", Timestamp: time.Now().Add(time.Hour)},
+					// Failed to list.
+					{ID: "id-5", Resource: "services", Namespace: "test-namespace1", Name: "test-service",
+						SpinnakerApp: "test-application1", Cluster: "service test-service", Timestamp: before},
+					// Not an infrastructure kind.
+					{ID: "id-6", Resource: "configmaps", Namespace: "test-namespace1", Name: "test-config-map",
+						SpinnakerApp: "test-application1", Timestamp: before},
+				}, nil)
+			})
+
+			It("repairs the drifted resources", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				Expect(fakeSQLClient.CreateKubernetesResourceCallCount()).To(Equal(0))
+				Expect(fakeSQLClient.UpdateKubernetesResourceCallCount()).To(Equal(1))
+				kr := fakeSQLClient.UpdateKubernetesResourceArgsForCall(0)
+				Expect(kr.ID).To(Equal("id-2"))
+				Expect(kr.SpinnakerApp).To(Equal("test-application2"))
+				Expect(fakeSQLClient.DeleteKubernetesResourceCallCount()).To(Equal(1))
+				Expect(fakeSQLClient.DeleteKubernetesResourceArgsForCall(0)).To(Equal("id-3"))
+			})
+		})
+
 		When("All list resources call return resources", func() {
 			BeforeEach(func() {
 				fakeKubeClient.ListResourceWithContextReturnsOnCall(0, fakeDaemonSets, nil)
@@ -358,7 +414,7 @@ var _ = Describe("Resource", func() {
 			It("returns status OK and creates all resource rows", func() {
 				Expect(res.StatusCode).To(Equal(http.StatusOK))
 				Expect(fakeKubeClient.ListResourceWithContextCallCount()).To(Equal(6))
-				Expect(fakeSQLClient.DeleteKubernetesResourcesByAccountNameCallCount()).To(Equal(1))
+				Expect(fakeSQLClient.ListKubernetesResourcesByAccountNameCallCount()).To(Equal(1))
 				Expect(fakeSQLClient.CreateKubernetesResourceCallCount()).To(Equal(7))
 			})
 		})
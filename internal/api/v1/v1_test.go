@@ -30,6 +30,7 @@ var (
 	fakeArcadeClient   *arcadefakes.FakeClient
 	fakeKubeClient     *kubernetesfakes.FakeClient
 	fakeKubeController *kubernetesfakes.FakeController
+	fakeResourceCache  *kubernetesfakes.FakeResourceCache
 	fakeDaemonSets     *unstructured.UnstructuredList
 	fakeDeployments    *unstructured.UnstructuredList
 	fakeIngresses      *unstructured.UnstructuredList
@@ -44,6 +45,7 @@ func setup() {
 	fakeSQLClient = &sqlfakes.FakeClient{}
 	fakeArcadeClient = &arcadefakes.FakeClient{}
 	fakeKubeClient = &kubernetesfakes.FakeClient{}
+	fakeResourceCache = &kubernetesfakes.FakeResourceCache{}
 
 	fakeSQLClient.GetKubernetesProviderReturns(kubernetes.Provider{
 		Name:   "test-account",
@@ -63,9 +65,10 @@ func setup() {
 	r.Use(gin.Recovery())
 
 	c := &internal.Controller{
-		SQLClient:            fakeSQLClient,
-		ArcadeClient:         fakeArcadeClient,
-		KubernetesController: fakeKubeController,
+		SQLClient:               fakeSQLClient,
+		ArcadeClient:            fakeArcadeClient,
+		KubernetesController:    fakeKubeController,
+		KubernetesResourceCache: fakeResourceCache,
 	}
 	// Create server.
 	server := api.NewServer(r)
@@ -25,10 +25,21 @@ const (
 type Controller struct {
 	ArcadeClient                  arcade.Client
 	ArtifactCredentialsController artifact.CredentialsController
-	FiatClient                    fiat.Client
-	Front50Client                 front50.Client
-	KubernetesController          kubernetes.Controller
-	SQLClient                     sql.Client
+	// CredentialPolicy holds the exec credential plugins and
+	// kubeconfig files providers are allowed to use.
+	CredentialPolicy kubernetes.CredentialPolicy
+	FiatClient       fiat.Client
+	Front50Client    front50.Client
+	// KubernetesAccountHealth is optional. When set, it holds the
+	// last health check of each account.
+	KubernetesAccountHealth kubernetes.AccountHealthStore
+	KubernetesController    kubernetes.Controller
+	// KubernetesResourceCache is optional. When set, read endpoints
+	// list resources from it instead of the API servers once it has synced.
+	KubernetesResourceCache kubernetes.ResourceCache
+	// PreconfiguredJobs are the run job templates registered by operators.
+	PreconfiguredJobs kubernetes.PreconfiguredJobs
+	SQLClient         sql.Client
 }
 
 // KubernetesProvider returns a kubernetes provider instance
@@ -49,29 +60,9 @@ func (cc *Controller) KubernetesProviderWithTimeout(account string,
 		return nil, fmt.Errorf("internal: error getting kubernetes provider %s: %v", account, err)
 	}
 
-	// Decode the provider's CA data.
-	cd, err := base64.StdEncoding.DecodeString(provider.CAData)
+	config, err := cc.KubernetesRESTConfig(provider, timeout)
 	if err != nil {
-		return nil, fmt.Errorf("internal: error decoding provider CA data: %v", err)
-	}
-
-	// Grab the auth token from arcade.
-	token, err := cc.ArcadeClient.Token(provider.TokenProvider)
-	if err != nil {
-		return nil, fmt.Errorf("internal: error getting token from arcade for provider %s: %v",
-			provider.TokenProvider, err)
-	}
-
-	config := &rest.Config{
-		Host:        provider.Host,
-		BearerToken: token,
-		TLSClientConfig: rest.TLSClientConfig{
-			CAData: cd,
-		},
-	}
-
-	if timeout > 0 {
-		config.Timeout = timeout
+		return nil, fmt.Errorf("internal: %v", err)
 	}
 
 	client, err := cc.KubernetesController.NewClient(config)
@@ -115,35 +106,13 @@ func (cc *Controller) KubernetesProvidersForAccountsWithTimeout(accounts []strin
 			continue
 		}
 
-		// Decode the provider's CA data.
-		cd, err := base64.StdEncoding.DecodeString(provider.CAData)
+		config, err := cc.KubernetesRESTConfig(provider, timeout)
 		if err != nil {
-			clouddriver.Log(fmt.Errorf("internal: error decoding provider CA data: %v", err))
+			clouddriver.Log(fmt.Errorf("internal: %v", err))
 
 			continue
 		}
 
-		// Grab the auth token from arcade.
-		token, err := cc.ArcadeClient.Token(provider.TokenProvider)
-		if err != nil {
-			clouddriver.Log(fmt.Errorf("internal: error getting token from arcade for provider %s: %v",
-				provider.TokenProvider, err))
-
-			continue
-		}
-
-		config := &rest.Config{
-			Host:        provider.Host,
-			BearerToken: token,
-			TLSClientConfig: rest.TLSClientConfig{
-				CAData: cd,
-			},
-		}
-
-		if timeout > 0 {
-			config.Timeout = timeout
-		}
-
 		client, err := cc.KubernetesController.NewClient(config)
 		if err != nil {
 			clouddriver.Log(fmt.Errorf("internal: error creating new kubernetes client: %v", err))
@@ -180,35 +149,13 @@ func (cc *Controller) AllKubernetesProvidersWithTimeout(timeout time.Duration) (
 
 	for _, provider := range providers {
 		provider := provider
-		// Decode the provider's CA data.
-		cd, err := base64.StdEncoding.DecodeString(provider.CAData)
-		if err != nil {
-			clouddriver.Log(fmt.Errorf("internal: error decoding provider CA data: %v", err))
-
-			continue
-		}
-
-		// Grab the auth token from arcade.
-		token, err := cc.ArcadeClient.Token(provider.TokenProvider)
+		config, err := cc.KubernetesRESTConfig(provider, timeout)
 		if err != nil {
-			clouddriver.Log(fmt.Errorf("internal: error getting token from arcade for provider %s: %v",
-				provider.TokenProvider, err))
+			clouddriver.Log(fmt.Errorf("internal: %v", err))
 
 			continue
 		}
 
-		config := &rest.Config{
-			Host:        provider.Host,
-			BearerToken: token,
-			TLSClientConfig: rest.TLSClientConfig{
-				CAData: cd,
-			},
-		}
-
-		if timeout > 0 {
-			config.Timeout = timeout
-		}
-
 		client, err := cc.KubernetesController.NewClient(config)
 		if err != nil {
 			clouddriver.Log(fmt.Errorf("internal: error creating new kubernetes client: %v", err))
@@ -231,3 +178,41 @@ func (cc *Controller) AllKubernetesProvidersWithTimeout(timeout time.Duration) (
 
 	return ps, nil
 }
+
+// KubernetesRESTConfig returns the config of a client of a provider's cluster,
+// authenticated with the credentials of the source selected by the provider's
+// token provider. If no timeout is passed the config's timeout is not set.
+func (cc *Controller) KubernetesRESTConfig(provider kubernetes.Provider,
+	timeout time.Duration) (*rest.Config, error) {
+	// Decode the provider's CA data.
+	cd, err := base64.StdEncoding.DecodeString(provider.CAData)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding provider CA data: %v", err)
+	}
+
+	config := &rest.Config{
+		Host: provider.Host,
+		TLSClientConfig: rest.TLSClientConfig{
+			CAData: cd,
+		},
+	}
+
+	if timeout > 0 {
+		config.Timeout = timeout
+	}
+
+	err = kubernetes.NewCredentialSource(provider.TokenProvider, cc.ArcadeClient, cc.CredentialPolicy).Configure(provider, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// ForgetKubernetesResourceCache stops watching the resources of an account,
+// for example once its provider has been deleted or replaced.
+func (cc *Controller) ForgetKubernetesResourceCache(account string) {
+	if cc.KubernetesResourceCache != nil {
+		cc.KubernetesResourceCache.Forget(account)
+	}
+}
@@ -132,6 +132,39 @@ var _ = Describe("Controller", func() {
 			})
 		})
 
+		When("the provider uses its own bearer token", func() {
+			BeforeEach(func() {
+				fakeSQLClient.GetKubernetesProviderReturns(kubernetes.Provider{
+					Name:          "test-name",
+					CAData:        "12341234",
+					BearerToken:   "test-bearer-token",
+					TokenProvider: kubernetes.TokenProviderBearerToken,
+				}, nil)
+			})
+
+			It("does not get a token from arcade", func() {
+				Expect(err).To(BeNil())
+				Expect(fakeArcadeClient.TokenCallCount()).To(Equal(0))
+				config := fakeKubernetesController.NewClientArgsForCall(0)
+				Expect(config.BearerToken).To(Equal("test-bearer-token"))
+			})
+		})
+
+		When("the provider's credentials are missing", func() {
+			BeforeEach(func() {
+				fakeSQLClient.GetKubernetesProviderReturns(kubernetes.Provider{
+					Name:          "test-name",
+					CAData:        "12341234",
+					TokenProvider: kubernetes.TokenProviderClientCertificate,
+				}, nil)
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("internal: client certificate and key data required for token provider clientCertificate"))
+			})
+		})
+
 		When("generating a new client returns an error", func() {
 			BeforeEach(func() {
 				fakeKubernetesController.NewClientReturns(nil, errors.New("error generating client"))
@@ -14,6 +14,7 @@ const (
 //go:generate counterfeiter . Client
 type Client interface {
 	Project(project string) (Response, error)
+	Projects() ([]Response, error)
 }
 
 func NewClient(url string) Client {
@@ -91,3 +92,37 @@ func (c *client) Project(project string) (Response, error) {
 
 	return response, nil
 }
+
+// Projects gets all Spinnaker projects from the front50 service.
+//
+// See https://github.com/spinnaker/front50/blob/master/front50-web/src/main/java/com/netflix/spinnaker/front50/controllers/v2/ProjectsController.java
+func (c *client) Projects() ([]Response, error) {
+	req, err := http.NewRequest(http.MethodGet, c.url+"/v2/projects", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 399 {
+		return nil, fmt.Errorf("user authorization error: %s", res.Status)
+	}
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	response := []Response{}
+
+	err = json.Unmarshal(b, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
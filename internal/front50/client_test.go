@@ -145,4 +145,75 @@ var _ = Describe("Client", func() {
 			})
 		})
 	})
+
+	Describe("#Projects", func() {
+		var responses []Response
+
+		JustBeforeEach(func() {
+			responses, err = client.Projects()
+		})
+
+		When("the uri is invalid", func() {
+			BeforeEach(func() {
+				client = NewClient("::haha")
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(responses).To(BeNil())
+			})
+		})
+
+		When("the response is not 2XX", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusInternalServerError, nil),
+				)
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("user authorization error: 500 Internal Server Error"))
+			})
+		})
+
+		When("the server returns bad data", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusOK, ";{["),
+				)
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("invalid character ';' looking for beginning of value"))
+			})
+		})
+
+		When("it succeeds", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/v2/projects"),
+					ghttp.RespondWith(http.StatusOK, `[
+						{
+							"id": "048de9a7-7b57-4097-8444-e44682d9dcfc",
+							"name": "spinnaker",
+							"config": {
+								"applications": [
+									"smoketests"
+								]
+							}
+						}
+					]`),
+				))
+			})
+
+			It("succeeds", func() {
+				Expect(err).To(BeNil())
+				Expect(responses).To(HaveLen(1))
+				Expect(responses[0].Name).To(Equal("spinnaker"))
+				Expect(responses[0].Config.Applications).To(Equal([]string{"smoketests"}))
+			})
+		})
+	})
 })
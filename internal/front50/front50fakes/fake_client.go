@@ -21,6 +21,18 @@ type FakeClient struct {
 		result1 front50.Response
 		result2 error
 	}
+	ProjectsStub        func() ([]front50.Response, error)
+	projectsMutex       sync.RWMutex
+	projectsArgsForCall []struct {
+	}
+	projectsReturns struct {
+		result1 []front50.Response
+		result2 error
+	}
+	projectsReturnsOnCall map[int]struct {
+		result1 []front50.Response
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -89,11 +101,69 @@ func (fake *FakeClient) ProjectReturnsOnCall(i int, result1 front50.Response, re
 	}{result1, result2}
 }
 
+func (fake *FakeClient) Projects() ([]front50.Response, error) {
+	fake.projectsMutex.Lock()
+	ret, specificReturn := fake.projectsReturnsOnCall[len(fake.projectsArgsForCall)]
+	fake.projectsArgsForCall = append(fake.projectsArgsForCall, struct {
+	}{})
+	stub := fake.ProjectsStub
+	fakeReturns := fake.projectsReturns
+	fake.recordInvocation("Projects", []interface{}{})
+	fake.projectsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) ProjectsCallCount() int {
+	fake.projectsMutex.RLock()
+	defer fake.projectsMutex.RUnlock()
+	return len(fake.projectsArgsForCall)
+}
+
+func (fake *FakeClient) ProjectsCalls(stub func() ([]front50.Response, error)) {
+	fake.projectsMutex.Lock()
+	defer fake.projectsMutex.Unlock()
+	fake.ProjectsStub = stub
+}
+
+func (fake *FakeClient) ProjectsReturns(result1 []front50.Response, result2 error) {
+	fake.projectsMutex.Lock()
+	defer fake.projectsMutex.Unlock()
+	fake.ProjectsStub = nil
+	fake.projectsReturns = struct {
+		result1 []front50.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ProjectsReturnsOnCall(i int, result1 []front50.Response, result2 error) {
+	fake.projectsMutex.Lock()
+	defer fake.projectsMutex.Unlock()
+	fake.ProjectsStub = nil
+	if fake.projectsReturnsOnCall == nil {
+		fake.projectsReturnsOnCall = make(map[int]struct {
+			result1 []front50.Response
+			result2 error
+		})
+	}
+	fake.projectsReturnsOnCall[i] = struct {
+		result1 []front50.Response
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.projectMutex.RLock()
 	defer fake.projectMutex.RUnlock()
+	fake.projectsMutex.RLock()
+	defer fake.projectsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,131 @@
+package internal
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/homedepot/go-clouddriver/internal/kubernetes"
+	clouddriver "github.com/homedepot/go-clouddriver/pkg"
+)
+
+var (
+	accountReachable = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "clouddriver",
+		Name:      "kubernetes_account_reachable",
+		Help:      "Whether the API server of an account's cluster responded to the last health check (1) or not (0).",
+	}, []string{"account"})
+	accountTokenValid = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "clouddriver",
+		Name:      "kubernetes_account_token_valid",
+		Help:      "Whether the token of an account was retrieved and accepted on the last health check (1) or not (0).",
+	}, []string{"account"})
+	accountLastSuccessfulDiscovery = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "clouddriver",
+		Name:      "kubernetes_account_last_successful_discovery_timestamp_seconds",
+		Help:      "Unix time the API of an account's cluster was last discovered successfully.",
+	}, []string{"account"})
+)
+
+// CheckKubernetesAccountHealth checks that the cluster of a provider is
+// reachable with the provider's credentials, then discovers the cluster's API.
+func (cc *Controller) CheckKubernetesAccountHealth(provider kubernetes.Provider) clouddriver.AccountHealth {
+	h := clouddriver.AccountHealth{
+		Account:     provider.Name,
+		LastChecked: CurrentTimeUTC(),
+	}
+
+	config, err := cc.KubernetesRESTConfig(provider, time.Second*DefaultListTimeoutSeconds)
+	if err != nil {
+		h.Error = err.Error()
+		return h
+	}
+
+	h.TokenValid = true
+
+	clientset, err := cc.KubernetesController.NewClientset(config)
+	if err != nil {
+		h.Error = fmt.Sprintf("error creating new kubernetes clientset: %v", err)
+		return h
+	}
+
+	version, err := clientset.ServerVersion()
+	if err != nil {
+		// An API server that rejects the token is still reachable.
+		if errors.IsUnauthorized(err) {
+			h.Reachable = true
+			h.TokenValid = false
+		}
+
+		h.Error = fmt.Sprintf("error getting server version: %v", err)
+
+		return h
+	}
+
+	h.Reachable = true
+	h.ServerVersion = version
+
+	client, err := cc.KubernetesController.NewClient(config)
+	if err != nil {
+		h.Error = fmt.Sprintf("error creating new kubernetes client: %v", err)
+		return h
+	}
+
+	err = client.Discover()
+	if err != nil {
+		h.Error = err.Error()
+		return h
+	}
+
+	discovered := h.LastChecked
+	h.LastSuccessfulDiscovery = &discovered
+
+	return h
+}
+
+// RecordKubernetesAccountHealth checks the health of a provider's account,
+// recording the result in the account health store, when set, and as metrics.
+func (cc *Controller) RecordKubernetesAccountHealth(provider kubernetes.Provider) clouddriver.AccountHealth {
+	h := cc.CheckKubernetesAccountHealth(provider)
+
+	if cc.KubernetesAccountHealth != nil {
+		// Keep when the API was last discovered if this check failed.
+		if last, ok := cc.KubernetesAccountHealth.Get(provider.Name); ok && h.LastSuccessfulDiscovery == nil {
+			h.LastSuccessfulDiscovery = last.LastSuccessfulDiscovery
+		}
+
+		cc.KubernetesAccountHealth.Set(h)
+	}
+
+	accountReachable.WithLabelValues(provider.Name).Set(boolToFloat(h.Reachable))
+	accountTokenValid.WithLabelValues(provider.Name).Set(boolToFloat(h.TokenValid))
+
+	if h.LastSuccessfulDiscovery != nil {
+		accountLastSuccessfulDiscovery.WithLabelValues(provider.Name).Set(float64(h.LastSuccessfulDiscovery.Unix()))
+	}
+
+	return h
+}
+
+// ForgetKubernetesAccountHealth removes the recorded health of an account,
+// for example once its provider has been deleted.
+func (cc *Controller) ForgetKubernetesAccountHealth(account string) {
+	if cc.KubernetesAccountHealth != nil {
+		cc.KubernetesAccountHealth.Delete(account)
+	}
+
+	accountReachable.DeleteLabelValues(account)
+	accountTokenValid.DeleteLabelValues(account)
+	accountLastSuccessfulDiscovery.DeleteLabelValues(account)
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
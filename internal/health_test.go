@@ -0,0 +1,184 @@
+package internal_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/homedepot/arcade/pkg/arcadefakes"
+	"github.com/homedepot/go-clouddriver/internal"
+	"github.com/homedepot/go-clouddriver/internal/kubernetes"
+	"github.com/homedepot/go-clouddriver/internal/kubernetes/kubernetesfakes"
+	clouddriver "github.com/homedepot/go-clouddriver/pkg"
+)
+
+var _ = Describe("Health", func() {
+	var (
+		c                        *internal.Controller
+		fakeArcadeClient         *arcadefakes.FakeClient
+		fakeKubernetesController *kubernetesfakes.FakeController
+		fakeKubernetesClient     *kubernetesfakes.FakeClient
+		fakeKubernetesClientset  *kubernetesfakes.FakeClientset
+		provider                 kubernetes.Provider
+		health                   clouddriver.AccountHealth
+	)
+
+	BeforeEach(func() {
+		fakeArcadeClient = &arcadefakes.FakeClient{}
+		fakeKubernetesController = &kubernetesfakes.FakeController{}
+		fakeKubernetesClient = &kubernetesfakes.FakeClient{}
+		fakeKubernetesClientset = &kubernetesfakes.FakeClientset{}
+
+		fakeArcadeClient.TokenReturns("test-token", nil)
+		fakeKubernetesClientset.ServerVersionReturns("v1.26.15", nil)
+		fakeKubernetesController.NewClientReturns(fakeKubernetesClient, nil)
+		fakeKubernetesController.NewClientsetReturns(fakeKubernetesClientset, nil)
+
+		provider = kubernetes.Provider{
+			Name:          "test-account",
+			Host:          "test-host",
+			CAData:        "dGVzdAo=",
+			TokenProvider: "test-token-provider",
+		}
+		c = &internal.Controller{
+			ArcadeClient:         fakeArcadeClient,
+			KubernetesController: fakeKubernetesController,
+		}
+	})
+
+	Describe("#CheckKubernetesAccountHealth", func() {
+		JustBeforeEach(func() {
+			health = c.CheckKubernetesAccountHealth(provider)
+		})
+
+		When("decoding the CA data fails", func() {
+			BeforeEach(func() {
+				provider.CAData = "{}"
+			})
+
+			It("reports the account as unreachable", func() {
+				Expect(health.Reachable).To(BeFalse())
+				Expect(health.Error).To(HavePrefix("error decoding provider CA data"))
+			})
+		})
+
+		When("getting the token fails", func() {
+			BeforeEach(func() {
+				fakeArcadeClient.TokenReturns("", errors.New("error getting token"))
+			})
+
+			It("reports the token as invalid", func() {
+				Expect(health.Reachable).To(BeFalse())
+				Expect(health.TokenValid).To(BeFalse())
+				Expect(health.Error).To(Equal("error getting token from arcade for provider test-token-provider: error getting token"))
+				Expect(fakeKubernetesController.NewClientsetCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the API server cannot be reached", func() {
+			BeforeEach(func() {
+				fakeKubernetesClientset.ServerVersionReturns("", errors.New("connection refused"))
+			})
+
+			It("reports the account as unreachable", func() {
+				Expect(health.Reachable).To(BeFalse())
+				Expect(health.TokenValid).To(BeTrue())
+				Expect(health.Error).To(Equal("error getting server version: connection refused"))
+			})
+		})
+
+		When("the API server rejects the token", func() {
+			BeforeEach(func() {
+				fakeKubernetesClientset.ServerVersionReturns("", k8serrors.NewUnauthorized("Unauthorized"))
+			})
+
+			It("reports the token as invalid", func() {
+				Expect(health.Reachable).To(BeTrue())
+				Expect(health.TokenValid).To(BeFalse())
+			})
+		})
+
+		When("discovering the API fails", func() {
+			BeforeEach(func() {
+				fakeKubernetesClient.DiscoverReturns(errors.New("error discovering API"))
+			})
+
+			It("reports the account as reachable without a discovery", func() {
+				Expect(health.Reachable).To(BeTrue())
+				Expect(health.ServerVersion).To(Equal("v1.26.15"))
+				Expect(health.LastSuccessfulDiscovery).To(BeNil())
+				Expect(health.Error).To(Equal("error discovering API"))
+			})
+		})
+
+		When("it succeeds", func() {
+			It("reports the account as healthy", func() {
+				Expect(health.Account).To(Equal("test-account"))
+				Expect(health.Reachable).To(BeTrue())
+				Expect(health.TokenValid).To(BeTrue())
+				Expect(health.ServerVersion).To(Equal("v1.26.15"))
+				Expect(*health.LastSuccessfulDiscovery).To(Equal(health.LastChecked))
+				Expect(health.Error).To(BeEmpty())
+				config := fakeKubernetesController.NewClientsetArgsForCall(0)
+				Expect(config.BearerToken).To(Equal("test-token"))
+				Expect(config.Timeout).To(Equal(time.Second * internal.DefaultListTimeoutSeconds))
+			})
+		})
+	})
+
+	Describe("#RecordKubernetesAccountHealth", func() {
+		var discovered time.Time
+
+		BeforeEach(func() {
+			discovered = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+			c.KubernetesAccountHealth = kubernetes.NewAccountHealthStore()
+			c.KubernetesAccountHealth.Set(clouddriver.AccountHealth{
+				Account:                 "test-account",
+				LastSuccessfulDiscovery: &discovered,
+			})
+		})
+
+		JustBeforeEach(func() {
+			health = c.RecordKubernetesAccountHealth(provider)
+		})
+
+		When("the check fails", func() {
+			BeforeEach(func() {
+				fakeKubernetesClientset.ServerVersionReturns("", errors.New("connection refused"))
+			})
+
+			It("keeps the last successful discovery", func() {
+				Expect(*health.LastSuccessfulDiscovery).To(Equal(discovered))
+				h, ok := c.KubernetesAccountHealth.Get("test-account")
+				Expect(ok).To(BeTrue())
+				Expect(h.Reachable).To(BeFalse())
+				Expect(*h.LastSuccessfulDiscovery).To(Equal(discovered))
+			})
+		})
+
+		When("the check succeeds", func() {
+			It("records the check", func() {
+				h, ok := c.KubernetesAccountHealth.Get("test-account")
+				Expect(ok).To(BeTrue())
+				Expect(h).To(Equal(health))
+				Expect(h.LastSuccessfulDiscovery.After(discovered)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("#ForgetKubernetesAccountHealth", func() {
+		BeforeEach(func() {
+			c.KubernetesAccountHealth = kubernetes.NewAccountHealthStore()
+			c.RecordKubernetesAccountHealth(provider)
+		})
+
+		It("removes the recorded health", func() {
+			c.ForgetKubernetesAccountHealth("test-account")
+			_, ok := c.KubernetesAccountHealth.Get("test-account")
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
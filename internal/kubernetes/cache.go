@@ -0,0 +1,168 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sync"
+
+	clouddriver "github.com/homedepot/go-clouddriver/pkg"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ResourceCache keeps an in-memory copy of the objects managed by Spinnaker
+// of resources in each account, kept up to date by watching the resources
+// with informers.
+//
+//go:generate counterfeiter . ResourceCache
+type ResourceCache interface {
+	// List returns a copy of each cached object of a resource in an account's
+	// namespace, or in all namespaces if the namespace is empty. It returns false
+	// if the resource is not watched or its cache has not synced yet.
+	List(string, schema.GroupVersionResource, string) ([]unstructured.Unstructured, bool)
+	// Watch starts watching a resource in an account's namespace, or in all
+	// namespaces if the namespace is empty, using the given client. It does
+	// nothing if the resource is already watched.
+	Watch(string, schema.GroupVersionResource, string, Client)
+	// Watching returns true if a resource in an account's namespace is watched.
+	Watching(string, schema.GroupVersionResource, string) bool
+	// Forget stops watching all resources of an account, for example
+	// once its provider has been deleted or its credentials changed.
+	Forget(string)
+	// Stop stops watching all resources.
+	Stop()
+}
+
+// NewResourceCache returns an empty ResourceCache.
+func NewResourceCache() ResourceCache {
+	return &resourceCache{
+		informers: map[cacheKey]*cachedInformer{},
+	}
+}
+
+type cacheKey struct {
+	account   string
+	gvr       schema.GroupVersionResource
+	namespace string
+}
+
+type cachedInformer struct {
+	informer cache.SharedIndexInformer
+	stop     chan struct{}
+}
+
+type resourceCache struct {
+	mu        sync.RWMutex
+	informers map[cacheKey]*cachedInformer
+}
+
+func (rc *resourceCache) List(account string, gvr schema.GroupVersionResource,
+	namespace string) ([]unstructured.Unstructured, bool) {
+	rc.mu.RLock()
+	ci, ok := rc.informers[cacheKey{account: account, gvr: gvr, namespace: namespace}]
+	rc.mu.RUnlock()
+
+	if !ok || !ci.informer.HasSynced() {
+		return nil, false
+	}
+
+	items := []unstructured.Unstructured{}
+
+	for _, obj := range ci.informer.GetStore().List() {
+		if u, ok := obj.(*unstructured.Unstructured); ok {
+			// Callers may modify the objects, so never hand out the cached ones.
+			items = append(items, *u.DeepCopy())
+		}
+	}
+
+	return items, true
+}
+
+func (rc *resourceCache) Watch(account string, gvr schema.GroupVersionResource, namespace string, client Client) {
+	key := cacheKey{account: account, gvr: gvr, namespace: namespace}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if _, ok := rc.informers[key]; ok {
+		return
+	}
+
+	ci := &cachedInformer{
+		informer: client.NewInformer(gvr, namespace, DefaultLabelSelector()),
+		stop:     make(chan struct{}),
+	}
+
+	// The client's credentials are fixed when it is created, so once they expire
+	// drop the informer so that the next request starts a new one with a new client.
+	_ = ci.informer.SetWatchErrorHandler(func(_ *cache.Reflector, err error) {
+		if errors.IsUnauthorized(err) || errors.IsForbidden(err) {
+			clouddriver.Log(fmt.Errorf("stopped watching %s in account %s: %w", gvr.Resource, account, err))
+			rc.remove(key, ci)
+		}
+	})
+
+	rc.informers[key] = ci
+
+	go ci.informer.Run(ci.stop)
+}
+
+func (rc *resourceCache) Watching(account string, gvr schema.GroupVersionResource, namespace string) bool {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+
+	_, ok := rc.informers[cacheKey{account: account, gvr: gvr, namespace: namespace}]
+
+	return ok
+}
+
+func (rc *resourceCache) Forget(account string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	for key, ci := range rc.informers {
+		if key.account == account {
+			close(ci.stop)
+			delete(rc.informers, key)
+		}
+	}
+}
+
+func (rc *resourceCache) Stop() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	for key, ci := range rc.informers {
+		close(ci.stop)
+		delete(rc.informers, key)
+	}
+}
+
+// remove stops an informer and removes it from the cache,
+// unless it has already been replaced.
+func (rc *resourceCache) remove(key cacheKey, ci *cachedInformer) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if rc.informers[key] != ci {
+		return
+	}
+
+	close(ci.stop)
+	delete(rc.informers, key)
+}
+
+// NewInformer returns an informer that watches the objects of a resource
+// matching a label selector in a namespace, or in all namespaces if the
+// namespace is empty. An empty label selector matches all objects.
+// The informer is not started.
+func (c *client) NewInformer(gvr schema.GroupVersionResource, namespace, labelSelector string) cache.SharedIndexInformer {
+	tweakListOptions := func(lo *metav1.ListOptions) {
+		lo.LabelSelector = labelSelector
+	}
+
+	return dynamicinformer.NewFilteredDynamicInformer(c.c, gvr, namespace, 0, cache.Indexers{}, tweakListOptions).Informer()
+}
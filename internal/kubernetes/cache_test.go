@@ -0,0 +1,169 @@
+package kubernetes_test
+
+import (
+	. "github.com/homedepot/go-clouddriver/internal/kubernetes"
+	"github.com/homedepot/go-clouddriver/internal/kubernetes/kubernetesfakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+)
+
+var _ = Describe("Cache", func() {
+	var (
+		rc             ResourceCache
+		fakeKubeClient *kubernetesfakes.FakeClient
+		gvr            schema.GroupVersionResource
+		items          []unstructured.Unstructured
+		synced         bool
+	)
+
+	BeforeEach(func() {
+		gvr = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+		deployment := &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata": map[string]interface{}{
+					"name":      "test-deployment",
+					"namespace": "test-namespace",
+				},
+			},
+		}
+		dynamicClient := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{gvr: "DeploymentList"}, deployment)
+
+		fakeKubeClient = &kubernetesfakes.FakeClient{}
+		fakeKubeClient.NewInformerStub = func(gvr schema.GroupVersionResource, namespace, _ string) cache.SharedIndexInformer {
+			return dynamicinformer.NewFilteredDynamicInformer(dynamicClient, gvr, namespace, 0, cache.Indexers{}, nil).Informer()
+		}
+
+		rc = NewResourceCache()
+	})
+
+	AfterEach(func() {
+		rc.Stop()
+	})
+
+	Describe("#List", func() {
+		JustBeforeEach(func() {
+			items, synced = rc.List("test-account", gvr, "")
+		})
+
+		When("the resource is not watched", func() {
+			It("returns false", func() {
+				Expect(synced).To(BeFalse())
+				Expect(items).To(BeNil())
+			})
+		})
+
+		When("the resource is watched", func() {
+			BeforeEach(func() {
+				rc.Watch("test-account", gvr, "", fakeKubeClient)
+				Eventually(func() bool {
+					_, ok := rc.List("test-account", gvr, "")
+					return ok
+				}).Should(BeTrue())
+			})
+
+			It("returns a copy of the cached objects", func() {
+				Expect(synced).To(BeTrue())
+				Expect(items).To(HaveLen(1))
+				Expect(items[0].GetName()).To(Equal("test-deployment"))
+
+				items[0].SetName("modified")
+				cached, _ := rc.List("test-account", gvr, "")
+				Expect(cached[0].GetName()).To(Equal("test-deployment"))
+			})
+
+			It("does not return the objects of other accounts", func() {
+				_, ok := rc.List("other-account", gvr, "")
+				Expect(ok).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("#Watch", func() {
+		JustBeforeEach(func() {
+			rc.Watch("test-account", gvr, "test-namespace", fakeKubeClient)
+		})
+
+		When("the resource is already watched", func() {
+			BeforeEach(func() {
+				rc.Watch("test-account", gvr, "test-namespace", fakeKubeClient)
+			})
+
+			It("does not start another informer", func() {
+				Expect(fakeKubeClient.NewInformerCallCount()).To(Equal(1))
+			})
+		})
+
+		When("the watch is unauthorized", func() {
+			BeforeEach(func() {
+				fakeKubeClient.NewInformerStub = func(gvr schema.GroupVersionResource, namespace, _ string) cache.SharedIndexInformer {
+					dynamicClient := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+						map[schema.GroupVersionResource]string{gvr: "DeploymentList"})
+					dynamicClient.PrependReactor("list", "deployments", func(_ k8stesting.Action) (bool, runtime.Object, error) {
+						return true, nil, errors.NewUnauthorized("token expired")
+					})
+
+					return dynamicinformer.NewFilteredDynamicInformer(dynamicClient, gvr, namespace, 0, cache.Indexers{}, nil).Informer()
+				}
+			})
+
+			It("stops watching the resource", func() {
+				Eventually(func() bool {
+					return rc.Watching("test-account", gvr, "test-namespace")
+				}).Should(BeFalse())
+			})
+		})
+
+		When("it succeeds", func() {
+			It("watches the resource in the namespace", func() {
+				Expect(rc.Watching("test-account", gvr, "test-namespace")).To(BeTrue())
+				Expect(rc.Watching("test-account", gvr, "")).To(BeFalse())
+				_, namespace, labelSelector := fakeKubeClient.NewInformerArgsForCall(0)
+				Expect(namespace).To(Equal("test-namespace"))
+				Expect(labelSelector).To(Equal(DefaultLabelSelector()))
+			})
+		})
+	})
+
+	Describe("#Forget", func() {
+		BeforeEach(func() {
+			rc.Watch("test-account", gvr, "", fakeKubeClient)
+			rc.Watch("test-account", gvr, "test-namespace", fakeKubeClient)
+			rc.Watch("other-account", gvr, "", fakeKubeClient)
+		})
+
+		JustBeforeEach(func() {
+			rc.Forget("test-account")
+		})
+
+		It("stops watching the resources of the account", func() {
+			Expect(rc.Watching("test-account", gvr, "")).To(BeFalse())
+			Expect(rc.Watching("test-account", gvr, "test-namespace")).To(BeFalse())
+			Expect(rc.Watching("other-account", gvr, "")).To(BeTrue())
+		})
+	})
+
+	Describe("#Stop", func() {
+		BeforeEach(func() {
+			rc.Watch("test-account", gvr, "", fakeKubeClient)
+		})
+
+		JustBeforeEach(func() {
+			rc.Stop()
+		})
+
+		It("stops watching all resources", func() {
+			Expect(rc.Watching("test-account", gvr, "")).To(BeFalse())
+		})
+	})
+})
@@ -5,15 +5,18 @@ import (
 	"fmt"
 
 	gcpatcher "github.com/homedepot/go-clouddriver/internal/kubernetes/patcher"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/cli-runtime/pkg/resource"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/kubectl/pkg/util"
 )
 
@@ -35,9 +38,11 @@ type Metadata struct {
 //go:generate counterfeiter . Client
 type Client interface {
 	Apply(*unstructured.Unstructured) (Metadata, error)
+	DryRunApply(*unstructured.Unstructured) (*unstructured.Unstructured, *unstructured.Unstructured, error)
 	Replace(*unstructured.Unstructured) (Metadata, error)
 	DeleteResourceByKindAndNameAndNamespace(string, string, string, metav1.DeleteOptions) error
 	Discover() error
+	InvalidateDiscovery()
 	GVRForKind(string) (schema.GroupVersionResource, error)
 	Get(string, string, string) (*unstructured.Unstructured, error)
 	ListByGVR(schema.GroupVersionResource, metav1.ListOptions) (*unstructured.UnstructuredList, error)
@@ -48,6 +53,7 @@ type Client interface {
 	PatchUsingStrategy(string, string, string, []byte, types.PatchType) (Metadata, *unstructured.Unstructured, error)
 	ListResourcesByKindAndNamespace(string, string, metav1.ListOptions) (*unstructured.UnstructuredList, error)
 	ListResourcesByKindAndNamespaceWithContext(context.Context, string, string, metav1.ListOptions) (*unstructured.UnstructuredList, error)
+	NewInformer(schema.GroupVersionResource, string, string) cache.SharedIndexInformer
 }
 
 type client struct {
@@ -114,7 +120,15 @@ func (c *client) Apply(u *unstructured.Unstructured) (Metadata, error) {
 		patcher.Force = true
 	}
 
-	if !serverSideApply {
+	if serverSideApply {
+		// Take over ownership of the fields of an object previously deployed
+		// with a client-side apply the first time it is server-side applied.
+		if err := c.migrateToServerSideApply(helper, info.Namespace, info.Name); err != nil {
+			return metadata, err
+		}
+
+		removeLastAppliedAnnotation(u)
+	} else {
 		if err := info.Get(); err != nil {
 			if !errors.IsNotFound(err) {
 				return metadata, err
@@ -138,6 +152,10 @@ func (c *client) Apply(u *unstructured.Unstructured) (Metadata, error) {
 
 	_, patchedObject, err := patcher.Patch(info.Object, modified, info.Namespace, info.Name, serverSideApply)
 	if err != nil {
+		if serverSideApply && errors.IsConflict(err) {
+			return metadata, NewConflictError(err)
+		}
+
 		return metadata, err
 	}
 
@@ -228,6 +246,141 @@ func (c *client) Replace(u *unstructured.Unstructured) (Metadata, error) {
 	return metadata, nil
 }
 
+// DryRunApply deploys a given manifest in server-side dry-run mode, using the same
+// strategy as a deployment: a replace if the manifest is annotated to be replaced,
+// a server-side apply if it is annotated to be server-side applied, or else a
+// client-side apply. It returns the live object, or nil if it does not exist, along
+// with the object as the server would have persisted it. Nothing is changed in the cluster.
+//
+// The first server-side apply of an object previously deployed with a client-side
+// apply takes over the fields of its client-side managers, which can't be dry run,
+// so conflicts are forced for such objects.
+func (c *client) DryRunApply(u *unstructured.Unstructured) (*unstructured.Unstructured, *unstructured.Unstructured, error) {
+	gvk := u.GroupVersionKind()
+
+	restMapping, err := c.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	restClient, err := newRestClient(*c.config, gvk.GroupVersion())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	helper := resource.NewHelper(restClient, restMapping).
+		DryRun(true).
+		WithFieldManager(spinnaker)
+
+	var live *unstructured.Unstructured
+
+	current, err := helper.Get(u.GetNamespace(), u.GetName())
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, nil, err
+		}
+	} else {
+		live, err = toUnstructuredObject(current)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Copy the manifest, as applying it sets its annotations.
+	obj := u.DeepCopy()
+
+	var result runtime.Object
+
+	switch {
+	case Replace(*u):
+		err = util.CreateOrUpdateAnnotation(false, obj, unstructured.UnstructuredJSONScheme)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if live == nil {
+			result, err = helper.Create(obj.GetNamespace(), true, obj)
+		} else {
+			result, err = helper.Replace(obj.GetNamespace(), obj.GetName(), true, obj)
+		}
+	case AnnotationMatches(*u, AnnotationSpinnakerServerSideApply, "true"),
+		AnnotationMatches(*u, AnnotationSpinnakerServerSideApply, "force-conflicts"):
+		removeLastAppliedAnnotation(obj)
+
+		force := AnnotationMatches(*u, AnnotationSpinnakerServerSideApply, "force-conflicts")
+		if live != nil {
+			if _, ok := live.GetAnnotations()[corev1.LastAppliedConfigAnnotation]; ok {
+				force = true
+			}
+		}
+
+		var data []byte
+
+		data, err = runtime.Encode(unstructured.UnstructuredJSONScheme, obj)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		result, err = helper.Patch(obj.GetNamespace(), obj.GetName(), types.ApplyPatchType, data, &metav1.PatchOptions{Force: &force})
+		if errors.IsConflict(err) {
+			return nil, nil, NewConflictError(err)
+		}
+	default:
+		if live == nil {
+			err = util.CreateApplyAnnotation(obj, unstructured.UnstructuredJSONScheme)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			result, err = helper.Create(obj.GetNamespace(), true, obj)
+
+			break
+		}
+
+		var (
+			modified []byte
+			patcher  *gcpatcher.Patcher
+		)
+
+		modified, err = util.GetModifiedConfiguration(obj, true, unstructured.UnstructuredJSONScheme)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		patcher, err = gcpatcher.New(&resource.Info{Mapping: restMapping}, helper)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		_, result, err = patcher.Patch(current, modified, obj.GetNamespace(), obj.GetName(), false)
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r, err := toUnstructuredObject(result)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return live, r, nil
+}
+
+// toUnstructuredObject converts a runtime object returned by the API server to unstructured.
+func toUnstructuredObject(obj runtime.Object) (*unstructured.Unstructured, error) {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		return u, nil
+	}
+
+	m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return &unstructured.Unstructured{Object: m}, nil
+}
+
 func newRestClient(restConfig rest.Config, gv schema.GroupVersion) (rest.Interface, error) {
 	restConfig.ContentConfig = resource.UnstructuredPlusDefaultContentConfig()
 	restConfig.GroupVersion = &gv
@@ -292,6 +445,13 @@ func (c *client) Discover() error {
 	return nil
 }
 
+// InvalidateDiscovery drops the cached API discovery of the cluster, so that
+// the next request discovers the API again and updates the cache for other
+// clients of the cluster, picking up any newly installed kinds.
+func (c *client) InvalidateDiscovery() {
+	c.mapper.Reset()
+}
+
 // Get a manifest by resource/kind (example: 'pods' or 'pod'),
 // name (example: 'my-pod'), and namespace (example: 'my-namespace').
 func (c *client) Get(kind, name, namespace string) (*unstructured.Unstructured, error) {
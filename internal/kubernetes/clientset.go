@@ -21,7 +21,9 @@ var (
 //go:generate counterfeiter . Clientset
 type Clientset interface {
 	PodLogs(string, string, string) (string, error)
+	StreamPodLogs(context.Context, string, string, v1.PodLogOptions) (io.ReadCloser, error)
 	Events(context.Context, string, string, string) ([]v1.Event, error)
+	ServerVersion() (string, error)
 }
 
 type clientset struct {
@@ -56,6 +58,22 @@ func (c *clientset) PodLogs(name, namespace, container string) (string, error) {
 	return buf.String(), nil
 }
 
+// StreamPodLogs returns a stream of the logs of a given pod in a given
+// namespace, selected by the options passed in. If no tail lines are
+// set the previous 10000 lines are streamed. The stream is closed when
+// the context is canceled.
+func (c *clientset) StreamPodLogs(ctx context.Context, name, namespace string,
+	options v1.PodLogOptions) (io.ReadCloser, error) {
+	if options.TailLines == nil {
+		options.TailLines = &defaultTailLines
+	}
+
+	return c.clientset.CoreV1().
+		Pods(namespace).
+		GetLogs(name, &options).
+		Stream(ctx)
+}
+
 // Events returns events for a given kind, name, and namespace.
 func (c *clientset) Events(ctx context.Context, kind, name, namespace string) ([]v1.Event, error) {
 	lo := metav1.ListOptions{
@@ -70,6 +88,17 @@ func (c *clientset) Events(ctx context.Context, kind, name, namespace string) ([
 	return events.Items, nil
 }
 
+// ServerVersion returns the git version of the cluster's API server,
+// for example 'v1.26.15'.
+func (c *clientset) ServerVersion() (string, error) {
+	info, err := c.clientset.Discovery().ServerVersion()
+	if err != nil {
+		return "", err
+	}
+
+	return info.GitVersion, nil
+}
+
 // uppercaseFirst uppercases the first letter of a string.
 func uppercaseFirst(str string) string {
 	for i, v := range str {
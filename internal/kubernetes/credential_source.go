@@ -0,0 +1,302 @@
+package kubernetes
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	arcade "github.com/homedepot/arcade/pkg"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// Token providers that select a credential source other than arcade.
+// Every other token provider gets its tokens from arcade.
+const (
+	// TokenProviderBearerToken authenticates with the provider's static bearer token.
+	TokenProviderBearerToken = "bearerToken"
+	// TokenProviderClientCertificate authenticates with the provider's
+	// client certificate and key.
+	TokenProviderClientCertificate = "clientCertificate"
+	// TokenProviderExec authenticates with the token returned
+	// by the provider's exec credential plugin.
+	TokenProviderExec = "exec"
+	// TokenProviderKubeconfig authenticates with the current context
+	// of the provider's kubeconfig file.
+	TokenProviderKubeconfig = "kubeconfig"
+
+	defaultExecAPIVersion = "client.authentication.k8s.io/v1"
+)
+
+// ProviderExec defines the exec credential plugin of a provider.
+//
+// See https://kubernetes.io/docs/reference/access-authn-authz/authentication/#client-go-credential-plugins.
+type ProviderExec struct {
+	// APIVersion of the ExecCredential the plugin returns.
+	// Defaults to 'client.authentication.k8s.io/v1'.
+	APIVersion string            `json:"apiVersion,omitempty"`
+	Command    string            `json:"command"`
+	Args       []string          `json:"args,omitempty"`
+	Env        map[string]string `json:"env,omitempty"`
+}
+
+// CredentialPolicy restricts the exec credential plugins and kubeconfig files
+// providers can use to those allowed by operators, as anyone who can create
+// a provider could otherwise run any command or read any file.
+type CredentialPolicy struct {
+	// ExecCommands are the commands exec credential plugins may run.
+	ExecCommands []string
+	// ExecEnv are the names of the environment variables exec
+	// credential plugins may be given.
+	ExecEnv []string
+	// KubeconfigDirs are the directories kubeconfig files may be read from.
+	KubeconfigDirs []string
+}
+
+func (cp CredentialPolicy) validateExec(exec ProviderExec) error {
+	if !contains(cp.ExecCommands, exec.Command) {
+		return fmt.Errorf("exec command %s is not allowed", exec.Command)
+	}
+
+	for name := range exec.Env {
+		if !contains(cp.ExecEnv, name) {
+			return fmt.Errorf("exec env %s is not allowed", name)
+		}
+	}
+
+	return nil
+}
+
+func (cp CredentialPolicy) validateKubeconfig(path string) error {
+	if filepath.IsAbs(path) {
+		for _, dir := range cp.KubeconfigDirs {
+			rel, err := filepath.Rel(filepath.Clean(dir), filepath.Clean(path))
+			if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("kubeconfig %s is not in an allowed directory", path)
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CredentialSource provides the credentials a provider authenticates to its cluster with.
+type CredentialSource interface {
+	// Validate verifies that the source can provide credentials for the provider.
+	Validate(Provider) error
+	// Configure sets the provider's credentials on the config of a client of its cluster.
+	Configure(Provider, *rest.Config) error
+}
+
+// NewCredentialSource returns the credential source selected by a provider's
+// token provider. Token providers without a source of their own get tokens
+// from arcade. Exec and kubeconfig sources are restricted by the given policy.
+func NewCredentialSource(tokenProvider string, ac arcade.Client, policy CredentialPolicy) CredentialSource {
+	switch tokenProvider {
+	case TokenProviderBearerToken:
+		return bearerTokenSource{}
+	case TokenProviderClientCertificate:
+		return clientCertificateSource{}
+	case TokenProviderExec:
+		return execSource{policy: policy}
+	case TokenProviderKubeconfig:
+		return kubeconfigSource{policy: policy}
+	default:
+		return arcadeSource{ac: ac}
+	}
+}
+
+// UsesArcade returns true if the token provider gets its tokens from arcade.
+func UsesArcade(tokenProvider string) bool {
+	_, ok := NewCredentialSource(tokenProvider, nil, CredentialPolicy{}).(arcadeSource)
+
+	return ok
+}
+
+type arcadeSource struct {
+	ac arcade.Client
+}
+
+func (s arcadeSource) Validate(p Provider) error {
+	_, err := s.ac.Token(p.TokenProvider)
+	if err != nil {
+		return fmt.Errorf("error getting token: %w", err)
+	}
+
+	return nil
+}
+
+func (s arcadeSource) Configure(p Provider, config *rest.Config) error {
+	token, err := s.ac.Token(p.TokenProvider)
+	if err != nil {
+		return fmt.Errorf("error getting token from arcade for provider %s: %w", p.TokenProvider, err)
+	}
+
+	config.BearerToken = token
+
+	return nil
+}
+
+type bearerTokenSource struct{}
+
+func (bearerTokenSource) Validate(p Provider) error {
+	if p.BearerToken == "" {
+		return errors.New("bearer token required for token provider " + TokenProviderBearerToken)
+	}
+
+	return nil
+}
+
+func (s bearerTokenSource) Configure(p Provider, config *rest.Config) error {
+	err := s.Validate(p)
+	if err != nil {
+		return err
+	}
+
+	config.BearerToken = p.BearerToken
+
+	return nil
+}
+
+type clientCertificateSource struct{}
+
+func (s clientCertificateSource) Validate(p Provider) error {
+	_, _, err := s.decode(p)
+
+	return err
+}
+
+func (s clientCertificateSource) Configure(p Provider, config *rest.Config) error {
+	cert, key, err := s.decode(p)
+	if err != nil {
+		return err
+	}
+
+	config.TLSClientConfig.CertData = cert
+	config.TLSClientConfig.KeyData = key
+
+	return nil
+}
+
+// decode decodes the provider's base64 encoded client certificate and key.
+func (clientCertificateSource) decode(p Provider) ([]byte, []byte, error) {
+	if p.ClientCertificateData == "" || p.ClientKeyData == "" {
+		return nil, nil, errors.New("client certificate and key data required for token provider " +
+			TokenProviderClientCertificate)
+	}
+
+	cert, err := base64.StdEncoding.DecodeString(p.ClientCertificateData)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error decoding base64 client certificate data: %w", err)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(p.ClientKeyData)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error decoding base64 client key data: %w", err)
+	}
+
+	return cert, key, nil
+}
+
+type execSource struct {
+	policy CredentialPolicy
+}
+
+func (s execSource) Validate(p Provider) error {
+	if p.Exec == nil || p.Exec.Command == "" {
+		return errors.New("exec command required for token provider " + TokenProviderExec)
+	}
+
+	return s.policy.validateExec(*p.Exec)
+}
+
+func (s execSource) Configure(p Provider, config *rest.Config) error {
+	err := s.Validate(p)
+	if err != nil {
+		return err
+	}
+
+	exec := &clientcmdapi.ExecConfig{
+		APIVersion:      p.Exec.APIVersion,
+		Command:         p.Exec.Command,
+		Args:            p.Exec.Args,
+		InteractiveMode: clientcmdapi.NeverExecInteractiveMode,
+	}
+
+	if exec.APIVersion == "" {
+		exec.APIVersion = defaultExecAPIVersion
+	}
+
+	for name, value := range p.Exec.Env {
+		exec.Env = append(exec.Env, clientcmdapi.ExecEnvVar{Name: name, Value: value})
+	}
+
+	config.ExecProvider = exec
+
+	return nil
+}
+
+type kubeconfigSource struct {
+	policy CredentialPolicy
+}
+
+func (s kubeconfigSource) Validate(p Provider) error {
+	_, err := s.load(p)
+
+	return err
+}
+
+// Configure uses the kubeconfig's current context, keeping the provider's
+// host and CA data when they are set.
+func (s kubeconfigSource) Configure(p Provider, config *rest.Config) error {
+	kc, err := s.load(p)
+	if err != nil {
+		return err
+	}
+
+	kc.Timeout = config.Timeout
+
+	if config.Host != "" {
+		kc.Host = config.Host
+	}
+
+	if len(config.TLSClientConfig.CAData) > 0 {
+		kc.TLSClientConfig.CAData = config.TLSClientConfig.CAData
+		kc.TLSClientConfig.CAFile = ""
+	}
+
+	*config = *kc
+
+	return nil
+}
+
+func (s kubeconfigSource) load(p Provider) (*rest.Config, error) {
+	if p.Kubeconfig == "" {
+		return nil, errors.New("kubeconfig file required for token provider " + TokenProviderKubeconfig)
+	}
+
+	err := s.policy.validateKubeconfig(p.Kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := clientcmd.BuildConfigFromFlags("", p.Kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("error loading kubeconfig %s: %w", p.Kubeconfig, err)
+	}
+
+	return config, nil
+}
@@ -0,0 +1,277 @@
+package kubernetes_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/homedepot/arcade/pkg/arcadefakes"
+	. "github.com/homedepot/go-clouddriver/internal/kubernetes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/rest"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+const kubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: test-cluster
+  cluster:
+    server: https://kubeconfig-host
+contexts:
+- name: test-context
+  context:
+    cluster: test-cluster
+    user: test-user
+current-context: test-context
+users:
+- name: test-user
+  user:
+    token: kubeconfig-token
+`
+
+var _ = Describe("CredentialSource", func() {
+	var (
+		fakeArcadeClient *arcadefakes.FakeClient
+		policy           CredentialPolicy
+		provider         Provider
+		source           CredentialSource
+		config           *rest.Config
+		err              error
+	)
+
+	BeforeEach(func() {
+		fakeArcadeClient = &arcadefakes.FakeClient{}
+		fakeArcadeClient.TokenReturns("arcade-token", nil)
+		policy = CredentialPolicy{}
+		provider = Provider{
+			Name:          "test-account",
+			Host:          "https://test-host",
+			TokenProvider: "google",
+		}
+		config = &rest.Config{
+			Host: "https://test-host",
+			TLSClientConfig: rest.TLSClientConfig{
+				CAData: []byte("test-ca-data"),
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		source = NewCredentialSource(provider.TokenProvider, fakeArcadeClient, policy)
+		err = source.Configure(provider, config)
+	})
+
+	Describe("#UsesArcade", func() {
+		It("returns true only for token providers without a source of their own", func() {
+			Expect(UsesArcade("google")).To(BeTrue())
+			Expect(UsesArcade("rancher")).To(BeTrue())
+			Expect(UsesArcade(TokenProviderBearerToken)).To(BeFalse())
+			Expect(UsesArcade(TokenProviderClientCertificate)).To(BeFalse())
+			Expect(UsesArcade(TokenProviderExec)).To(BeFalse())
+			Expect(UsesArcade(TokenProviderKubeconfig)).To(BeFalse())
+		})
+	})
+
+	When("the token provider is an arcade token provider", func() {
+		When("getting the token returns an error", func() {
+			BeforeEach(func() {
+				fakeArcadeClient.TokenReturns("", errors.New("unsupported token provider"))
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("error getting token from arcade for provider google: unsupported token provider"))
+				Expect(source.Validate(provider).Error()).To(Equal("error getting token: unsupported token provider"))
+			})
+		})
+
+		It("uses the token from arcade", func() {
+			Expect(err).To(BeNil())
+			Expect(config.BearerToken).To(Equal("arcade-token"))
+			Expect(fakeArcadeClient.TokenArgsForCall(0)).To(Equal("google"))
+		})
+	})
+
+	When("the token provider is bearerToken", func() {
+		BeforeEach(func() {
+			provider.TokenProvider = TokenProviderBearerToken
+			provider.BearerToken = "static-token"
+		})
+
+		When("the provider has no bearer token", func() {
+			BeforeEach(func() {
+				provider.BearerToken = ""
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("bearer token required for token provider bearerToken"))
+			})
+		})
+
+		It("uses the provider's bearer token", func() {
+			Expect(err).To(BeNil())
+			Expect(config.BearerToken).To(Equal("static-token"))
+			Expect(fakeArcadeClient.TokenCallCount()).To(Equal(0))
+		})
+	})
+
+	When("the token provider is clientCertificate", func() {
+		BeforeEach(func() {
+			provider.TokenProvider = TokenProviderClientCertificate
+			provider.ClientCertificateData = "dGVzdC1jZXJ0"
+			provider.ClientKeyData = "dGVzdC1rZXk="
+		})
+
+		When("the client key is missing", func() {
+			BeforeEach(func() {
+				provider.ClientKeyData = ""
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("client certificate and key data required for token provider clientCertificate"))
+			})
+		})
+
+		When("the client certificate is not base64 encoded", func() {
+			BeforeEach(func() {
+				provider.ClientCertificateData = "{}"
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(HavePrefix("error decoding base64 client certificate data"))
+			})
+		})
+
+		It("uses the provider's client certificate", func() {
+			Expect(err).To(BeNil())
+			Expect(string(config.TLSClientConfig.CertData)).To(Equal("test-cert"))
+			Expect(string(config.TLSClientConfig.KeyData)).To(Equal("test-key"))
+			Expect(config.BearerToken).To(BeEmpty())
+		})
+	})
+
+	When("the token provider is exec", func() {
+		BeforeEach(func() {
+			provider.TokenProvider = TokenProviderExec
+			provider.Exec = &ProviderExec{
+				Command: "get-token",
+				Args:    []string{"--cluster", "test-cluster"},
+				Env:     map[string]string{"REGION": "us-east1"},
+			}
+			policy.ExecCommands = []string{"get-token"}
+			policy.ExecEnv = []string{"REGION"}
+		})
+
+		When("the provider has no exec command", func() {
+			BeforeEach(func() {
+				provider.Exec = nil
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("exec command required for token provider exec"))
+			})
+		})
+
+		When("the exec command is not allowed", func() {
+			BeforeEach(func() {
+				provider.Exec.Command = "/bin/sh"
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("exec command /bin/sh is not allowed"))
+				Expect(config.ExecProvider).To(BeNil())
+			})
+		})
+
+		When("an exec env is not allowed", func() {
+			BeforeEach(func() {
+				provider.Exec.Env["LD_PRELOAD"] = "/tmp/lib.so"
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("exec env LD_PRELOAD is not allowed"))
+				Expect(config.ExecProvider).To(BeNil())
+			})
+		})
+
+		It("uses the provider's exec credential plugin", func() {
+			Expect(err).To(BeNil())
+			Expect(config.ExecProvider).To(Equal(&clientcmdapi.ExecConfig{
+				APIVersion:      "client.authentication.k8s.io/v1",
+				Command:         "get-token",
+				Args:            []string{"--cluster", "test-cluster"},
+				Env:             []clientcmdapi.ExecEnvVar{{Name: "REGION", Value: "us-east1"}},
+				InteractiveMode: clientcmdapi.NeverExecInteractiveMode,
+			}))
+		})
+	})
+
+	When("the token provider is kubeconfig", func() {
+		BeforeEach(func() {
+			dir := GinkgoT().TempDir()
+			provider.TokenProvider = TokenProviderKubeconfig
+			provider.Kubeconfig = filepath.Join(dir, "config")
+			Expect(os.WriteFile(provider.Kubeconfig, []byte(kubeconfig), 0600)).To(Succeed())
+			policy.KubeconfigDirs = []string{dir}
+		})
+
+		When("the kubeconfig file is not in an allowed directory", func() {
+			BeforeEach(func() {
+				provider.Kubeconfig = "/etc/passwd"
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("kubeconfig /etc/passwd is not in an allowed directory"))
+			})
+		})
+
+		When("the kubeconfig path leaves an allowed directory", func() {
+			BeforeEach(func() {
+				provider.Kubeconfig = filepath.Join(policy.KubeconfigDirs[0], "..", "config")
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(HaveSuffix("is not in an allowed directory"))
+			})
+		})
+
+		When("the kubeconfig file does not exist", func() {
+			BeforeEach(func() {
+				provider.Kubeconfig = filepath.Join(policy.KubeconfigDirs[0], "does-not-exist")
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(HavePrefix("error loading kubeconfig " + provider.Kubeconfig))
+			})
+		})
+
+		When("the provider has no host", func() {
+			BeforeEach(func() {
+				config.Host = ""
+			})
+
+			It("uses the kubeconfig's host", func() {
+				Expect(err).To(BeNil())
+				Expect(config.Host).To(Equal("https://kubeconfig-host"))
+			})
+		})
+
+		It("uses the kubeconfig's credentials", func() {
+			Expect(err).To(BeNil())
+			Expect(config.Host).To(Equal("https://test-host"))
+			Expect(config.BearerToken).To(Equal("kubeconfig-token"))
+			Expect(string(config.TLSClientConfig.CAData)).To(Equal("test-ca-data"))
+		})
+	})
+})
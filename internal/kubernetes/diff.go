@@ -0,0 +1,63 @@
+package kubernetes
+
+import (
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/jsonmergepatch"
+)
+
+// serverSetFields are fields the API server sets or changes on every write,
+// so they are ignored when diffing manifests.
+var serverSetFields = [][]string{
+	{"metadata", "creationTimestamp"},
+	{"metadata", "generation"},
+	{"metadata", "managedFields"},
+	{"metadata", "resourceVersion"},
+	{"metadata", "selfLink"},
+	{"metadata", "uid"},
+	{"status"},
+}
+
+// Diff returns the JSON merge patch that changes the live object into the
+// desired object, ignoring fields set by the server. A nil live object is
+// treated as empty, so the diff of a new object is the object itself.
+func Diff(live, desired *unstructured.Unstructured) (map[string]interface{}, error) {
+	l, err := diffable(live)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := diffable(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := jsonmergepatch.CreateThreeWayJSONMergePatch(l, d, l)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := map[string]interface{}{}
+
+	err = json.Unmarshal(b, &diff)
+	if err != nil {
+		return nil, err
+	}
+
+	return diff, nil
+}
+
+// diffable returns the JSON of the object without the fields set by the server.
+func diffable(u *unstructured.Unstructured) ([]byte, error) {
+	if u == nil {
+		return []byte("{}"), nil
+	}
+
+	c := u.DeepCopy()
+	for _, fields := range serverSetFields {
+		unstructured.RemoveNestedField(c.Object, fields...)
+	}
+
+	return json.Marshal(c.Object)
+}
@@ -0,0 +1,99 @@
+package kubernetes_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	. "github.com/homedepot/go-clouddriver/internal/kubernetes"
+)
+
+var _ = Describe("Diff", func() {
+	var (
+		live    *unstructured.Unstructured
+		desired *unstructured.Unstructured
+		diff    map[string]interface{}
+		err     error
+	)
+
+	BeforeEach(func() {
+		live = &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata": map[string]interface{}{
+					"name":            "test-deployment",
+					"namespace":       "test-namespace",
+					"resourceVersion": "100",
+					"generation":      int64(3),
+					"uid":             "test-uid",
+					"labels": map[string]interface{}{
+						"app": "test-app",
+						"old": "label",
+					},
+				},
+				"spec": map[string]interface{}{
+					"replicas": int64(1),
+				},
+				"status": map[string]interface{}{
+					"replicas": int64(1),
+				},
+			},
+		}
+		desired = live.DeepCopy()
+		desired.SetResourceVersion("101")
+		desired.SetGeneration(4)
+		desired.SetLabels(map[string]string{
+			"app": "test-app",
+		})
+		_ = unstructured.SetNestedField(desired.Object, int64(3), "spec", "replicas")
+		_ = unstructured.SetNestedField(desired.Object, int64(0), "status", "replicas")
+	})
+
+	JustBeforeEach(func() {
+		diff, err = Diff(live, desired)
+	})
+
+	When("the live object does not exist", func() {
+		BeforeEach(func() {
+			live = nil
+		})
+
+		It("returns the desired object without server set fields", func() {
+			Expect(err).To(BeNil())
+			Expect(diff["kind"]).To(Equal("Deployment"))
+			_, found, _ := unstructured.NestedString(diff, "metadata", "resourceVersion")
+			Expect(found).To(BeFalse())
+			_, found, _ = unstructured.NestedFieldNoCopy(diff, "status")
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	When("nothing changed", func() {
+		BeforeEach(func() {
+			desired = live.DeepCopy()
+			desired.SetResourceVersion("101")
+		})
+
+		It("returns an empty diff", func() {
+			Expect(err).To(BeNil())
+			Expect(diff).To(BeEmpty())
+		})
+	})
+
+	When("it succeeds", func() {
+		It("returns the changed fields", func() {
+			Expect(err).To(BeNil())
+			Expect(diff).To(Equal(map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"labels": map[string]interface{}{
+						"old": nil,
+					},
+				},
+				"spec": map[string]interface{}{
+					"replicas": float64(3),
+				},
+			}))
+		})
+	})
+})
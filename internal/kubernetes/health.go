@@ -0,0 +1,70 @@
+package kubernetes
+
+import (
+	"sort"
+	"sync"
+
+	clouddriver "github.com/homedepot/go-clouddriver/pkg"
+)
+
+// AccountHealthStore holds the result of the last health check of each account.
+type AccountHealthStore interface {
+	// Get returns the last health check of an account,
+	// or false if the account has not been checked.
+	Get(string) (clouddriver.AccountHealth, bool)
+	// Set records the last health check of an account.
+	Set(clouddriver.AccountHealth)
+	// Delete forgets the health checks of an account.
+	Delete(string)
+	// Accounts returns the sorted names of the accounts checked.
+	Accounts() []string
+}
+
+// NewAccountHealthStore returns an empty AccountHealthStore.
+func NewAccountHealthStore() AccountHealthStore {
+	return &accountHealthStore{
+		health: map[string]clouddriver.AccountHealth{},
+	}
+}
+
+type accountHealthStore struct {
+	mu     sync.RWMutex
+	health map[string]clouddriver.AccountHealth
+}
+
+func (s *accountHealthStore) Get(account string) (clouddriver.AccountHealth, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	h, ok := s.health[account]
+
+	return h, ok
+}
+
+func (s *accountHealthStore) Set(h clouddriver.AccountHealth) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.health[h.Account] = h
+}
+
+func (s *accountHealthStore) Delete(account string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.health, account)
+}
+
+func (s *accountHealthStore) Accounts() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	accounts := make([]string, 0, len(s.health))
+	for account := range s.health {
+		accounts = append(accounts, account)
+	}
+
+	sort.Strings(accounts)
+
+	return accounts
+}
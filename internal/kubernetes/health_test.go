@@ -0,0 +1,68 @@
+package kubernetes_test
+
+import (
+	. "github.com/homedepot/go-clouddriver/internal/kubernetes"
+	clouddriver "github.com/homedepot/go-clouddriver/pkg"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Health", func() {
+	var (
+		store AccountHealthStore
+	)
+
+	BeforeEach(func() {
+		store = NewAccountHealthStore()
+		store.Set(clouddriver.AccountHealth{
+			Account:   "account-b",
+			Reachable: true,
+		})
+		store.Set(clouddriver.AccountHealth{
+			Account: "account-a",
+			Error:   "error getting token",
+		})
+	})
+
+	Describe("#Get", func() {
+		When("the account has not been checked", func() {
+			It("returns false", func() {
+				_, ok := store.Get("account-c")
+				Expect(ok).To(BeFalse())
+			})
+		})
+
+		When("the account has been checked", func() {
+			It("returns the last check", func() {
+				h, ok := store.Get("account-b")
+				Expect(ok).To(BeTrue())
+				Expect(h.Reachable).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("#Set", func() {
+		It("replaces the last check", func() {
+			store.Set(clouddriver.AccountHealth{
+				Account: "account-b",
+			})
+			h, _ := store.Get("account-b")
+			Expect(h.Reachable).To(BeFalse())
+		})
+	})
+
+	Describe("#Delete", func() {
+		It("forgets the account", func() {
+			store.Delete("account-a")
+			_, ok := store.Get("account-a")
+			Expect(ok).To(BeFalse())
+			Expect(store.Accounts()).To(Equal([]string{"account-b"}))
+		})
+	})
+
+	Describe("#Accounts", func() {
+		It("returns the sorted accounts", func() {
+			Expect(store.Accounts()).To(Equal([]string{"account-a", "account-b"}))
+		})
+	})
+})
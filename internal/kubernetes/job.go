@@ -5,6 +5,7 @@ import (
 
 	"github.com/homedepot/go-clouddriver/internal/kubernetes/manifest"
 	v1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func NewJob(m map[string]interface{}) *Job {
@@ -89,3 +90,27 @@ func (j *Job) Status() manifest.Status {
 
 	return s
 }
+
+// PodSelector returns the label selector of the pods created by the job.
+func (j *Job) PodSelector() string {
+	if j.j.Spec.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(j.j.Spec.Selector)
+		if err == nil && !selector.Empty() {
+			return selector.String()
+		}
+	}
+
+	return "job-name=" + j.j.Name
+}
+
+// FailedCondition returns the failed condition of the job,
+// or nil if the job has not failed.
+func (j *Job) FailedCondition() *v1.JobCondition {
+	for i, condition := range j.j.Status.Conditions {
+		if condition.Type == v1.JobFailed {
+			return &j.j.Status.Conditions[i]
+		}
+	}
+
+	return nil
+}
@@ -138,4 +138,68 @@ var _ = Describe("Job", func() {
 			})
 		})
 	})
+
+	Describe("#PodSelector", func() {
+		var selector string
+
+		BeforeEach(func() {
+			job.Object().Name = "test-job"
+		})
+
+		JustBeforeEach(func() {
+			selector = job.PodSelector()
+		})
+
+		When("the job has no selector", func() {
+			It("selects pods by job name", func() {
+				Expect(selector).To(Equal("job-name=test-job"))
+			})
+		})
+
+		When("the job has a selector", func() {
+			BeforeEach(func() {
+				job.Object().Spec.Selector = &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"controller-uid": "test-uid",
+					},
+				}
+			})
+
+			It("returns the selector", func() {
+				Expect(selector).To(Equal("controller-uid=test-uid"))
+			})
+		})
+	})
+
+	Describe("#FailedCondition", func() {
+		var condition *v1.JobCondition
+
+		JustBeforeEach(func() {
+			condition = job.FailedCondition()
+		})
+
+		When("the job has not failed", func() {
+			BeforeEach(func() {
+				job.Object().Status.Conditions = []v1.JobCondition{{Type: v1.JobComplete}}
+			})
+
+			It("returns nil", func() {
+				Expect(condition).To(BeNil())
+			})
+		})
+
+		When("the job failed", func() {
+			BeforeEach(func() {
+				job.Object().Status.Conditions = []v1.JobCondition{
+					{Type: v1.JobSuspended},
+					{Type: v1.JobFailed, Reason: "BackoffLimitExceeded"},
+				}
+			})
+
+			It("returns the failed condition", func() {
+				Expect(condition).ToNot(BeNil())
+				Expect(condition.Reason).To(Equal("BackoffLimitExceeded"))
+			})
+		})
+	})
 })
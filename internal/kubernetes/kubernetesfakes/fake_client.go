@@ -10,6 +10,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
 )
 
 type FakeClient struct {
@@ -50,6 +51,21 @@ type FakeClient struct {
 	discoverReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DryRunApplyStub        func(*unstructured.Unstructured) (*unstructured.Unstructured, *unstructured.Unstructured, error)
+	dryRunApplyMutex       sync.RWMutex
+	dryRunApplyArgsForCall []struct {
+		arg1 *unstructured.Unstructured
+	}
+	dryRunApplyReturns struct {
+		result1 *unstructured.Unstructured
+		result2 *unstructured.Unstructured
+		result3 error
+	}
+	dryRunApplyReturnsOnCall map[int]struct {
+		result1 *unstructured.Unstructured
+		result2 *unstructured.Unstructured
+		result3 error
+	}
 	GVRForKindStub        func(string) (schema.GroupVersionResource, error)
 	gVRForKindMutex       sync.RWMutex
 	gVRForKindArgsForCall []struct {
@@ -78,6 +94,10 @@ type FakeClient struct {
 		result1 *unstructured.Unstructured
 		result2 error
 	}
+	InvalidateDiscoveryStub        func()
+	invalidateDiscoveryMutex       sync.RWMutex
+	invalidateDiscoveryArgsForCall []struct {
+	}
 	ListByGVRStub        func(schema.GroupVersionResource, v1.ListOptions) (*unstructured.UnstructuredList, error)
 	listByGVRMutex       sync.RWMutex
 	listByGVRArgsForCall []struct {
@@ -167,6 +187,19 @@ type FakeClient struct {
 		result1 *unstructured.UnstructuredList
 		result2 error
 	}
+	NewInformerStub        func(schema.GroupVersionResource, string, string) cache.SharedIndexInformer
+	newInformerMutex       sync.RWMutex
+	newInformerArgsForCall []struct {
+		arg1 schema.GroupVersionResource
+		arg2 string
+		arg3 string
+	}
+	newInformerReturns struct {
+		result1 cache.SharedIndexInformer
+	}
+	newInformerReturnsOnCall map[int]struct {
+		result1 cache.SharedIndexInformer
+	}
 	PatchStub        func(string, string, string, []byte) (kubernetes.Metadata, *unstructured.Unstructured, error)
 	patchMutex       sync.RWMutex
 	patchArgsForCall []struct {
@@ -402,6 +435,73 @@ func (fake *FakeClient) DiscoverReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeClient) DryRunApply(arg1 *unstructured.Unstructured) (*unstructured.Unstructured, *unstructured.Unstructured, error) {
+	fake.dryRunApplyMutex.Lock()
+	ret, specificReturn := fake.dryRunApplyReturnsOnCall[len(fake.dryRunApplyArgsForCall)]
+	fake.dryRunApplyArgsForCall = append(fake.dryRunApplyArgsForCall, struct {
+		arg1 *unstructured.Unstructured
+	}{arg1})
+	stub := fake.DryRunApplyStub
+	fakeReturns := fake.dryRunApplyReturns
+	fake.recordInvocation("DryRunApply", []interface{}{arg1})
+	fake.dryRunApplyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeClient) DryRunApplyCallCount() int {
+	fake.dryRunApplyMutex.RLock()
+	defer fake.dryRunApplyMutex.RUnlock()
+	return len(fake.dryRunApplyArgsForCall)
+}
+
+func (fake *FakeClient) DryRunApplyCalls(stub func(*unstructured.Unstructured) (*unstructured.Unstructured, *unstructured.Unstructured, error)) {
+	fake.dryRunApplyMutex.Lock()
+	defer fake.dryRunApplyMutex.Unlock()
+	fake.DryRunApplyStub = stub
+}
+
+func (fake *FakeClient) DryRunApplyArgsForCall(i int) *unstructured.Unstructured {
+	fake.dryRunApplyMutex.RLock()
+	defer fake.dryRunApplyMutex.RUnlock()
+	argsForCall := fake.dryRunApplyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) DryRunApplyReturns(result1 *unstructured.Unstructured, result2 *unstructured.Unstructured, result3 error) {
+	fake.dryRunApplyMutex.Lock()
+	defer fake.dryRunApplyMutex.Unlock()
+	fake.DryRunApplyStub = nil
+	fake.dryRunApplyReturns = struct {
+		result1 *unstructured.Unstructured
+		result2 *unstructured.Unstructured
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) DryRunApplyReturnsOnCall(i int, result1 *unstructured.Unstructured, result2 *unstructured.Unstructured, result3 error) {
+	fake.dryRunApplyMutex.Lock()
+	defer fake.dryRunApplyMutex.Unlock()
+	fake.DryRunApplyStub = nil
+	if fake.dryRunApplyReturnsOnCall == nil {
+		fake.dryRunApplyReturnsOnCall = make(map[int]struct {
+			result1 *unstructured.Unstructured
+			result2 *unstructured.Unstructured
+			result3 error
+		})
+	}
+	fake.dryRunApplyReturnsOnCall[i] = struct {
+		result1 *unstructured.Unstructured
+		result2 *unstructured.Unstructured
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeClient) GVRForKind(arg1 string) (schema.GroupVersionResource, error) {
 	fake.gVRForKindMutex.Lock()
 	ret, specificReturn := fake.gVRForKindReturnsOnCall[len(fake.gVRForKindArgsForCall)]
@@ -532,6 +632,30 @@ func (fake *FakeClient) GetReturnsOnCall(i int, result1 *unstructured.Unstructur
 	}{result1, result2}
 }
 
+func (fake *FakeClient) InvalidateDiscovery() {
+	fake.invalidateDiscoveryMutex.Lock()
+	fake.invalidateDiscoveryArgsForCall = append(fake.invalidateDiscoveryArgsForCall, struct {
+	}{})
+	stub := fake.InvalidateDiscoveryStub
+	fake.recordInvocation("InvalidateDiscovery", []interface{}{})
+	fake.invalidateDiscoveryMutex.Unlock()
+	if stub != nil {
+		fake.InvalidateDiscoveryStub()
+	}
+}
+
+func (fake *FakeClient) InvalidateDiscoveryCallCount() int {
+	fake.invalidateDiscoveryMutex.RLock()
+	defer fake.invalidateDiscoveryMutex.RUnlock()
+	return len(fake.invalidateDiscoveryArgsForCall)
+}
+
+func (fake *FakeClient) InvalidateDiscoveryCalls(stub func()) {
+	fake.invalidateDiscoveryMutex.Lock()
+	defer fake.invalidateDiscoveryMutex.Unlock()
+	fake.InvalidateDiscoveryStub = stub
+}
+
 func (fake *FakeClient) ListByGVR(arg1 schema.GroupVersionResource, arg2 v1.ListOptions) (*unstructured.UnstructuredList, error) {
 	fake.listByGVRMutex.Lock()
 	ret, specificReturn := fake.listByGVRReturnsOnCall[len(fake.listByGVRArgsForCall)]
@@ -927,6 +1051,69 @@ func (fake *FakeClient) ListResourcesByKindAndNamespaceWithContextReturnsOnCall(
 	}{result1, result2}
 }
 
+func (fake *FakeClient) NewInformer(arg1 schema.GroupVersionResource, arg2 string, arg3 string) cache.SharedIndexInformer {
+	fake.newInformerMutex.Lock()
+	ret, specificReturn := fake.newInformerReturnsOnCall[len(fake.newInformerArgsForCall)]
+	fake.newInformerArgsForCall = append(fake.newInformerArgsForCall, struct {
+		arg1 schema.GroupVersionResource
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.NewInformerStub
+	fakeReturns := fake.newInformerReturns
+	fake.recordInvocation("NewInformer", []interface{}{arg1, arg2, arg3})
+	fake.newInformerMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) NewInformerCallCount() int {
+	fake.newInformerMutex.RLock()
+	defer fake.newInformerMutex.RUnlock()
+	return len(fake.newInformerArgsForCall)
+}
+
+func (fake *FakeClient) NewInformerCalls(stub func(schema.GroupVersionResource, string, string) cache.SharedIndexInformer) {
+	fake.newInformerMutex.Lock()
+	defer fake.newInformerMutex.Unlock()
+	fake.NewInformerStub = stub
+}
+
+func (fake *FakeClient) NewInformerArgsForCall(i int) (schema.GroupVersionResource, string, string) {
+	fake.newInformerMutex.RLock()
+	defer fake.newInformerMutex.RUnlock()
+	argsForCall := fake.newInformerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeClient) NewInformerReturns(result1 cache.SharedIndexInformer) {
+	fake.newInformerMutex.Lock()
+	defer fake.newInformerMutex.Unlock()
+	fake.NewInformerStub = nil
+	fake.newInformerReturns = struct {
+		result1 cache.SharedIndexInformer
+	}{result1}
+}
+
+func (fake *FakeClient) NewInformerReturnsOnCall(i int, result1 cache.SharedIndexInformer) {
+	fake.newInformerMutex.Lock()
+	defer fake.newInformerMutex.Unlock()
+	fake.NewInformerStub = nil
+	if fake.newInformerReturnsOnCall == nil {
+		fake.newInformerReturnsOnCall = make(map[int]struct {
+			result1 cache.SharedIndexInformer
+		})
+	}
+	fake.newInformerReturnsOnCall[i] = struct {
+		result1 cache.SharedIndexInformer
+	}{result1}
+}
+
 func (fake *FakeClient) Patch(arg1 string, arg2 string, arg3 string, arg4 []byte) (kubernetes.Metadata, *unstructured.Unstructured, error) {
 	var arg4Copy []byte
 	if arg4 != nil {
@@ -1151,10 +1338,14 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.deleteResourceByKindAndNameAndNamespaceMutex.RUnlock()
 	fake.discoverMutex.RLock()
 	defer fake.discoverMutex.RUnlock()
+	fake.dryRunApplyMutex.RLock()
+	defer fake.dryRunApplyMutex.RUnlock()
 	fake.gVRForKindMutex.RLock()
 	defer fake.gVRForKindMutex.RUnlock()
 	fake.getMutex.RLock()
 	defer fake.getMutex.RUnlock()
+	fake.invalidateDiscoveryMutex.RLock()
+	defer fake.invalidateDiscoveryMutex.RUnlock()
 	fake.listByGVRMutex.RLock()
 	defer fake.listByGVRMutex.RUnlock()
 	fake.listByGVRWithContextMutex.RLock()
@@ -1167,6 +1358,8 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.listResourcesByKindAndNamespaceMutex.RUnlock()
 	fake.listResourcesByKindAndNamespaceWithContextMutex.RLock()
 	defer fake.listResourcesByKindAndNamespaceWithContextMutex.RUnlock()
+	fake.newInformerMutex.RLock()
+	defer fake.newInformerMutex.RUnlock()
 	fake.patchMutex.RLock()
 	defer fake.patchMutex.RUnlock()
 	fake.patchUsingStrategyMutex.RLock()
@@ -3,6 +3,7 @@ package kubernetesfakes
 
 import (
 	"context"
+	"io"
 	"sync"
 
 	"github.com/homedepot/go-clouddriver/internal/kubernetes"
@@ -41,6 +42,34 @@ type FakeClientset struct {
 		result1 string
 		result2 error
 	}
+	ServerVersionStub        func() (string, error)
+	serverVersionMutex       sync.RWMutex
+	serverVersionArgsForCall []struct {
+	}
+	serverVersionReturns struct {
+		result1 string
+		result2 error
+	}
+	serverVersionReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	StreamPodLogsStub        func(context.Context, string, string, v1.PodLogOptions) (io.ReadCloser, error)
+	streamPodLogsMutex       sync.RWMutex
+	streamPodLogsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 v1.PodLogOptions
+	}
+	streamPodLogsReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	streamPodLogsReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -178,6 +207,129 @@ func (fake *FakeClientset) PodLogsReturnsOnCall(i int, result1 string, result2 e
 	}{result1, result2}
 }
 
+func (fake *FakeClientset) ServerVersion() (string, error) {
+	fake.serverVersionMutex.Lock()
+	ret, specificReturn := fake.serverVersionReturnsOnCall[len(fake.serverVersionArgsForCall)]
+	fake.serverVersionArgsForCall = append(fake.serverVersionArgsForCall, struct {
+	}{})
+	stub := fake.ServerVersionStub
+	fakeReturns := fake.serverVersionReturns
+	fake.recordInvocation("ServerVersion", []interface{}{})
+	fake.serverVersionMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClientset) ServerVersionCallCount() int {
+	fake.serverVersionMutex.RLock()
+	defer fake.serverVersionMutex.RUnlock()
+	return len(fake.serverVersionArgsForCall)
+}
+
+func (fake *FakeClientset) ServerVersionCalls(stub func() (string, error)) {
+	fake.serverVersionMutex.Lock()
+	defer fake.serverVersionMutex.Unlock()
+	fake.ServerVersionStub = stub
+}
+
+func (fake *FakeClientset) ServerVersionReturns(result1 string, result2 error) {
+	fake.serverVersionMutex.Lock()
+	defer fake.serverVersionMutex.Unlock()
+	fake.ServerVersionStub = nil
+	fake.serverVersionReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClientset) ServerVersionReturnsOnCall(i int, result1 string, result2 error) {
+	fake.serverVersionMutex.Lock()
+	defer fake.serverVersionMutex.Unlock()
+	fake.ServerVersionStub = nil
+	if fake.serverVersionReturnsOnCall == nil {
+		fake.serverVersionReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.serverVersionReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClientset) StreamPodLogs(arg1 context.Context, arg2 string, arg3 string, arg4 v1.PodLogOptions) (io.ReadCloser, error) {
+	fake.streamPodLogsMutex.Lock()
+	ret, specificReturn := fake.streamPodLogsReturnsOnCall[len(fake.streamPodLogsArgsForCall)]
+	fake.streamPodLogsArgsForCall = append(fake.streamPodLogsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 v1.PodLogOptions
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.StreamPodLogsStub
+	fakeReturns := fake.streamPodLogsReturns
+	fake.recordInvocation("StreamPodLogs", []interface{}{arg1, arg2, arg3, arg4})
+	fake.streamPodLogsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClientset) StreamPodLogsCallCount() int {
+	fake.streamPodLogsMutex.RLock()
+	defer fake.streamPodLogsMutex.RUnlock()
+	return len(fake.streamPodLogsArgsForCall)
+}
+
+func (fake *FakeClientset) StreamPodLogsCalls(stub func(context.Context, string, string, v1.PodLogOptions) (io.ReadCloser, error)) {
+	fake.streamPodLogsMutex.Lock()
+	defer fake.streamPodLogsMutex.Unlock()
+	fake.StreamPodLogsStub = stub
+}
+
+func (fake *FakeClientset) StreamPodLogsArgsForCall(i int) (context.Context, string, string, v1.PodLogOptions) {
+	fake.streamPodLogsMutex.RLock()
+	defer fake.streamPodLogsMutex.RUnlock()
+	argsForCall := fake.streamPodLogsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeClientset) StreamPodLogsReturns(result1 io.ReadCloser, result2 error) {
+	fake.streamPodLogsMutex.Lock()
+	defer fake.streamPodLogsMutex.Unlock()
+	fake.StreamPodLogsStub = nil
+	fake.streamPodLogsReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClientset) StreamPodLogsReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
+	fake.streamPodLogsMutex.Lock()
+	defer fake.streamPodLogsMutex.Unlock()
+	fake.StreamPodLogsStub = nil
+	if fake.streamPodLogsReturnsOnCall == nil {
+		fake.streamPodLogsReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 error
+		})
+	}
+	fake.streamPodLogsReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClientset) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -185,6 +337,10 @@ func (fake *FakeClientset) Invocations() map[string][][]interface{} {
 	defer fake.eventsMutex.RUnlock()
 	fake.podLogsMutex.RLock()
 	defer fake.podLogsMutex.RUnlock()
+	fake.serverVersionMutex.RLock()
+	defer fake.serverVersionMutex.RUnlock()
+	fake.streamPodLogsMutex.RLock()
+	defer fake.streamPodLogsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
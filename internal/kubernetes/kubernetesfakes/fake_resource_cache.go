@@ -0,0 +1,314 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package kubernetesfakes
+
+import (
+	"sync"
+
+	"github.com/homedepot/go-clouddriver/internal/kubernetes"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type FakeResourceCache struct {
+	ForgetStub        func(string)
+	forgetMutex       sync.RWMutex
+	forgetArgsForCall []struct {
+		arg1 string
+	}
+	ListStub        func(string, schema.GroupVersionResource, string) ([]unstructured.Unstructured, bool)
+	listMutex       sync.RWMutex
+	listArgsForCall []struct {
+		arg1 string
+		arg2 schema.GroupVersionResource
+		arg3 string
+	}
+	listReturns struct {
+		result1 []unstructured.Unstructured
+		result2 bool
+	}
+	listReturnsOnCall map[int]struct {
+		result1 []unstructured.Unstructured
+		result2 bool
+	}
+	StopStub        func()
+	stopMutex       sync.RWMutex
+	stopArgsForCall []struct {
+	}
+	WatchStub        func(string, schema.GroupVersionResource, string, kubernetes.Client)
+	watchMutex       sync.RWMutex
+	watchArgsForCall []struct {
+		arg1 string
+		arg2 schema.GroupVersionResource
+		arg3 string
+		arg4 kubernetes.Client
+	}
+	WatchingStub        func(string, schema.GroupVersionResource, string) bool
+	watchingMutex       sync.RWMutex
+	watchingArgsForCall []struct {
+		arg1 string
+		arg2 schema.GroupVersionResource
+		arg3 string
+	}
+	watchingReturns struct {
+		result1 bool
+	}
+	watchingReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeResourceCache) Forget(arg1 string) {
+	fake.forgetMutex.Lock()
+	fake.forgetArgsForCall = append(fake.forgetArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ForgetStub
+	fake.recordInvocation("Forget", []interface{}{arg1})
+	fake.forgetMutex.Unlock()
+	if stub != nil {
+		fake.ForgetStub(arg1)
+	}
+}
+
+func (fake *FakeResourceCache) ForgetCallCount() int {
+	fake.forgetMutex.RLock()
+	defer fake.forgetMutex.RUnlock()
+	return len(fake.forgetArgsForCall)
+}
+
+func (fake *FakeResourceCache) ForgetCalls(stub func(string)) {
+	fake.forgetMutex.Lock()
+	defer fake.forgetMutex.Unlock()
+	fake.ForgetStub = stub
+}
+
+func (fake *FakeResourceCache) ForgetArgsForCall(i int) string {
+	fake.forgetMutex.RLock()
+	defer fake.forgetMutex.RUnlock()
+	argsForCall := fake.forgetArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResourceCache) List(arg1 string, arg2 schema.GroupVersionResource, arg3 string) ([]unstructured.Unstructured, bool) {
+	fake.listMutex.Lock()
+	ret, specificReturn := fake.listReturnsOnCall[len(fake.listArgsForCall)]
+	fake.listArgsForCall = append(fake.listArgsForCall, struct {
+		arg1 string
+		arg2 schema.GroupVersionResource
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ListStub
+	fakeReturns := fake.listReturns
+	fake.recordInvocation("List", []interface{}{arg1, arg2, arg3})
+	fake.listMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResourceCache) ListCallCount() int {
+	fake.listMutex.RLock()
+	defer fake.listMutex.RUnlock()
+	return len(fake.listArgsForCall)
+}
+
+func (fake *FakeResourceCache) ListCalls(stub func(string, schema.GroupVersionResource, string) ([]unstructured.Unstructured, bool)) {
+	fake.listMutex.Lock()
+	defer fake.listMutex.Unlock()
+	fake.ListStub = stub
+}
+
+func (fake *FakeResourceCache) ListArgsForCall(i int) (string, schema.GroupVersionResource, string) {
+	fake.listMutex.RLock()
+	defer fake.listMutex.RUnlock()
+	argsForCall := fake.listArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeResourceCache) ListReturns(result1 []unstructured.Unstructured, result2 bool) {
+	fake.listMutex.Lock()
+	defer fake.listMutex.Unlock()
+	fake.ListStub = nil
+	fake.listReturns = struct {
+		result1 []unstructured.Unstructured
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeResourceCache) ListReturnsOnCall(i int, result1 []unstructured.Unstructured, result2 bool) {
+	fake.listMutex.Lock()
+	defer fake.listMutex.Unlock()
+	fake.ListStub = nil
+	if fake.listReturnsOnCall == nil {
+		fake.listReturnsOnCall = make(map[int]struct {
+			result1 []unstructured.Unstructured
+			result2 bool
+		})
+	}
+	fake.listReturnsOnCall[i] = struct {
+		result1 []unstructured.Unstructured
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeResourceCache) Stop() {
+	fake.stopMutex.Lock()
+	fake.stopArgsForCall = append(fake.stopArgsForCall, struct {
+	}{})
+	stub := fake.StopStub
+	fake.recordInvocation("Stop", []interface{}{})
+	fake.stopMutex.Unlock()
+	if stub != nil {
+		fake.StopStub()
+	}
+}
+
+func (fake *FakeResourceCache) StopCallCount() int {
+	fake.stopMutex.RLock()
+	defer fake.stopMutex.RUnlock()
+	return len(fake.stopArgsForCall)
+}
+
+func (fake *FakeResourceCache) StopCalls(stub func()) {
+	fake.stopMutex.Lock()
+	defer fake.stopMutex.Unlock()
+	fake.StopStub = stub
+}
+
+func (fake *FakeResourceCache) Watch(arg1 string, arg2 schema.GroupVersionResource, arg3 string, arg4 kubernetes.Client) {
+	fake.watchMutex.Lock()
+	fake.watchArgsForCall = append(fake.watchArgsForCall, struct {
+		arg1 string
+		arg2 schema.GroupVersionResource
+		arg3 string
+		arg4 kubernetes.Client
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.WatchStub
+	fake.recordInvocation("Watch", []interface{}{arg1, arg2, arg3, arg4})
+	fake.watchMutex.Unlock()
+	if stub != nil {
+		fake.WatchStub(arg1, arg2, arg3, arg4)
+	}
+}
+
+func (fake *FakeResourceCache) WatchCallCount() int {
+	fake.watchMutex.RLock()
+	defer fake.watchMutex.RUnlock()
+	return len(fake.watchArgsForCall)
+}
+
+func (fake *FakeResourceCache) WatchCalls(stub func(string, schema.GroupVersionResource, string, kubernetes.Client)) {
+	fake.watchMutex.Lock()
+	defer fake.watchMutex.Unlock()
+	fake.WatchStub = stub
+}
+
+func (fake *FakeResourceCache) WatchArgsForCall(i int) (string, schema.GroupVersionResource, string, kubernetes.Client) {
+	fake.watchMutex.RLock()
+	defer fake.watchMutex.RUnlock()
+	argsForCall := fake.watchArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeResourceCache) Watching(arg1 string, arg2 schema.GroupVersionResource, arg3 string) bool {
+	fake.watchingMutex.Lock()
+	ret, specificReturn := fake.watchingReturnsOnCall[len(fake.watchingArgsForCall)]
+	fake.watchingArgsForCall = append(fake.watchingArgsForCall, struct {
+		arg1 string
+		arg2 schema.GroupVersionResource
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.WatchingStub
+	fakeReturns := fake.watchingReturns
+	fake.recordInvocation("Watching", []interface{}{arg1, arg2, arg3})
+	fake.watchingMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResourceCache) WatchingCallCount() int {
+	fake.watchingMutex.RLock()
+	defer fake.watchingMutex.RUnlock()
+	return len(fake.watchingArgsForCall)
+}
+
+func (fake *FakeResourceCache) WatchingCalls(stub func(string, schema.GroupVersionResource, string) bool) {
+	fake.watchingMutex.Lock()
+	defer fake.watchingMutex.Unlock()
+	fake.WatchingStub = stub
+}
+
+func (fake *FakeResourceCache) WatchingArgsForCall(i int) (string, schema.GroupVersionResource, string) {
+	fake.watchingMutex.RLock()
+	defer fake.watchingMutex.RUnlock()
+	argsForCall := fake.watchingArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeResourceCache) WatchingReturns(result1 bool) {
+	fake.watchingMutex.Lock()
+	defer fake.watchingMutex.Unlock()
+	fake.WatchingStub = nil
+	fake.watchingReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeResourceCache) WatchingReturnsOnCall(i int, result1 bool) {
+	fake.watchingMutex.Lock()
+	defer fake.watchingMutex.Unlock()
+	fake.WatchingStub = nil
+	if fake.watchingReturnsOnCall == nil {
+		fake.watchingReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.watchingReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeResourceCache) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.forgetMutex.RLock()
+	defer fake.forgetMutex.RUnlock()
+	fake.listMutex.RLock()
+	defer fake.listMutex.RUnlock()
+	fake.stopMutex.RLock()
+	defer fake.stopMutex.RUnlock()
+	fake.watchMutex.RLock()
+	defer fake.watchMutex.RUnlock()
+	fake.watchingMutex.RLock()
+	defer fake.watchingMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeResourceCache) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ kubernetes.ResourceCache = new(FakeResourceCache)
@@ -9,10 +9,34 @@ import (
 const (
 	// https://kubernetes.io/docs/concepts/overview/working-with-objects/common-labels/
 	LabelKubernetesName           = `app.kubernetes.io/name`
+	LabelKubernetesInstance       = `app.kubernetes.io/instance`
+	LabelKubernetesPartOf         = `app.kubernetes.io/part-of`
 	LabelKubernetesManagedBy      = `app.kubernetes.io/managed-by`
 	LabelSpinnakerMonikerSequence = `moniker.spinnaker.io/sequence`
 )
 
+// searchableLabels are the labels recorded with a resource to be searched by.
+var searchableLabels = []string{
+	LabelKubernetesName,
+	LabelKubernetesInstance,
+	LabelKubernetesPartOf,
+}
+
+// SearchableLabels returns the searchable labels of a given unstructured
+// Kubernetes resource in the format "key=value", separated by commas.
+func SearchableLabels(u unstructured.Unstructured) string {
+	labels := u.GetLabels()
+	pairs := []string{}
+
+	for _, key := range searchableLabels {
+		if value, ok := labels[key]; ok {
+			pairs = append(pairs, key+"="+value)
+		}
+	}
+
+	return strings.Join(pairs, ",")
+}
+
 // AddSpinnakerLabels labels a given unstructured Kubernetes resource
 // with Spinnaker defined labels.
 func AddSpinnakerLabels(u *unstructured.Unstructured, application string) error {
@@ -517,4 +517,40 @@ var _ = Describe("Label", func() {
 			})
 		})
 	})
+
+	Context("#SearchableLabels", func() {
+		var labels string
+
+		BeforeEach(func() {
+			u = unstructured.Unstructured{Object: map[string]interface{}{}}
+		})
+
+		JustBeforeEach(func() {
+			labels = SearchableLabels(u)
+		})
+
+		When("the object has no searchable labels", func() {
+			BeforeEach(func() {
+				u.SetLabels(map[string]string{LabelKubernetesManagedBy: "spinnaker"})
+			})
+
+			It("returns an empty string", func() {
+				Expect(labels).To(BeEmpty())
+			})
+		})
+
+		When("the object has searchable labels", func() {
+			BeforeEach(func() {
+				u.SetLabels(map[string]string{
+					LabelKubernetesPartOf:    "test-system",
+					LabelKubernetesManagedBy: "spinnaker",
+					LabelKubernetesName:      "test-application",
+				})
+			})
+
+			It("returns them in order", func() {
+				Expect(labels).To(Equal("app.kubernetes.io/name=test-application,app.kubernetes.io/part-of=test-system"))
+			})
+		})
+	})
 })
@@ -0,0 +1,23 @@
+package kubernetes
+
+import (
+	"encoding/json"
+
+	v1 "k8s.io/api/networking/v1"
+)
+
+func NewNetworkPolicy(m map[string]interface{}) *NetworkPolicy {
+	np := &v1.NetworkPolicy{}
+	b, _ := json.Marshal(m)
+	_ = json.Unmarshal(b, &np)
+
+	return &NetworkPolicy{np: np}
+}
+
+type NetworkPolicy struct {
+	np *v1.NetworkPolicy
+}
+
+func (np *NetworkPolicy) Object() *v1.NetworkPolicy {
+	return np.np
+}
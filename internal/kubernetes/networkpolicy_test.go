@@ -0,0 +1,43 @@
+package kubernetes_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/homedepot/go-clouddriver/internal/kubernetes"
+)
+
+var _ = Describe("NetworkPolicy", func() {
+	var (
+		np *NetworkPolicy
+	)
+
+	BeforeEach(func() {
+		np = NewNetworkPolicy(map[string]interface{}{
+			"kind": "NetworkPolicy",
+			"metadata": map[string]interface{}{
+				"name": "test-policy",
+			},
+			"spec": map[string]interface{}{
+				"ingress": []interface{}{
+					map[string]interface{}{
+						"ports": []interface{}{
+							map[string]interface{}{
+								"port": 8080,
+							},
+						},
+					},
+				},
+			},
+		})
+	})
+
+	Describe("#Object", func() {
+		It("returns the network policy", func() {
+			o := np.Object()
+			Expect(o.Name).To(Equal("test-policy"))
+			Expect(o.Spec.Ingress).To(HaveLen(1))
+			Expect(o.Spec.Ingress[0].Ports[0].Port.IntValue()).To(Equal(8080))
+		})
+	})
+})
@@ -37,3 +37,20 @@ func (p *Pod) Status() manifest.Status {
 
 	return s
 }
+
+// FailedContainerState returns the terminated state of the first of the pod's
+// containers, init containers first, that exited with a non-zero code, or nil
+// if no container has failed.
+func (p *Pod) FailedContainerState() *v1.ContainerStateTerminated {
+	statuses := append([]v1.ContainerStatus{}, p.p.Status.InitContainerStatuses...)
+	statuses = append(statuses, p.p.Status.ContainerStatuses...)
+
+	for _, status := range statuses {
+		terminated := status.State.Terminated
+		if terminated != nil && terminated.ExitCode != 0 {
+			return terminated
+		}
+	}
+
+	return nil
+}
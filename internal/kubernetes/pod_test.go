@@ -68,4 +68,42 @@ var _ = Describe("Pod", func() {
 			})
 		})
 	})
+
+	Describe("#FailedContainerState", func() {
+		var terminated *v1.ContainerStateTerminated
+
+		JustBeforeEach(func() {
+			terminated = pod.FailedContainerState()
+		})
+
+		When("no container failed", func() {
+			BeforeEach(func() {
+				pod.Object().Status.ContainerStatuses = []v1.ContainerStatus{
+					{State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 0}}},
+					{State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}},
+				}
+			})
+
+			It("returns nil", func() {
+				Expect(terminated).To(BeNil())
+			})
+		})
+
+		When("containers failed", func() {
+			BeforeEach(func() {
+				pod.Object().Status.ContainerStatuses = []v1.ContainerStatus{
+					{State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 1, Reason: "Error"}}},
+				}
+				pod.Object().Status.InitContainerStatuses = []v1.ContainerStatus{
+					{State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 137, Reason: "OOMKilled"}}},
+				}
+			})
+
+			It("returns the state of the first failed init container", func() {
+				Expect(terminated).ToNot(BeNil())
+				Expect(terminated.ExitCode).To(Equal(int32(137)))
+				Expect(terminated.Reason).To(Equal("OOMKilled"))
+			})
+		})
+	})
 })
@@ -0,0 +1,365 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrPreconfiguredJobNotFound is returned when getting a preconfigured job
+// that has not been registered.
+var ErrPreconfiguredJobNotFound = errors.New("preconfigured job not found")
+
+// PreconfiguredJob is a named run job template registered by operators, see example below:
+//
+//	{
+//	  "name": "dbMigration",
+//	  "label": "Database Migration",
+//	  "description": "Migrates the database schema of an application.",
+//	  "waitForCompletion": true,
+//	  "account": "my-account",
+//	  "parameters": [
+//	    {
+//	      "name": "image",
+//	      "label": "Image",
+//	      "mapping": "spec.template.spec.containers[0].image",
+//	      "defaultValue": "gcr.io/my-project/migrate:latest"
+//	    },
+//	    {
+//	      "name": "backoffLimit",
+//	      "type": "int",
+//	      "mapping": "spec.backoffLimit",
+//	      "defaultValue": "0"
+//	    }
+//	  ],
+//	  "manifest": {
+//	    "apiVersion": "batch/v1",
+//	    "kind": "Job",
+//	    ...
+//	  }
+//	}
+type PreconfiguredJob struct {
+	// Name is the stage type the job is advertised as.
+	Name              string                      `json:"name"`
+	Label             string                      `json:"label"`
+	Description       string                      `json:"description"`
+	WaitForCompletion bool                        `json:"waitForCompletion"`
+	Parameters        []PreconfiguredJobParameter `json:"parameters"`
+	// Account, Application, PropertyFile and ProducesArtifacts are the defaults
+	// of the job's stage, used when the stage does not set them.
+	Account           string `json:"account"`
+	Application       string `json:"application"`
+	PropertyFile      string `json:"propertyFile"`
+	ProducesArtifacts bool   `json:"producesArtifacts"`
+	// Manifest is the base Job manifest the parameters are rendered into.
+	Manifest map[string]interface{} `json:"manifest"`
+}
+
+// PreconfiguredJobParameter maps a parameter supplied when running a
+// preconfigured job into a field of its manifest.
+type PreconfiguredJobParameter struct {
+	Name        string `json:"name"`
+	Label       string `json:"label"`
+	Description string `json:"description"`
+	// Type is the type a value is converted to before it is set in the
+	// manifest: 'string', the default, 'int', 'float' or 'bool'.
+	Type string `json:"type"`
+	// Mapping is the path of the manifest field the parameter sets, using dot
+	// notation for nested fields and brackets for list indexes, such as
+	// 'spec.template.spec.containers[0].image'.
+	Mapping      string `json:"mapping"`
+	DefaultValue string `json:"defaultValue"`
+	Order        int    `json:"order"`
+}
+
+// PreconfiguredJobs are the preconfigured jobs registered by operators.
+type PreconfiguredJobs []PreconfiguredJob
+
+// Get returns the preconfigured job with the given name.
+func (pjs PreconfiguredJobs) Get(name string) (PreconfiguredJob, error) {
+	for _, pj := range pjs {
+		if pj.Name == name {
+			return pj, nil
+		}
+	}
+
+	return PreconfiguredJob{}, fmt.Errorf("%w: %s", ErrPreconfiguredJobNotFound, name)
+}
+
+// ReadPreconfiguredJobs reads the preconfigured jobs defined in each file
+// of a directory, one job per file, sorted by name.
+func ReadPreconfiguredJobs(dir string) (PreconfiguredJobs, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	pjs := PreconfiguredJobs{}
+
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+
+		path := filepath.Join(dir, f.Name())
+
+		// Handle symlinks for ConfigMaps.
+		ln, err := filepath.EvalSymlinks(path)
+		if err == nil {
+			path = ln
+		}
+
+		b, err := os.ReadFile(path)
+		if err != nil {
+			// The 'file' might be a symlink to a dir when using kubernetes ConfigMaps.
+			continue
+		}
+
+		pj := PreconfiguredJob{}
+
+		err = json.Unmarshal(b, &pj)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing preconfigured job file %s: %w", path, err)
+		}
+
+		err = pj.validate()
+		if err != nil {
+			return nil, fmt.Errorf("invalid preconfigured job file %s: %w", path, err)
+		}
+
+		if _, err := pjs.Get(pj.Name); err == nil {
+			return nil, fmt.Errorf("duplicate preconfigured job listed: %s", pj.Name)
+		}
+
+		pjs = append(pjs, pj)
+	}
+
+	sort.Slice(pjs, func(i, j int) bool {
+		return pjs[i].Name < pjs[j].Name
+	})
+
+	return pjs, nil
+}
+
+func (pj PreconfiguredJob) validate() error {
+	if pj.Name == "" {
+		return errors.New("no \"name\" found")
+	}
+
+	if !strings.EqualFold(fmt.Sprint(pj.Manifest["kind"]), "job") {
+		return fmt.Errorf("preconfigured job %s manifest must be of kind Job", pj.Name)
+	}
+
+	names := map[string]bool{}
+
+	for _, p := range pj.Parameters {
+		if p.Name == "" {
+			return fmt.Errorf("preconfigured job %s has a parameter with no \"name\"", pj.Name)
+		}
+
+		if names[p.Name] {
+			return fmt.Errorf("preconfigured job %s has duplicate parameter %s", pj.Name, p.Name)
+		}
+
+		names[p.Name] = true
+
+		if _, err := parseMapping(p.Mapping); err != nil {
+			return fmt.Errorf("preconfigured job %s parameter %s: %w", pj.Name, p.Name, err)
+		}
+
+		if !contains(parameterTypes, strings.ToLower(p.Type)) {
+			return fmt.Errorf("preconfigured job %s parameter %s has unknown type %s", pj.Name, p.Name, p.Type)
+		}
+
+		if p.DefaultValue != "" {
+			if _, err := p.convert(p.DefaultValue); err != nil {
+				return fmt.Errorf("preconfigured job %s: %w", pj.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Render returns a copy of the job's manifest with each parameter's field set
+// to its supplied value, or its default value if none is supplied, converted
+// to the parameter's type. Parameters with neither leave their field as defined
+// in the manifest.
+func (pj PreconfiguredJob) Render(values map[string]string) (map[string]interface{}, error) {
+	for name := range values {
+		if !pj.hasParameter(name) {
+			return nil, fmt.Errorf("unknown parameter %s for preconfigured job %s", name, pj.Name)
+		}
+	}
+
+	// Copy the manifest so the template is never modified.
+	b, err := json.Marshal(pj.Manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	m := map[string]interface{}{}
+
+	err = json.Unmarshal(b, &m)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range pj.Parameters {
+		value, ok := values[p.Name]
+		if !ok {
+			if p.DefaultValue == "" {
+				continue
+			}
+
+			value = p.DefaultValue
+		}
+
+		v, err := p.convert(value)
+		if err != nil {
+			return nil, err
+		}
+
+		path, err := parseMapping(p.Mapping)
+		if err != nil {
+			return nil, err
+		}
+
+		err = setField(m, path, v)
+		if err != nil {
+			return nil, fmt.Errorf("error setting parameter %s at %s: %w", p.Name, p.Mapping, err)
+		}
+	}
+
+	return m, nil
+}
+
+func (pj PreconfiguredJob) hasParameter(name string) bool {
+	for _, p := range pj.Parameters {
+		if p.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parameterTypes are the types a parameter's value can be converted to.
+var parameterTypes = []string{"", "string", "int", "float", "bool"}
+
+// convert converts a value of the parameter to its type.
+func (p PreconfiguredJobParameter) convert(value string) (interface{}, error) {
+	var (
+		v   interface{}
+		err error
+	)
+
+	switch strings.ToLower(p.Type) {
+	case "int":
+		v, err = strconv.ParseInt(value, 10, 64)
+	case "float":
+		v, err = strconv.ParseFloat(value, 64)
+	case "bool":
+		v, err = strconv.ParseBool(value)
+	default:
+		v = value
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid value %q for parameter %s of type %s", value, p.Name, p.Type)
+	}
+
+	return v, nil
+}
+
+// parseMapping splits a mapping like 'spec.containers[0].image' into its
+// field names and list indexes.
+func parseMapping(mapping string) ([]interface{}, error) {
+	if mapping == "" {
+		return nil, errors.New("no \"mapping\" found")
+	}
+
+	path := []interface{}{}
+
+	for _, field := range strings.Split(mapping, ".") {
+		name := field
+		if i := strings.Index(field, "["); i >= 0 {
+			name = field[:i]
+		}
+
+		if name == "" {
+			return nil, fmt.Errorf("invalid mapping %s", mapping)
+		}
+
+		path = append(path, name)
+
+		for rest := field[len(name):]; rest != ""; {
+			end := strings.Index(rest, "]")
+			if rest[0] != '[' || end < 0 {
+				return nil, fmt.Errorf("invalid mapping %s", mapping)
+			}
+
+			index, err := strconv.Atoi(rest[1:end])
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid index in mapping %s", mapping)
+			}
+
+			path = append(path, index)
+			rest = rest[end+1:]
+		}
+	}
+
+	return path, nil
+}
+
+// setField sets the field at path in obj to value, creating any missing
+// objects along the way. Lists must already contain the indexes in path.
+func setField(obj interface{}, path []interface{}, value interface{}) error {
+	last := len(path) == 1
+
+	switch key := path[0].(type) {
+	case string:
+		m, ok := obj.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("field %s is not in an object", key)
+		}
+
+		if last {
+			m[key] = value
+			return nil
+		}
+
+		if _, ok := m[key]; !ok {
+			if _, isIndex := path[1].(int); isIndex {
+				return fmt.Errorf("list %s not found", key)
+			}
+
+			m[key] = map[string]interface{}{}
+		}
+
+		return setField(m[key], path[1:], value)
+	case int:
+		l, ok := obj.([]interface{})
+		if !ok {
+			return fmt.Errorf("index %d is not in a list", key)
+		}
+
+		if key >= len(l) {
+			return fmt.Errorf("index %d out of range", key)
+		}
+
+		if last {
+			l[key] = value
+			return nil
+		}
+
+		return setField(l[key], path[1:], value)
+	}
+
+	return nil
+}
@@ -0,0 +1,361 @@
+package kubernetes_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	. "github.com/homedepot/go-clouddriver/internal/kubernetes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const preconfiguredJobJSON = `{
+  "name": "test-job",
+  "label": "Test Job",
+  "parameters": [
+    {
+      "name": "image",
+      "mapping": "spec.template.spec.containers[0].image",
+      "defaultValue": "test-image:latest"
+    },
+    {
+      "name": "team",
+      "mapping": "metadata.labels.team"
+    }
+  ],
+  "manifest": {
+    "apiVersion": "batch/v1",
+    "kind": "Job",
+    "metadata": {
+      "name": "test-job"
+    },
+    "spec": {
+      "template": {
+        "spec": {
+          "containers": [
+            {
+              "name": "test-container",
+              "image": "test-image:v1"
+            }
+          ]
+        }
+      }
+    }
+  }
+}`
+
+var _ = Describe("PreconfiguredJob", func() {
+	Describe("#ReadPreconfiguredJobs", func() {
+		var (
+			dir string
+			pjs PreconfiguredJobs
+			err error
+		)
+
+		BeforeEach(func() {
+			dir, err = os.MkdirTemp("", "preconfigured-jobs")
+			Expect(err).To(BeNil())
+			err = os.WriteFile(filepath.Join(dir, "test-job.json"), []byte(preconfiguredJobJSON), 0600)
+			Expect(err).To(BeNil())
+			err = os.WriteFile(filepath.Join(dir, "another-job.json"),
+				[]byte(`{"name": "another-job", "manifest": {"kind": "Job"}}`), 0600)
+			Expect(err).To(BeNil())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		JustBeforeEach(func() {
+			pjs, err = ReadPreconfiguredJobs(dir)
+		})
+
+		When("the directory does not exist", func() {
+			BeforeEach(func() {
+				os.RemoveAll(dir)
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("a file is not valid JSON", func() {
+			BeforeEach(func() {
+				err = os.WriteFile(filepath.Join(dir, "bad-job.json"), []byte("{"), 0600)
+				Expect(err).To(BeNil())
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(HavePrefix("error parsing preconfigured job file"))
+			})
+		})
+
+		When("a job has no name", func() {
+			BeforeEach(func() {
+				err = os.WriteFile(filepath.Join(dir, "bad-job.json"), []byte(`{"manifest": {"kind": "Job"}}`), 0600)
+				Expect(err).To(BeNil())
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(HaveSuffix(`no "name" found`))
+			})
+		})
+
+		When("a job's manifest is not a Job", func() {
+			BeforeEach(func() {
+				err = os.WriteFile(filepath.Join(dir, "bad-job.json"),
+					[]byte(`{"name": "bad-job", "manifest": {"kind": "Pod"}}`), 0600)
+				Expect(err).To(BeNil())
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(HaveSuffix("preconfigured job bad-job manifest must be of kind Job"))
+			})
+		})
+
+		When("a parameter has an invalid mapping", func() {
+			BeforeEach(func() {
+				err = os.WriteFile(filepath.Join(dir, "bad-job.json"),
+					[]byte(`{"name": "bad-job", "manifest": {"kind": "Job"},
+					"parameters": [{"name": "image", "mapping": "spec.containers[a].image"}]}`), 0600)
+				Expect(err).To(BeNil())
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(HaveSuffix("preconfigured job bad-job parameter image: invalid index in mapping spec.containers[a].image"))
+			})
+		})
+
+		When("a parameter has no mapping", func() {
+			BeforeEach(func() {
+				err = os.WriteFile(filepath.Join(dir, "bad-job.json"),
+					[]byte(`{"name": "bad-job", "manifest": {"kind": "Job"}, "parameters": [{"name": "image"}]}`), 0600)
+				Expect(err).To(BeNil())
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(HaveSuffix(`preconfigured job bad-job parameter image: no "mapping" found`))
+			})
+		})
+
+		When("a parameter has an unknown type", func() {
+			BeforeEach(func() {
+				err = os.WriteFile(filepath.Join(dir, "bad-job.json"),
+					[]byte(`{"name": "bad-job", "manifest": {"kind": "Job"},
+					"parameters": [{"name": "retries", "type": "list", "mapping": "spec.backoffLimit"}]}`), 0600)
+				Expect(err).To(BeNil())
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(HaveSuffix("preconfigured job bad-job parameter retries has unknown type list"))
+			})
+		})
+
+		When("a parameter's default value does not match its type", func() {
+			BeforeEach(func() {
+				err = os.WriteFile(filepath.Join(dir, "bad-job.json"),
+					[]byte(`{"name": "bad-job", "manifest": {"kind": "Job"},
+					"parameters": [{"name": "retries", "type": "int", "mapping": "spec.backoffLimit", "defaultValue": "none"}]}`), 0600)
+				Expect(err).To(BeNil())
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(HaveSuffix(`preconfigured job bad-job: invalid value "none" for parameter retries of type int`))
+			})
+		})
+
+		When("a job is listed twice", func() {
+			BeforeEach(func() {
+				err = os.WriteFile(filepath.Join(dir, "test-job-copy.json"), []byte(preconfiguredJobJSON), 0600)
+				Expect(err).To(BeNil())
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("duplicate preconfigured job listed: test-job"))
+			})
+		})
+
+		When("the directory contains a subdirectory", func() {
+			BeforeEach(func() {
+				err = os.Mkdir(filepath.Join(dir, "..data"), 0700)
+				Expect(err).To(BeNil())
+			})
+
+			It("ignores it", func() {
+				Expect(err).To(BeNil())
+				Expect(pjs).To(HaveLen(2))
+			})
+		})
+
+		When("it succeeds", func() {
+			It("returns the jobs sorted by name", func() {
+				Expect(err).To(BeNil())
+				Expect(pjs).To(HaveLen(2))
+				Expect(pjs[0].Name).To(Equal("another-job"))
+				Expect(pjs[1].Name).To(Equal("test-job"))
+				Expect(pjs[1].Label).To(Equal("Test Job"))
+				Expect(pjs[1].Parameters).To(HaveLen(2))
+			})
+		})
+	})
+
+	Describe("#Get", func() {
+		var (
+			pjs PreconfiguredJobs
+			pj  PreconfiguredJob
+			err error
+		)
+
+		BeforeEach(func() {
+			pjs = PreconfiguredJobs{{Name: "test-job"}}
+		})
+
+		When("the job is not registered", func() {
+			BeforeEach(func() {
+				pj, err = pjs.Get("missing-job")
+			})
+
+			It("returns an error", func() {
+				Expect(errors.Is(err, ErrPreconfiguredJobNotFound)).To(BeTrue())
+				Expect(err.Error()).To(Equal("preconfigured job not found: missing-job"))
+			})
+		})
+
+		When("the job is registered", func() {
+			BeforeEach(func() {
+				pj, err = pjs.Get("test-job")
+			})
+
+			It("returns the job", func() {
+				Expect(err).To(BeNil())
+				Expect(pj.Name).To(Equal("test-job"))
+			})
+		})
+	})
+
+	Describe("#Render", func() {
+		var (
+			pj       PreconfiguredJob
+			values   map[string]string
+			manifest map[string]interface{}
+			err      error
+		)
+
+		BeforeEach(func() {
+			dir, err := os.MkdirTemp("", "preconfigured-jobs")
+			Expect(err).To(BeNil())
+			defer os.RemoveAll(dir)
+			err = os.WriteFile(filepath.Join(dir, "test-job.json"), []byte(preconfiguredJobJSON), 0600)
+			Expect(err).To(BeNil())
+			pjs, err := ReadPreconfiguredJobs(dir)
+			Expect(err).To(BeNil())
+			pj = pjs[0]
+			values = map[string]string{}
+		})
+
+		JustBeforeEach(func() {
+			manifest, err = pj.Render(values)
+		})
+
+		image := func(m map[string]interface{}) interface{} {
+			spec := m["spec"].(map[string]interface{})["template"].(map[string]interface{})["spec"].(map[string]interface{})
+			return spec["containers"].([]interface{})[0].(map[string]interface{})["image"]
+		}
+
+		When("an unknown parameter is supplied", func() {
+			BeforeEach(func() {
+				values["unknown"] = "value"
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("unknown parameter unknown for preconfigured job test-job"))
+			})
+		})
+
+		When("a mapping indexes past the end of a list", func() {
+			BeforeEach(func() {
+				pj.Parameters[0].Mapping = "spec.template.spec.containers[1].image"
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("error setting parameter image at spec.template.spec.containers[1].image: index 1 out of range"))
+			})
+		})
+
+		When("a mapping indexes a field that is not a list", func() {
+			BeforeEach(func() {
+				pj.Parameters[0].Mapping = "metadata.name[0]"
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("error setting parameter image at metadata.name[0]: index 0 is not in a list"))
+			})
+		})
+
+		When("no values are supplied", func() {
+			It("renders the default values", func() {
+				Expect(err).To(BeNil())
+				Expect(image(manifest)).To(Equal("test-image:latest"))
+				Expect(manifest["metadata"]).ToNot(HaveKey("labels"))
+			})
+		})
+
+		When("parameters have types", func() {
+			BeforeEach(func() {
+				pj.Parameters = append(pj.Parameters,
+					PreconfiguredJobParameter{Name: "backoffLimit", Type: "int", Mapping: "spec.backoffLimit"},
+					PreconfiguredJobParameter{Name: "suspend", Type: "bool", Mapping: "spec.suspend"},
+					PreconfiguredJobParameter{Name: "ratio", Type: "float", Mapping: "metadata.annotations.ratio"},
+				)
+				values["backoffLimit"] = "3"
+				values["suspend"] = "true"
+				values["ratio"] = "0.5"
+			})
+
+			It("converts the values to their types", func() {
+				Expect(err).To(BeNil())
+				Expect(manifest["spec"]).To(HaveKeyWithValue("backoffLimit", int64(3)))
+				Expect(manifest["spec"]).To(HaveKeyWithValue("suspend", true))
+				Expect(manifest["metadata"]).To(HaveKeyWithValue("annotations", map[string]interface{}{"ratio": 0.5}))
+			})
+
+			When("a value does not match its type", func() {
+				BeforeEach(func() {
+					values["backoffLimit"] = "three"
+				})
+
+				It("returns an error", func() {
+					Expect(err).ToNot(BeNil())
+					Expect(err.Error()).To(Equal(`invalid value "three" for parameter backoffLimit of type int`))
+				})
+			})
+		})
+
+		When("values are supplied", func() {
+			BeforeEach(func() {
+				values["image"] = "test-image:v2"
+				values["team"] = "test-team"
+			})
+
+			It("renders the values without changing the template", func() {
+				Expect(err).To(BeNil())
+				Expect(image(manifest)).To(Equal("test-image:v2"))
+				Expect(manifest["metadata"]).To(HaveKeyWithValue("labels", map[string]interface{}{"team": "test-team"}))
+				Expect(image(pj.Manifest)).To(Equal("test-image:v1"))
+			})
+		})
+	})
+})
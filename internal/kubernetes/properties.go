@@ -0,0 +1,47 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// Markers a job writes to its logs to output properties
+	// for Spinnaker to consume, matched as OSS Clouddriver does.
+	propertyRegexp   = regexp.MustCompile(`^\s*SPINNAKER_PROPERTY_(\S+)=(.*)$`)
+	configJSONRegexp = regexp.MustCompile(`^\s*SPINNAKER_CONFIG_JSON=(.*)$`)
+)
+
+// ParseOutputProperties returns the properties output to a container's logs.
+// A 'SPINNAKER_PROPERTY_<NAME>=<value>' line sets the property <name>, lowercased,
+// and a 'SPINNAKER_CONFIG_JSON=<object>' line sets each property of the JSON object.
+// Properties set by later lines replace those set by earlier lines.
+func ParseOutputProperties(logs string) (map[string]interface{}, error) {
+	properties := map[string]interface{}{}
+
+	for _, line := range strings.Split(logs, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+
+		if m := propertyRegexp.FindStringSubmatch(line); m != nil {
+			properties[strings.ToLower(m[1])] = m[2]
+			continue
+		}
+
+		if m := configJSONRegexp.FindStringSubmatch(line); m != nil {
+			config := map[string]interface{}{}
+
+			err := json.Unmarshal([]byte(m[1]), &config)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing SPINNAKER_CONFIG_JSON: %w", err)
+			}
+
+			for k, v := range config {
+				properties[k] = v
+			}
+		}
+	}
+
+	return properties, nil
+}
@@ -0,0 +1,60 @@
+package kubernetes_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/homedepot/go-clouddriver/internal/kubernetes"
+)
+
+var _ = Describe("Properties", func() {
+	Describe("#ParseOutputProperties", func() {
+		var (
+			logs       string
+			properties map[string]interface{}
+		)
+
+		BeforeEach(func() {
+			logs = "starting job\n" +
+				"SPINNAKER_PROPERTY_IMAGE_TAG=v1.2.3\r\n" +
+				"  SPINNAKER_PROPERTY_Message=hello = world\n" +
+				"SPINNAKER_CONFIG_JSON={\"replicas\": 3, \"image_tag\": \"v1.2.4\"}\n" +
+				"echo SPINNAKER_PROPERTY_IGNORED=true\n"
+		})
+
+		JustBeforeEach(func() {
+			properties, err = ParseOutputProperties(logs)
+		})
+
+		When("the config JSON is invalid", func() {
+			BeforeEach(func() {
+				logs = "SPINNAKER_CONFIG_JSON=[1, 2]"
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(HavePrefix("error parsing SPINNAKER_CONFIG_JSON: "))
+			})
+		})
+
+		When("there are no properties", func() {
+			BeforeEach(func() {
+				logs = "nothing to see here"
+			})
+
+			It("returns no properties", func() {
+				Expect(err).To(BeNil())
+				Expect(properties).To(BeEmpty())
+			})
+		})
+
+		It("returns the properties, later lines replacing earlier lines", func() {
+			Expect(err).To(BeNil())
+			Expect(properties).To(Equal(map[string]interface{}{
+				"image_tag": "v1.2.4",
+				"message":   "hello = world",
+				"replicas":  float64(3),
+			}))
+		})
+	})
+})
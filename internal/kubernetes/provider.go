@@ -23,14 +23,22 @@ var (
 )
 
 type Provider struct {
-	Name          string              `json:"name" gorm:"primary_key"`
-	Host          string              `json:"host"`
-	CAData        string              `json:"caData" gorm:"type:text"`
-	BearerToken   string              `json:"bearerToken,omitempty" gorm:"size:2048"`
-	TokenProvider string              `json:"tokenProvider,omitempty" gorm:"size:128;not null;default:'google'"`
-	Namespace     *string             `json:"namespace,omitempty" gorm:"size:253"`
-	Namespaces    []string            `json:"namespaces,omitempty" gorm:"-"`
-	Permissions   ProviderPermissions `json:"permissions" gorm:"-"`
+	Name          string `json:"name" gorm:"primary_key"`
+	Host          string `json:"host"`
+	CAData        string `json:"caData" gorm:"type:text"`
+	BearerToken   string `json:"bearerToken,omitempty" gorm:"type:text"`
+	TokenProvider string `json:"tokenProvider,omitempty" gorm:"size:128;not null;default:'google'"`
+	// Credentials of the credential sources selected by TokenProvider.
+	ClientCertificateData string        `json:"clientCertificateData,omitempty" gorm:"type:text"`
+	ClientKeyData         string        `json:"clientKeyData,omitempty" gorm:"type:text"`
+	Kubeconfig            string        `json:"kubeconfig,omitempty" gorm:"size:1024"`
+	Exec                  *ProviderExec `json:"exec,omitempty" gorm:"type:text;serializer:json"`
+	Namespace             *string       `json:"namespace,omitempty" gorm:"size:253"`
+	// Version is incremented on every update of the provider and
+	// served as its ETag to detect concurrent updates.
+	Version     int64               `json:"-" gorm:"not null;default:1"`
+	Namespaces  []string            `json:"namespaces,omitempty" gorm:"-"`
+	Permissions ProviderPermissions `json:"permissions" gorm:"-"`
 	// Providers can hold instances of clients.
 	Client    Client    `json:"-" gorm:"-"`
 	Clientset Clientset `json:"-" gorm:"-"`
@@ -45,6 +53,49 @@ func (Provider) TableName() string {
 	return "kubernetes_providers"
 }
 
+// ProviderPatch holds the fields of a provider to update.
+// Fields that are not set are left as they are.
+type ProviderPatch struct {
+	Host        *string                   `json:"host,omitempty"`
+	CAData      *string                   `json:"caData,omitempty"`
+	Namespaces  *[]string                 `json:"namespaces,omitempty"`
+	Permissions *ProviderPermissionsPatch `json:"permissions,omitempty"`
+}
+
+// ProviderPermissionsPatch holds the permission groups of a provider to replace.
+type ProviderPermissionsPatch struct {
+	Read  *[]string `json:"read,omitempty"`
+	Write *[]string `json:"write,omitempty"`
+}
+
+// Apply returns the provider with the patch's fields set.
+func (pp ProviderPatch) Apply(p Provider) Provider {
+	if pp.Host != nil {
+		p.Host = *pp.Host
+	}
+
+	if pp.CAData != nil {
+		p.CAData = *pp.CAData
+	}
+
+	if pp.Namespaces != nil {
+		p.Namespace = nil
+		p.Namespaces = *pp.Namespaces
+	}
+
+	if pp.Permissions != nil {
+		if pp.Permissions.Read != nil {
+			p.Permissions.Read = *pp.Permissions.Read
+		}
+
+		if pp.Permissions.Write != nil {
+			p.Permissions.Write = *pp.Permissions.Write
+		}
+	}
+
+	return p
+}
+
 type ProviderNamespaces struct {
 	// ID          string `json:"-" gorm:"primary_key"`
 	AccountName string `json:"accountName" gorm:"index:account_name_namespace_idx,unique"`
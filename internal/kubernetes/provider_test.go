@@ -64,4 +64,59 @@ var _ = Describe("Provider", func() {
 			})
 		})
 	})
+
+	Context("#Apply", func() {
+		var patch ProviderPatch
+
+		BeforeEach(func() {
+			legacy := "legacy-namespace"
+			provider = Provider{
+				Name:      "test-name",
+				Host:      "test-host",
+				CAData:    "test-ca-data",
+				Namespace: &legacy,
+				Permissions: ProviderPermissions{
+					Read:  []string{"test-read-group"},
+					Write: []string{"test-write-group"},
+				},
+			}
+			patch = ProviderPatch{}
+		})
+
+		JustBeforeEach(func() {
+			provider = patch.Apply(provider)
+		})
+
+		When("no fields are set", func() {
+			It("leaves the provider as it is", func() {
+				Expect(provider.Host).To(Equal("test-host"))
+				Expect(provider.CAData).To(Equal("test-ca-data"))
+				Expect(*provider.Namespace).To(Equal("legacy-namespace"))
+				Expect(provider.Permissions.Read).To(Equal([]string{"test-read-group"}))
+				Expect(provider.Permissions.Write).To(Equal([]string{"test-write-group"}))
+			})
+		})
+
+		When("fields are set", func() {
+			BeforeEach(func() {
+				host := "new-host"
+				namespaces := []string{"n1", "n2"}
+				write := []string{}
+				patch.Host = &host
+				patch.Namespaces = &namespaces
+				patch.Permissions = &ProviderPermissionsPatch{
+					Write: &write,
+				}
+			})
+
+			It("replaces them", func() {
+				Expect(provider.Host).To(Equal("new-host"))
+				Expect(provider.CAData).To(Equal("test-ca-data"))
+				Expect(provider.Namespace).To(BeNil())
+				Expect(provider.Namespaces).To(Equal([]string{"n1", "n2"}))
+				Expect(provider.Permissions.Read).To(Equal([]string{"test-read-group"}))
+				Expect(provider.Permissions.Write).To(BeEmpty())
+			})
+		})
+	})
 })
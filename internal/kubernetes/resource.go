@@ -1,6 +1,11 @@
 package kubernetes
 
-import "time"
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
 
 type Resource struct {
 	AccountName  string    `json:"accountName" gorm:"index:account_name_kind_name_spinnaker_app_idx,priority:1"`
@@ -17,8 +22,29 @@ type Resource struct {
 	Kind         string    `json:"kind" gorm:"index:account_name_kind_name_spinnaker_app_idx,priority:2;index:kind_idx"`
 	SpinnakerApp string    `json:"spinnakerApp" gorm:"index:account_name_kind_name_spinnaker_app_idx,priority:4"`
 	Cluster      string    `json:"-"`
+	Labels       string    `json:"-" gorm:"type:text"`
 }
 
 func (Resource) TableName() string {
 	return "kubernetes_resources"
 }
+
+// NewResource maps a live object of a resource to an entry of the resources table.
+// The ID, task ID and timestamp are left for the caller to set.
+func NewResource(account string, gvr schema.GroupVersionResource, u unstructured.Unstructured) Resource {
+	nameWithoutVersion := NameWithoutVersion(u.GetName())
+
+	return Resource{
+		AccountName:  account,
+		APIGroup:     gvr.Group,
+		Name:         u.GetName(),
+		ArtifactName: nameWithoutVersion,
+		Namespace:    u.GetNamespace(),
+		Resource:     gvr.Resource,
+		Version:      gvr.Version,
+		Kind:         u.GetKind(),
+		SpinnakerApp: SpinnakerMonikerApplication(u),
+		Cluster:      Cluster(u.GetKind(), nameWithoutVersion),
+		Labels:       SearchableLabels(u),
+	}
+}
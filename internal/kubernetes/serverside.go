@@ -0,0 +1,207 @@
+package kubernetes
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	clouddriver "github.com/homedepot/go-clouddriver/pkg"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/csaupgrade"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+)
+
+// kubectlClientSideFieldManager is the field manager kubectl uses for client-side applies.
+const kubectlClientSideFieldManager = `kubectl-client-side-apply`
+
+// ConflictError is returned when a server-side apply fails because
+// fields in the manifest are owned by other field managers.
+type ConflictError struct {
+	Conflicts []clouddriver.FieldConflict
+}
+
+func (e *ConflictError) Error() string {
+	fields := []string{}
+
+	for _, conflict := range e.Conflicts {
+		fields = append(fields, fmt.Sprintf("%s (managers: %s)", conflict.Field, strings.Join(conflict.Managers, ", ")))
+	}
+
+	return fmt.Sprintf("server-side apply failed with %d conflict(s): %s", len(e.Conflicts), strings.Join(fields, "; "))
+}
+
+// NewConflictError returns a *ConflictError describing the field manager
+// conflicts of a failed server-side apply, or the given error if it was not
+// caused by conflicts.
+func NewConflictError(err error) error {
+	var statusErr *k8serrors.StatusError
+	if !errors.As(err, &statusErr) || statusErr.ErrStatus.Details == nil {
+		return err
+	}
+
+	managers := map[string][]string{}
+	fields := []string{}
+
+	for _, cause := range statusErr.ErrStatus.Details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+
+		if _, ok := managers[cause.Field]; !ok {
+			fields = append(fields, cause.Field)
+		}
+
+		managers[cause.Field] = append(managers[cause.Field], conflictManager(cause.Message))
+	}
+
+	if len(fields) == 0 {
+		return err
+	}
+
+	ce := &ConflictError{}
+	for _, field := range fields {
+		ce.Conflicts = append(ce.Conflicts, clouddriver.FieldConflict{
+			Field:    field,
+			Managers: managers[field],
+		})
+	}
+
+	return ce
+}
+
+// conflictManager returns the field manager named in a conflict cause message,
+// for example `conflict with "kubectl-client-side-apply" using apps/v1`.
+func conflictManager(message string) string {
+	a := strings.Split(message, `"`)
+	if len(a) < 3 {
+		return message
+	}
+
+	return a[1]
+}
+
+// clientSideFieldManagers returns the field managers that own fields set by
+// client-side applies: kubectl's and this client's default field manager, which
+// the API server derives from the client's user agent.
+func (c *client) clientSideFieldManagers() sets.Set[string] {
+	userAgent := c.config.UserAgent
+	if userAgent == "" {
+		userAgent = rest.DefaultKubernetesUserAgent()
+	}
+
+	return sets.New(kubectlClientSideFieldManager, strings.SplitN(userAgent, "/", 2)[0])
+}
+
+// migrateToServerSideApply moves ownership of the fields of a live object
+// previously deployed with a client-side apply to the "spinnaker" field manager,
+// so the first server-side apply doesn't conflict with the client-side managers
+// and removes fields, such as the last-applied-configuration annotation,
+// that are no longer in the manifest.
+//
+// Objects that don't exist or were never client-side applied are left alone.
+func (c *client) migrateToServerSideApply(helper *resource.Helper, namespace, name string) error {
+	obj, err := helper.Get(namespace, name)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	live, err := toUnstructuredObject(obj)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := live.GetAnnotations()[corev1.LastAppliedConfigAnnotation]; !ok {
+		return nil
+	}
+
+	patch, err := csaupgrade.UpgradeManagedFieldsPatch(live, c.clientSideFieldManagers(), spinnaker)
+	if err != nil {
+		return err
+	}
+
+	if patch == nil {
+		return nil
+	}
+
+	_, err = helper.Patch(namespace, name, types.JSONPatchType, patch, nil)
+
+	return err
+}
+
+// removeLastAppliedAnnotation removes the annotation used by client-side applies
+// so that it is not included in a server-side apply.
+func removeLastAppliedAnnotation(u *unstructured.Unstructured) {
+	annotations := u.GetAnnotations()
+	if _, ok := annotations[corev1.LastAppliedConfigAnnotation]; !ok {
+		return
+	}
+
+	delete(annotations, corev1.LastAppliedConfigAnnotation)
+
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+
+	u.SetAnnotations(annotations)
+}
+
+// ManagedFields are the fields of an object owned by a field manager.
+type ManagedFields struct {
+	Manager     string    `json:"manager"`
+	Operation   string    `json:"operation"`
+	APIVersion  string    `json:"apiVersion"`
+	Subresource string    `json:"subresource,omitempty"`
+	Time        time.Time `json:"time"`
+	Fields      []string  `json:"fields"`
+}
+
+// ListManagedFields returns the fields owned by each field manager of a given object.
+func ListManagedFields(u *unstructured.Unstructured) ([]ManagedFields, error) {
+	mfs := []ManagedFields{}
+
+	for _, entry := range u.GetManagedFields() {
+		mf := ManagedFields{
+			Manager:     entry.Manager,
+			Operation:   string(entry.Operation),
+			APIVersion:  entry.APIVersion,
+			Subresource: entry.Subresource,
+			Fields:      []string{},
+		}
+
+		if entry.Time != nil {
+			mf.Time = entry.Time.Time
+		}
+
+		if entry.FieldsV1 != nil {
+			set := &fieldpath.Set{}
+
+			err := set.FromJSON(strings.NewReader(string(entry.FieldsV1.Raw)))
+			if err != nil {
+				return nil, fmt.Errorf("error decoding fields of manager %s: %w", entry.Manager, err)
+			}
+
+			set.Leaves().Iterate(func(p fieldpath.Path) {
+				mf.Fields = append(mf.Fields, p.String())
+			})
+
+			sort.Strings(mf.Fields)
+		}
+
+		mfs = append(mfs, mf)
+	}
+
+	return mfs, nil
+}
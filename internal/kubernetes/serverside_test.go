@@ -0,0 +1,156 @@
+package kubernetes_test
+
+import (
+	"errors"
+	"time"
+
+	clouddriver "github.com/homedepot/go-clouddriver/pkg"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	. "github.com/homedepot/go-clouddriver/internal/kubernetes"
+)
+
+var _ = Describe("Serverside", func() {
+	Describe("#NewConflictError", func() {
+		var (
+			statusErr *k8serrors.StatusError
+			err       error
+		)
+
+		BeforeEach(func() {
+			statusErr = k8serrors.NewApplyConflict([]metav1.StatusCause{
+				{
+					Type:    metav1.CauseTypeFieldManagerConflict,
+					Message: `conflict with "kubectl-client-side-apply" using apps/v1`,
+					Field:   ".spec.replicas",
+				},
+				{
+					Type:    metav1.CauseTypeFieldManagerConflict,
+					Message: `conflict with "hpa-controller" using autoscaling/v2`,
+					Field:   ".spec.replicas",
+				},
+				{
+					Type:    metav1.CauseTypeFieldManagerConflict,
+					Message: `conflict with "kubectl-client-side-apply" using apps/v1`,
+					Field:   ".metadata.labels.app",
+				},
+			}, "Apply failed with 3 conflicts")
+		})
+
+		JustBeforeEach(func() {
+			err = NewConflictError(statusErr)
+		})
+
+		When("the error is not caused by conflicts", func() {
+			BeforeEach(func() {
+				statusErr = k8serrors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"},
+					"test-deployment", errors.New("the object has been modified"))
+			})
+
+			It("returns the error", func() {
+				Expect(err).To(Equal(statusErr))
+			})
+		})
+
+		When("it succeeds", func() {
+			It("returns the conflicting fields and their managers", func() {
+				var ce *ConflictError
+				Expect(errors.As(err, &ce)).To(BeTrue())
+				Expect(ce.Conflicts).To(Equal([]clouddriver.FieldConflict{
+					{
+						Field:    ".spec.replicas",
+						Managers: []string{"kubectl-client-side-apply", "hpa-controller"},
+					},
+					{
+						Field:    ".metadata.labels.app",
+						Managers: []string{"kubectl-client-side-apply"},
+					},
+				}))
+				Expect(err.Error()).To(Equal("server-side apply failed with 2 conflict(s): " +
+					".spec.replicas (managers: kubectl-client-side-apply, hpa-controller); " +
+					".metadata.labels.app (managers: kubectl-client-side-apply)"))
+			})
+		})
+	})
+
+	Describe("#ListManagedFields", func() {
+		var (
+			u   *unstructured.Unstructured
+			mfs []ManagedFields
+			err error
+			now metav1.Time
+		)
+
+		BeforeEach(func() {
+			now = metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+			u = &unstructured.Unstructured{Object: map[string]interface{}{}}
+			u.SetManagedFields([]metav1.ManagedFieldsEntry{
+				{
+					Manager:    "spinnaker",
+					Operation:  metav1.ManagedFieldsOperationApply,
+					APIVersion: "apps/v1",
+					Time:       &now,
+					FieldsType: "FieldsV1",
+					FieldsV1: &metav1.FieldsV1{
+						Raw: []byte(`{"f:metadata":{"f:labels":{"f:app":{}}},"f:spec":{"f:replicas":{}}}`),
+					},
+				},
+				{
+					Manager:     "kube-controller-manager",
+					Operation:   metav1.ManagedFieldsOperationUpdate,
+					APIVersion:  "apps/v1",
+					Subresource: "status",
+				},
+			})
+		})
+
+		JustBeforeEach(func() {
+			mfs, err = ListManagedFields(u)
+		})
+
+		When("the fields cannot be decoded", func() {
+			BeforeEach(func() {
+				u.SetManagedFields([]metav1.ManagedFieldsEntry{
+					{
+						Manager:  "spinnaker",
+						FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"unknown":{}}`)},
+					},
+				})
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(HavePrefix("error decoding fields of manager spinnaker: "))
+			})
+		})
+
+		When("it succeeds", func() {
+			It("returns the fields owned by each manager", func() {
+				Expect(err).To(BeNil())
+				Expect(mfs).To(HaveLen(2))
+				Expect(mfs[0].Time).To(BeTemporally("==", now.Time))
+				mfs[0].Time = time.Time{}
+				Expect(mfs).To(Equal([]ManagedFields{
+					{
+						Manager:    "spinnaker",
+						Operation:  "Apply",
+						APIVersion: "apps/v1",
+						Fields:     []string{".metadata.labels.app", ".spec.replicas"},
+					},
+					{
+						Manager:     "kube-controller-manager",
+						Operation:   "Update",
+						APIVersion:  "apps/v1",
+						Subresource: "status",
+						Fields:      []string{},
+					},
+				}))
+			})
+		})
+	})
+})
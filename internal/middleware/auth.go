@@ -58,9 +58,17 @@ func (cc *Controller) AuthApplication(permissions ...string) gin.HandlerFunc {
 func (cc *Controller) AuthAccount(permissions ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		user := c.GetHeader(headerSpinnakerUser)
+		if user == "" {
+			c.Next()
+			return
+		}
+
 		account := c.Param("account")
+		if account == "" {
+			account = accountFromBody(c)
+		}
 
-		if user == "" || account == "" {
+		if account == "" {
 			c.Next()
 			return
 		}
@@ -93,6 +101,23 @@ func (cc *Controller) AuthAccount(permissions ...string) gin.HandlerFunc {
 	}
 }
 
+// accountFromBody returns the account named in the JSON body of a request
+// that has no account path param, such as a manifest cache refresh.
+// The body is kept for the handler to bind with c.ShouldBindBodyWith.
+func accountFromBody(c *gin.Context) string {
+	if c.Request.Body == nil || c.Request.Body == http.NoBody {
+		return ""
+	}
+
+	body := struct {
+		Account string `json:"account"`
+	}{}
+
+	_ = c.ShouldBindBodyWith(&body, binding.JSON)
+
+	return body.Account
+}
+
 func (cc *Controller) AuthOps(permissions ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		user := c.GetHeader(headerSpinnakerUser)
@@ -113,47 +138,11 @@ func (cc *Controller) AuthOps(permissions ...string) gin.HandlerFunc {
 
 		accounts := []string{}
 
-		// Loop through each request in the kubernetes operations and perform
-		// each requested action.
-		for _, req := range ko {
-			if req.DeployManifest != nil {
-				accounts = appendAccount(accounts, req.DeployManifest.Account)
-			}
-
-			if req.DeleteManifest != nil {
-				accounts = appendAccount(accounts, req.DeleteManifest.Account)
-			}
-
-			if req.DisableManifest != nil {
-				accounts = appendAccount(accounts, req.DisableManifest.Account)
-			}
-
-			if req.EnableManifest != nil {
-				accounts = appendAccount(accounts, req.EnableManifest.Account)
-			}
-
-			if req.ScaleManifest != nil {
-				accounts = appendAccount(accounts, req.ScaleManifest.Account)
-			}
-
-			if req.CleanupArtifacts != nil {
-				accounts = appendAccount(accounts, req.CleanupArtifacts.Account)
-			}
-
-			if req.RollingRestartManifest != nil {
-				accounts = appendAccount(accounts, req.RollingRestartManifest.Account)
-			}
-
-			if req.RunJob != nil {
-				accounts = appendAccount(accounts, req.RunJob.Account)
-			}
-
-			if req.UndoRolloutManifest != nil {
-				accounts = appendAccount(accounts, req.UndoRolloutManifest.Account)
-			}
-
-			if req.PatchManifest != nil {
-				accounts = appendAccount(accounts, req.PatchManifest.Account)
+		// Loop through each request in the kubernetes operations and collect
+		// the accounts they act on.
+		for _, op := range ko {
+			for _, account := range op.Accounts() {
+				accounts = appendAccount(accounts, account)
 			}
 		}
 
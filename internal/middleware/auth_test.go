@@ -177,6 +177,29 @@ var _ = Describe("Auth", func() {
 			})
 		})
 
+		When("account is in the request body", func() {
+			BeforeEach(func() {
+				c.Params = []gin.Param{}
+				c.Request, _ = http.NewRequest(http.MethodPost, "",
+					io.NopCloser(bytes.NewReader([]byte(`{"account":"test-account"}`))))
+				c.Request.Header.Add("X-Spinnaker-User", testUser)
+				fakeResp := fiat.Response{}
+				fakeResp.Name = testUser
+				fakeAccount := fiat.Account{
+					Name:           testAccount,
+					Authorizations: []string{"WRITE"},
+				}
+				fakeResp.Accounts = []fiat.Account{fakeAccount}
+				fakeFiatClient.AuthorizeReturns(fakeResp, nil)
+			})
+
+			It("authorizes the account", func() {
+				Expect(c.Writer.Status()).To(Equal(http.StatusForbidden))
+				Expect(c.Errors[0].Error()).To(Equal("access denied to account test-account - required authorization: READ"))
+				Expect(c.IsAborted()).To(BeTrue())
+			})
+		})
+
 		When("fiatClient.Authorize returns an error", func() {
 			BeforeEach(func() {
 				fakeFiatClient.AuthorizeReturns(fiat.Response{}, errors.New("fake error"))
@@ -236,6 +259,8 @@ var _ = Describe("Auth", func() {
 				{ "disableManifest": { "account": "test-disable-account" } },
 				{ "enableManifest": { "account": "test-enable-account" } },
 				{ "patchManifest": { "metadata": { "account": "test-patch-account" } } },
+				{ "pauseRolloutManifest": { "account": "test-pause-rollout-account" } },
+				{ "resumeRolloutManifest": { "account": "test-resume-rollout-account" } },
 				{ "rollingRestartManifest": { "account": "test-rolling-restart-account" } },
 				{ "runJob": { "account": "test-runjob-account" } },
 				{ "scaleManifest": { "account": "test-scale-account" } },
@@ -277,6 +302,24 @@ var _ = Describe("Auth", func() {
 			})
 		})
 
+		When("the payload contains an unsupported operation", func() {
+			BeforeEach(func() {
+				c.Request, _ = http.NewRequest(http.MethodPost, "", io.NopCloser(bytes.NewReader([]byte(`[
+					{
+						"terminateInstances": { "account": "test-account" }
+					}
+				]`))))
+				c.Request.Header.Add("X-Spinnaker-User", testUser)
+			})
+
+			It("returns status bad request", func() {
+				Expect(c.Writer.Status()).To(Equal(http.StatusBadRequest))
+				Expect(c.Errors[0].Error()).To(Equal("unsupported kubernetes operation: terminateInstances"))
+				Expect(fakeFiatClient.AuthorizeCallCount()).To(BeZero())
+				Expect(c.IsAborted()).To(BeTrue())
+			})
+		})
+
 		When("fiatClient.Authorize returns an error", func() {
 			BeforeEach(func() {
 				fakeFiatClient.AuthorizeReturns(fiat.Response{}, errors.New("fake error"))
@@ -337,6 +380,14 @@ var _ = Describe("Auth", func() {
 						Name:           "test-patch-account",
 						Authorizations: []string{"READ"},
 					},
+					{
+						Name:           "test-pause-rollout-account",
+						Authorizations: []string{"READ"},
+					},
+					{
+						Name:           "test-resume-rollout-account",
+						Authorizations: []string{"READ"},
+					},
 					{
 						Name:           "test-rolling-restart-account",
 						Authorizations: []string{"READ"},
@@ -0,0 +1,136 @@
+package internal
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/homedepot/go-clouddriver/internal/kubernetes"
+)
+
+const (
+	// Kinds of drift between the resources table and the live resources.
+	driftMissing = "missing"
+	driftChanged = "changed"
+	driftStale   = "stale"
+)
+
+var (
+	resourceDriftFound = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "clouddriver",
+		Name:      "kubernetes_resource_drift_found_total",
+		Help:      "Entries of the kubernetes resources table found to drift from the resources in the cluster.",
+	}, []string{"account", "drift"})
+	resourceDriftRepaired = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "clouddriver",
+		Name:      "kubernetes_resource_drift_repaired_total",
+		Help:      "Entries of the kubernetes resources table added, updated or removed to repair drift.",
+	}, []string{"account", "drift"})
+)
+
+// ReconcileKubernetesResources makes the recorded entries of an account's
+// resources match the live objects of those resources:
+//   - live objects without an entry are added
+//   - entries whose application, cluster or searchable labels changed are updated
+//   - entries without a live object are removed, unless they were
+//     recorded after the given time
+//
+// Callers pass only the entries of the resources they listed,
+// as every other entry passed in is treated as stale. Entries recorded
+// by delete, cleanup and no-op tasks are never changed, as they report
+// the results of their task rather than a deployed object.
+// It returns the entries added.
+func (cc *Controller) ReconcileKubernetesResources(account string, recorded,
+	live []kubernetes.Resource, since time.Time) ([]kubernetes.Resource, error) {
+	// An object can have an entry for each task that deployed it.
+	entries := map[string][]kubernetes.Resource{}
+
+	for _, kr := range recorded {
+		if kr.TaskType != "" {
+			continue
+		}
+
+		entries[resourceKey(kr)] = append(entries[resourceKey(kr)], kr)
+	}
+
+	// Use the same task ID for all entries added.
+	taskID := uuid.New().String()
+	added := []kubernetes.Resource{}
+	seen := map[string]bool{}
+
+	for _, kr := range live {
+		key := resourceKey(kr)
+		if seen[key] {
+			continue
+		}
+
+		seen[key] = true
+
+		krs, ok := entries[key]
+		if !ok {
+			resourceDriftFound.WithLabelValues(account, driftMissing).Inc()
+
+			kr.ID = uuid.New().String()
+			kr.TaskID = taskID
+			kr.Timestamp = CurrentTimeUTC()
+
+			err := cc.SQLClient.CreateKubernetesResource(kr)
+			if err != nil {
+				return nil, err
+			}
+
+			resourceDriftRepaired.WithLabelValues(account, driftMissing).Inc()
+
+			added = append(added, kr)
+
+			continue
+		}
+
+		delete(entries, key)
+
+		for _, r := range krs {
+			if r.SpinnakerApp == kr.SpinnakerApp && r.Cluster == kr.Cluster && r.Labels == kr.Labels {
+				continue
+			}
+
+			resourceDriftFound.WithLabelValues(account, driftChanged).Inc()
+
+			r.SpinnakerApp = kr.SpinnakerApp
+			r.Cluster = kr.Cluster
+			r.Labels = kr.Labels
+
+			err := cc.SQLClient.UpdateKubernetesResource(r)
+			if err != nil {
+				return nil, err
+			}
+
+			resourceDriftRepaired.WithLabelValues(account, driftChanged).Inc()
+		}
+	}
+
+	for _, krs := range entries {
+		for _, r := range krs {
+			if !r.Timestamp.Before(since) {
+				continue
+			}
+
+			resourceDriftFound.WithLabelValues(account, driftStale).Inc()
+
+			err := cc.SQLClient.DeleteKubernetesResource(r.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			resourceDriftRepaired.WithLabelValues(account, driftStale).Inc()
+		}
+	}
+
+	return added, nil
+}
+
+// resourceKey identifies the object of an entry in the resources table.
+func resourceKey(kr kubernetes.Resource) string {
+	return kr.Resource + "/" + kr.Namespace + "/" + kr.Name
+}
@@ -1,6 +1,7 @@
 package sql
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"sort"
@@ -10,33 +11,61 @@ import (
 	"github.com/homedepot/go-clouddriver/internal/kubernetes"
 	clouddriver "github.com/homedepot/go-clouddriver/pkg"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 const (
 	maxOpenConns    = 5
 	connMaxLifetime = time.Second * 30
+	claimBatchSize  = 10
+	// inventoryEntries matches the entries of the resources table that record
+	// deployed objects, leaving out those recorded by delete, cleanup and
+	// no-op tasks, which only exist to report the results of their task.
+	inventoryEntries = "task_type = ''"
 )
 
+// ErrProviderVersionConflict is returned when updating a provider
+// whose version changed since it was read.
+var ErrProviderVersionConflict = errors.New("provider has been modified")
+
 //go:generate counterfeiter . Client
 
 type Client interface {
+	AcquireLease(string, string, time.Time) (bool, error)
+	ClaimTask(string, time.Time) (clouddriver.TaskRecord, error)
 	Connect() error
 	CreateKubernetesProvider(kubernetes.Provider) error
 	CreateKubernetesResource(kubernetes.Resource) error
+	CreateTask(clouddriver.TaskRecord) error
+	CreateTaskHistory(clouddriver.TaskHistory) error
 	DeleteKubernetesProvider(string) error
+	DeleteKubernetesResource(string) error
 	DeleteKubernetesResourcesByAccountName(string) error
+	DeleteKubernetesResourcesByTaskID(string) error
 	GetKubernetesProvider(string) (kubernetes.Provider, error)
 	GetKubernetesProviderAndPermissions(string) (kubernetes.Provider, error)
+	GetTask(string) (clouddriver.TaskRecord, error)
 	ListKubernetesAccountsBySpinnakerApp(string) ([]string, error)
 	ListKubernetesClustersByApplication(string) ([]kubernetes.Resource, error)
 	ListKubernetesClustersByFields(...string) ([]kubernetes.Resource, error)
 	ListKubernetesProviders() ([]kubernetes.Provider, error)
 	ListKubernetesProvidersAndPermissions() ([]kubernetes.Provider, error)
+	ListKubernetesResourcesByAccountName(string) ([]kubernetes.Resource, error)
 	ListKubernetesResourcesByFields(...string) ([]kubernetes.Resource, error)
+	ListKubernetesResourcesByName(string, string, string, string) ([]kubernetes.Resource, error)
 	ListKubernetesResourcesByTaskID(string) ([]kubernetes.Resource, error)
 	ListReadGroupsByAccountName(string) ([]string, error)
+	ListTaskHistoryByTaskID(string) ([]clouddriver.TaskHistory, error)
 	ListWriteGroupsByAccountName(string) ([]string, error)
+	RenewTaskLease(string, string, time.Time) error
+	ReplaceKubernetesProvider(kubernetes.Provider, int64) error
+	SearchKubernetesResources([]string, []string, string, int, int) ([]kubernetes.Resource, int64, error)
+	UpdateKubernetesProvider(string, int64, kubernetes.ProviderPatch) error
+	UpdateKubernetesResource(kubernetes.Resource) error
+	UpdateTaskResult(string, string, string) error
+	UpdateTaskState(string, string, string, string) error
 	WithConfig(*gorm.Config)
+	WithKeyring(*Keyring)
 }
 
 func NewClient(dialector gorm.Dialector) Client {
@@ -50,6 +79,9 @@ type client struct {
 	config    *gorm.Config
 	dialector gorm.Dialector
 	db        *gorm.DB
+	// keyring is optional. When set, sensitive provider
+	// fields are encrypted at rest.
+	keyring *Keyring
 }
 
 // Connect sets up the database connection and creates tables.
@@ -65,6 +97,9 @@ func (c *client) Connect() error {
 		&kubernetes.ProviderNamespaces{},
 		&clouddriver.ReadPermission{},
 		&clouddriver.WritePermission{},
+		&clouddriver.TaskRecord{},
+		&clouddriver.TaskHistory{},
+		&clouddriver.Lease{},
 	)
 	if err != nil {
 		return fmt.Errorf("error migrating DB: %w", err)
@@ -81,12 +116,35 @@ func (c *client) Connect() error {
 
 	c.db = db
 
+	if c.keyring != nil {
+		err = c.encryptKubernetesProviders()
+		if err != nil {
+			return fmt.Errorf("error encrypting kubernetes providers: %w", err)
+		}
+	}
+
 	return nil
 }
 
 // CreateKubernetesProvider inserts the provider and permissions into the DB.
 func (c *client) CreateKubernetesProvider(p kubernetes.Provider) error {
-	err := c.db.Create(&p).Error
+	return c.createKubernetesProvider(c.db, p)
+}
+
+func (c *client) createKubernetesProvider(db *gorm.DB, p kubernetes.Provider) error {
+	// Encrypt a copy, leaving the provider's fields as they are.
+	ep := p
+
+	if ep.Version == 0 {
+		ep.Version = 1
+	}
+
+	err := c.encryptProvider(&ep)
+	if err != nil {
+		return err
+	}
+
+	err = db.Create(&ep).Error
 	if err != nil {
 		return err
 	}
@@ -98,7 +156,7 @@ func (c *client) CreateKubernetesProvider(p kubernetes.Provider) error {
 			ReadGroup:   group,
 		}
 
-		err = c.db.Create(&rp).Error
+		err = db.Create(&rp).Error
 		if err != nil {
 			return err
 		}
@@ -111,7 +169,7 @@ func (c *client) CreateKubernetesProvider(p kubernetes.Provider) error {
 			WriteGroup:  group,
 		}
 
-		err = c.db.Create(&wp).Error
+		err = db.Create(&wp).Error
 		if err != nil {
 			return err
 		}
@@ -123,7 +181,7 @@ func (c *client) CreateKubernetesProvider(p kubernetes.Provider) error {
 			Namespace:   namespace,
 		}
 
-		err = c.db.Create(&ns).Error
+		err = db.Create(&ns).Error
 		if err != nil {
 			return err
 		}
@@ -138,6 +196,18 @@ func (c *client) CreateKubernetesResource(r kubernetes.Resource) error {
 	return db.Error
 }
 
+// CreateTask inserts a task into the DB in the pending state.
+func (c *client) CreateTask(t clouddriver.TaskRecord) error {
+	t.State = clouddriver.TaskStatePending
+
+	return c.db.Create(&t).Error
+}
+
+// CreateTaskHistory inserts a task history entry into the DB.
+func (c *client) CreateTaskHistory(th clouddriver.TaskHistory) error {
+	return c.db.Create(&th).Error
+}
+
 // DeleteKubernetesProvider deletes the provider, namespaces, and permission from the DB.
 func (c *client) DeleteKubernetesProvider(name string) error {
 	err := c.db.Delete(&kubernetes.Provider{Name: name}).Error
@@ -145,27 +215,33 @@ func (c *client) DeleteKubernetesProvider(name string) error {
 		return err
 	}
 
-	err = c.db.Where("account_name = ?", name).Delete(&clouddriver.ReadPermission{}).Error
-	if err != nil {
-		return err
-	}
+	return deleteKubernetesProviderEntries(c.db, name)
+}
 
-	err = c.db.Where("account_name = ?", name).Delete(&clouddriver.WritePermission{}).Error
+// deleteKubernetesProviderEntries deletes the permissions, namespaces
+// and resources recorded for a provider.
+func deleteKubernetesProviderEntries(db *gorm.DB, name string) error {
+	err := db.Where("account_name = ?", name).Delete(&clouddriver.ReadPermission{}).Error
 	if err != nil {
 		return err
 	}
 
-	err = c.db.Where("account_name = ?", name).Delete(&kubernetes.ProviderNamespaces{}).Error
+	err = db.Where("account_name = ?", name).Delete(&clouddriver.WritePermission{}).Error
 	if err != nil {
 		return err
 	}
 
-	err = c.DeleteKubernetesResourcesByAccountName(name)
+	err = db.Where("account_name = ?", name).Delete(&kubernetes.ProviderNamespaces{}).Error
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return db.Where("account_name = ?", name).Delete(&kubernetes.Resource{}).Error
+}
+
+// DeleteKubernetesResource deletes a single resource entry from the DB.
+func (c *client) DeleteKubernetesResource(id string) error {
+	return c.db.Where("id = ?", id).Delete(&kubernetes.Resource{}).Error
 }
 
 // DeleteKubernetesResources deletes all resources for the given provider from the DB.
@@ -178,11 +254,18 @@ func (c *client) DeleteKubernetesResourcesByAccountName(account string) error {
 	return nil
 }
 
+// DeleteKubernetesResourcesByTaskID deletes all resources recorded for the given task from the DB.
+func (c *client) DeleteKubernetesResourcesByTaskID(taskID string) error {
+	return c.db.Where("task_id = ?", taskID).Delete(&kubernetes.Resource{}).Error
+}
+
 // GetKubernetesProvider reads the provider from the DB.
 func (c *client) GetKubernetesProvider(name string) (kubernetes.Provider, error) {
 	p := kubernetes.Provider{}
 	rows, err := c.db.Table("kubernetes_providers a").
-		Select("a.name, a.host, a.ca_data, a.bearer_token, a.token_provider, a.namespace as legacy_namespace, b.namespace").
+		Select("a.name, a.host, a.ca_data, a.bearer_token, a.token_provider, "+
+			"a.client_certificate_data, a.client_key_data, a.kubeconfig, a.exec, "+
+			"a.namespace as legacy_namespace, b.namespace").
 		Joins("LEFT JOIN "+kubernetes.ProviderNamespaces{}.TableName()+" b ON a.name = b.account_name").
 		Where("a.name = ?", name).
 		Rows()
@@ -204,9 +287,12 @@ func (c *client) GetKubernetesProvider(name string) (kubernetes.Provider, error)
 			LegacyNamespace *string
 			Namespace       *string
 			TokenProvider   string
+			Credentials     providerCredentials
 		}
 
-		err = rows.Scan(&r.Name, &r.Host, &r.CAData, &r.BearerToken, &r.TokenProvider, &r.LegacyNamespace, &r.Namespace)
+		err = rows.Scan(&r.Name, &r.Host, &r.CAData, &r.BearerToken, &r.TokenProvider,
+			&r.Credentials.ClientCertificateData, &r.Credentials.ClientKeyData, &r.Credentials.Kubeconfig, &r.Credentials.Exec,
+			&r.LegacyNamespace, &r.Namespace)
 		if err != nil {
 			return p, err
 		}
@@ -219,6 +305,11 @@ func (c *client) GetKubernetesProvider(name string) (kubernetes.Provider, error)
 			TokenProvider: r.TokenProvider,
 		}
 
+		err = r.Credentials.set(&p)
+		if err != nil {
+			return p, err
+		}
+
 		if r.LegacyNamespace != nil {
 			namespaces = append(namespaces, *r.LegacyNamespace)
 		}
@@ -234,12 +325,17 @@ func (c *client) GetKubernetesProvider(name string) (kubernetes.Provider, error)
 		return p, gorm.ErrRecordNotFound
 	}
 
+	err = c.decryptProvider(&p)
+	if err != nil {
+		return p, err
+	}
+
 	return p, nil
 }
 
 // GetKubernetesProviderAndPermissions reads the provider and permissions from the DB.
 //
-//				select a.name, a.host, a.ca_data, a.token_provider, a.namespace as legacy_namespace, d.namespace, b.read_group, c.write_group
+//				select a.name, a.host, a.ca_data, a.token_provider, a.version, a.namespace as legacy_namespace, d.namespace, b.read_group, c.write_group
 //	         from kubernetes_providers a
 //		  		left join provider_read_permissions b on a.name = b.account_name
 //		  		left join provider_write_permissions c on a.name = c.account_name
@@ -253,6 +349,7 @@ func (c *client) GetKubernetesProviderAndPermissions(name string) (kubernetes.Pr
 			"a.host, "+
 			"a.ca_data, "+
 			"a.token_provider, "+
+			"a.version, "+
 			"a.namespace as legacy_namespace, "+
 			"d.namespace, "+
 			"b.read_group, "+
@@ -281,9 +378,11 @@ func (c *client) GetKubernetesProviderAndPermissions(name string) (kubernetes.Pr
 			ReadGroup       *string
 			WriteGroup      *string
 			TokenProvider   string
+			Version         int64
 		}
 
-		err = rows.Scan(&r.Name, &r.Host, &r.CAData, &r.TokenProvider, &r.LegacyNamespace, &r.Namespace, &r.ReadGroup, &r.WriteGroup)
+		err = rows.Scan(&r.Name, &r.Host, &r.CAData, &r.TokenProvider, &r.Version,
+			&r.LegacyNamespace, &r.Namespace, &r.ReadGroup, &r.WriteGroup)
 		if err != nil {
 			return p, err
 		}
@@ -293,6 +392,7 @@ func (c *client) GetKubernetesProviderAndPermissions(name string) (kubernetes.Pr
 			Host:          r.Host,
 			CAData:        r.CAData,
 			TokenProvider: r.TokenProvider,
+			Version:       r.Version,
 		}
 
 		if r.ReadGroup != nil {
@@ -332,6 +432,11 @@ func (c *client) GetKubernetesProviderAndPermissions(name string) (kubernetes.Pr
 		return p, gorm.ErrRecordNotFound
 	}
 
+	err = c.decryptProvider(&p)
+	if err != nil {
+		return p, err
+	}
+
 	return p, nil
 }
 
@@ -377,7 +482,12 @@ func (c *client) ListKubernetesProviders() ([]kubernetes.Provider, error) {
 		Select("a.name, " +
 			"a.host, " +
 			"a.ca_data, " +
+			"a.bearer_token, " +
 			"a.token_provider, " +
+			"a.client_certificate_data, " +
+			"a.client_key_data, " +
+			"a.kubeconfig, " +
+			"a.exec, " +
 			"a.namespace as legacy_namespace, " +
 			"b.namespace").
 		Joins("LEFT JOIN kubernetes_providers_namespaces b ON a.name = b.account_name").
@@ -394,12 +504,16 @@ func (c *client) ListKubernetesProviders() ([]kubernetes.Provider, error) {
 			CAData          string
 			Host            string
 			Name            string
+			BearerToken     *string
 			LegacyNamespace *string
 			Namespace       *string
 			TokenProvider   string
+			Credentials     providerCredentials
 		}
 
-		err = rows.Scan(&r.Name, &r.Host, &r.CAData, &r.TokenProvider, &r.LegacyNamespace, &r.Namespace)
+		err = rows.Scan(&r.Name, &r.Host, &r.CAData, &r.BearerToken, &r.TokenProvider,
+			&r.Credentials.ClientCertificateData, &r.Credentials.ClientKeyData, &r.Credentials.Kubeconfig, &r.Credentials.Exec,
+			&r.LegacyNamespace, &r.Namespace)
 		if err != nil {
 			return nil, err
 		}
@@ -411,6 +525,16 @@ func (c *client) ListKubernetesProviders() ([]kubernetes.Provider, error) {
 				CAData:        r.CAData,
 				TokenProvider: r.TokenProvider,
 			}
+
+			if r.BearerToken != nil {
+				p.BearerToken = *r.BearerToken
+			}
+
+			err = r.Credentials.set(&p)
+			if err != nil {
+				return nil, err
+			}
+
 			providers[r.Name] = p
 		}
 
@@ -428,6 +552,11 @@ func (c *client) ListKubernetesProviders() ([]kubernetes.Provider, error) {
 	}
 
 	for _, provider := range providers {
+		err = c.decryptProvider(&provider)
+		if err != nil {
+			return nil, err
+		}
+
 		ps = append(ps, provider)
 	}
 
@@ -531,6 +660,11 @@ func (c *client) ListKubernetesProvidersAndPermissions() ([]kubernetes.Provider,
 	}
 
 	for name, provider := range providers {
+		err = c.decryptProvider(&provider)
+		if err != nil {
+			return nil, err
+		}
+
 		provider.Permissions.Read = readGroups[name]
 		provider.Permissions.Write = writeGroups[name]
 		ps = append(ps, provider)
@@ -554,6 +688,43 @@ func contains(s []string, e string) bool {
 	return false
 }
 
+// providerCredentials holds the nullable credential columns of a provider.
+type providerCredentials struct {
+	ClientCertificateData *string
+	ClientKeyData         *string
+	Kubeconfig            *string
+	Exec                  *string
+}
+
+// set sets the credentials on the provider, decoding its exec
+// credential plugin stored as JSON.
+func (pc providerCredentials) set(p *kubernetes.Provider) error {
+	if pc.ClientCertificateData != nil {
+		p.ClientCertificateData = *pc.ClientCertificateData
+	}
+
+	if pc.ClientKeyData != nil {
+		p.ClientKeyData = *pc.ClientKeyData
+	}
+
+	if pc.Kubeconfig != nil {
+		p.Kubeconfig = *pc.Kubeconfig
+	}
+
+	if pc.Exec != nil && *pc.Exec != "" && *pc.Exec != "null" {
+		exec := &kubernetes.ProviderExec{}
+
+		err := json.Unmarshal([]byte(*pc.Exec), exec)
+		if err != nil {
+			return fmt.Errorf("error decoding exec of provider %s: %w", p.Name, err)
+		}
+
+		p.Exec = exec
+	}
+
+	return nil
+}
+
 // ListKubernetesResourcesByTaskID get the list of kubernetes resources
 // by task ID from the DB.
 func (c *client) ListKubernetesResourcesByTaskID(taskID string) ([]kubernetes.Resource, error) {
@@ -564,8 +735,17 @@ func (c *client) ListKubernetesResourcesByTaskID(taskID string) ([]kubernetes.Re
 	return rs, db.Error
 }
 
+// ListKubernetesResourcesByAccountName gets every kubernetes resource
+// entry recorded for the given provider by a deployment from the DB.
+func (c *client) ListKubernetesResourcesByAccountName(account string) ([]kubernetes.Resource, error) {
+	var rs []kubernetes.Resource
+	db := c.db.Where("account_name = ?", account).Where(inventoryEntries).Find(&rs)
+
+	return rs, db.Error
+}
+
 // ListKubernetesResourcesByFields gets the list of unique set of
-// kubernetes resource attributes from the DB.
+// kubernetes resource attributes recorded by deployments from the DB.
 func (c *client) ListKubernetesResourcesByFields(fields ...string) ([]kubernetes.Resource, error) {
 	if len(fields) == 0 {
 		return nil, errors.New("no fields provided")
@@ -580,7 +760,18 @@ func (c *client) ListKubernetesResourcesByFields(fields ...string) ([]kubernetes
 	}
 
 	var rs []kubernetes.Resource
-	db := c.db.Select(list).Group(list).Find(&rs)
+	db := c.db.Select(list).Where(inventoryEntries).Group(list).Find(&rs)
+
+	return rs, db.Error
+}
+
+// ListKubernetesResourcesByName gets the entries recorded for an object by
+// deployments, identified by its account, resource (such as "deployments"),
+// namespace and name, from the DB.
+func (c *client) ListKubernetesResourcesByName(account, resource, namespace, name string) ([]kubernetes.Resource, error) {
+	var rs []kubernetes.Resource
+	db := c.db.Where("account_name = ? AND resource = ? AND namespace = ? AND name = ? AND "+inventoryEntries,
+		account, resource, namespace, name).Find(&rs)
 
 	return rs, db.Error
 }
@@ -619,6 +810,18 @@ func (c *client) ListReadGroupsByAccountName(accountName string) ([]string, erro
 	return groups, db.Error
 }
 
+// ListTaskHistoryByTaskID gets the history of a task from the DB
+// in the order it was recorded.
+func (c *client) ListTaskHistoryByTaskID(taskID string) ([]clouddriver.TaskHistory, error) {
+	var th []clouddriver.TaskHistory
+	db := c.db.Select("phase, status").
+		Where("task_id = ?", taskID).
+		Order("id").
+		Find(&th)
+
+	return th, db.Error
+}
+
 // ListWriteGroupsByAccountName gets the list of groups with write permission
 // for an account name from the DB.
 func (c *client) ListWriteGroupsByAccountName(accountName string) ([]string, error) {
@@ -636,7 +839,352 @@ func (c *client) ListWriteGroupsByAccountName(accountName string) ([]string, err
 	return groups, db.Error
 }
 
+// GetTask reads the task from the DB.
+func (c *client) GetTask(id string) (clouddriver.TaskRecord, error) {
+	t := clouddriver.TaskRecord{}
+	db := c.db.Where("id = ?", id).First(&t)
+
+	return t, db.Error
+}
+
+// ClaimTask claims the oldest task that is either pending or running with
+// an expired lease, setting its owner and lease expiry. The claim is
+// conditional on the task still being claimable, so two replicas racing
+// for the same task will never both succeed.
+//
+// If there are no tasks to claim gorm.ErrRecordNotFound is returned.
+func (c *client) ClaimTask(owner string, leaseExpiresAt time.Time) (clouddriver.TaskRecord, error) {
+	now := time.Now().UTC()
+	claimable := "(state = ? OR (state = ? AND lease_expires_at < ?))"
+
+	var candidates []clouddriver.TaskRecord
+
+	err := c.db.Select("id").
+		Where(claimable, clouddriver.TaskStatePending, clouddriver.TaskStateRunning, now).
+		Order("created_at").
+		Limit(claimBatchSize).
+		Find(&candidates).Error
+	if err != nil {
+		return clouddriver.TaskRecord{}, err
+	}
+
+	for _, candidate := range candidates {
+		db := c.db.Model(&clouddriver.TaskRecord{}).
+			Where("id = ? AND "+claimable, candidate.ID,
+				clouddriver.TaskStatePending, clouddriver.TaskStateRunning, now).
+			Updates(map[string]interface{}{
+				"state":            clouddriver.TaskStateRunning,
+				"owner":            owner,
+				"attempts":         gorm.Expr("attempts + 1"),
+				"lease_expires_at": leaseExpiresAt,
+			})
+		if db.Error != nil {
+			return clouddriver.TaskRecord{}, db.Error
+		}
+
+		// Another replica claimed this task first.
+		if db.RowsAffected == 0 {
+			continue
+		}
+
+		return c.GetTask(candidate.ID)
+	}
+
+	return clouddriver.TaskRecord{}, gorm.ErrRecordNotFound
+}
+
+// AcquireLease acquires or renews the named lease for the given owner,
+// returning false if another owner holds an unexpired lease.
+func (c *client) AcquireLease(name, owner string, expiresAt time.Time) (bool, error) {
+	db := c.db.Model(&clouddriver.Lease{}).
+		Where("name = ? AND (owner = ? OR expires_at < ?)", name, owner, time.Now().UTC()).
+		Updates(map[string]interface{}{
+			"owner":      owner,
+			"expires_at": expiresAt,
+		})
+	if db.Error != nil {
+		return false, db.Error
+	}
+
+	if db.RowsAffected > 0 {
+		return true, nil
+	}
+
+	// The lease is either held by another owner or has never been acquired.
+	db = c.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&clouddriver.Lease{
+		Name:      name,
+		Owner:     owner,
+		ExpiresAt: expiresAt,
+	})
+	if db.Error != nil {
+		return false, db.Error
+	}
+
+	return db.RowsAffected > 0, nil
+}
+
+// RenewTaskLease extends the lease of a running task held by the given owner.
+func (c *client) RenewTaskLease(id, owner string, leaseExpiresAt time.Time) error {
+	db := c.db.Model(&clouddriver.TaskRecord{}).
+		Where("id = ? AND owner = ? AND state = ?", id, owner, clouddriver.TaskStateRunning).
+		Update("lease_expires_at", leaseExpiresAt)
+	if db.Error != nil {
+		return db.Error
+	}
+
+	if db.RowsAffected == 0 {
+		return errTaskNotOwned(id, owner)
+	}
+
+	return nil
+}
+
+func errTaskNotOwned(id, owner string) error {
+	return fmt.Errorf("task %s is no longer owned by %s", id, owner)
+}
+
+// SearchKubernetesResources gets a page of the objects recorded in the given
+// accounts whose name, Spinnaker application, cluster or searchable labels
+// match a LIKE pattern escaped by '!', ordered by account, kind, namespace
+// and name, along with the number of matching objects. If any kinds are
+// given, in lowercase, only objects of those kinds are matched. Only entries
+// recorded by deployments are searched.
+func (c *client) SearchKubernetesResources(accounts, kinds []string, pattern string,
+	offset, limit int) ([]kubernetes.Resource, int64, error) {
+	// An object has an entry for each task that deployed it, so group its entries.
+	fields := "account_name, kind, namespace, name, spinnaker_app, cluster"
+	db := c.db.Model(&kubernetes.Resource{}).
+		Select(fields).
+		Where("account_name IN ?", accounts).
+		Where(inventoryEntries).
+		Where("name LIKE ? ESCAPE '!' OR spinnaker_app LIKE ? ESCAPE '!' OR "+
+			"cluster LIKE ? ESCAPE '!' OR labels LIKE ? ESCAPE '!'", pattern, pattern, pattern, pattern)
+
+	if len(kinds) > 0 {
+		db = db.Where("LOWER(kind) IN ?", kinds)
+	}
+
+	db = db.Group(fields)
+
+	var total int64
+
+	err := c.db.Table("(?) AS matches", db).Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var rs []kubernetes.Resource
+	err = db.Order(fields).Offset(offset).Limit(limit).Find(&rs).Error
+
+	return rs, total, err
+}
+
+// ReplaceKubernetesProvider replaces a provider, as when deleting it and creating
+// it again, in a single transaction. The provider must be at the given version,
+// or not exist if the version is 0, otherwise ErrProviderVersionConflict is
+// returned and nothing is replaced.
+func (c *client) ReplaceKubernetesProvider(p kubernetes.Provider, version int64) error {
+	return c.db.Transaction(func(tx *gorm.DB) error {
+		if version == 0 {
+			var count int64
+
+			err := tx.Model(&kubernetes.Provider{}).Where("name = ?", p.Name).Count(&count).Error
+			if err != nil {
+				return err
+			}
+
+			if count > 0 {
+				return ErrProviderVersionConflict
+			}
+		} else {
+			db := tx.Where("name = ? AND version = ?", p.Name, version).Delete(&kubernetes.Provider{})
+			if db.Error != nil {
+				return db.Error
+			}
+
+			if db.RowsAffected == 0 {
+				return ErrProviderVersionConflict
+			}
+		}
+
+		err := deleteKubernetesProviderEntries(tx, p.Name)
+		if err != nil {
+			return err
+		}
+
+		return c.createKubernetesProvider(tx, p)
+	})
+}
+
+// UpdateKubernetesProvider updates the given fields of a provider
+// in a single transaction, incrementing its version. If the provider is no
+// longer at the given version ErrProviderVersionConflict is returned and
+// nothing is updated. Replacing the namespaces of a provider clears its
+// deprecated namespace.
+func (c *client) UpdateKubernetesProvider(name string, version int64, patch kubernetes.ProviderPatch) error {
+	updates := map[string]interface{}{
+		"version": gorm.Expr("version + 1"),
+	}
+
+	if patch.Host != nil {
+		updates["host"] = *patch.Host
+	}
+
+	if patch.CAData != nil {
+		caData := *patch.CAData
+
+		if c.keyring != nil {
+			v, err := c.keyring.Encrypt(caData)
+			if err != nil {
+				return fmt.Errorf("error encrypting ca_data of provider %s: %w", name, err)
+			}
+
+			caData = v
+		}
+
+		updates["ca_data"] = caData
+	}
+
+	if patch.Namespaces != nil {
+		updates["namespace"] = nil
+	}
+
+	return c.db.Transaction(func(tx *gorm.DB) error {
+		db := tx.Model(&kubernetes.Provider{}).
+			Where("name = ? AND version = ?", name, version).
+			Updates(updates)
+		if db.Error != nil {
+			return db.Error
+		}
+
+		if db.RowsAffected == 0 {
+			return ErrProviderVersionConflict
+		}
+
+		if patch.Namespaces != nil {
+			err := tx.Where("account_name = ?", name).Delete(&kubernetes.ProviderNamespaces{}).Error
+			if err != nil {
+				return err
+			}
+
+			for _, namespace := range *patch.Namespaces {
+				ns := kubernetes.ProviderNamespaces{
+					AccountName: name,
+					Namespace:   namespace,
+				}
+
+				err = tx.Create(&ns).Error
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		if patch.Permissions == nil {
+			return nil
+		}
+
+		if patch.Permissions.Read != nil {
+			err := tx.Where("account_name = ?", name).Delete(&clouddriver.ReadPermission{}).Error
+			if err != nil {
+				return err
+			}
+
+			for _, group := range *patch.Permissions.Read {
+				rp := clouddriver.ReadPermission{
+					ID:          uuid.New().String(),
+					AccountName: name,
+					ReadGroup:   group,
+				}
+
+				err = tx.Create(&rp).Error
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		if patch.Permissions.Write != nil {
+			err := tx.Where("account_name = ?", name).Delete(&clouddriver.WritePermission{}).Error
+			if err != nil {
+				return err
+			}
+
+			for _, group := range *patch.Permissions.Write {
+				wp := clouddriver.WritePermission{
+					ID:          uuid.New().String(),
+					AccountName: name,
+					WriteGroup:  group,
+				}
+
+				err = tx.Create(&wp).Error
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+// UpdateKubernetesResource sets the Spinnaker application, cluster and
+// searchable labels of a resource entry, the only attributes of a resource
+// that can change without it being deleted and created again.
+func (c *client) UpdateKubernetesResource(r kubernetes.Resource) error {
+	return c.db.Model(&kubernetes.Resource{}).
+		Where("id = ?", r.ID).
+		Updates(map[string]interface{}{
+			"cluster":       r.Cluster,
+			"labels":        r.Labels,
+			"spinnaker_app": r.SpinnakerApp,
+		}).Error
+}
+
+// UpdateTaskResult sets the encoded result objects of a task held by the
+// given owner, for operations whose results are not recorded as kubernetes
+// resources.
+func (c *client) UpdateTaskResult(id, owner, result string) error {
+	db := c.db.Model(&clouddriver.TaskRecord{}).
+		Where("id = ? AND owner = ?", id, owner).
+		Update("result", result)
+	if db.Error != nil {
+		return db.Error
+	}
+
+	if db.RowsAffected == 0 {
+		return errTaskNotOwned(id, owner)
+	}
+
+	return nil
+}
+
+// UpdateTaskState sets the state and status message of a task held by the
+// given owner. A task whose lease was lost to another owner is left as is.
+func (c *client) UpdateTaskState(id, owner, state, message string) error {
+	db := c.db.Model(&clouddriver.TaskRecord{}).
+		Where("id = ? AND owner = ?", id, owner).
+		Updates(map[string]interface{}{
+			"state":   state,
+			"message": message,
+		})
+	if db.Error != nil {
+		return db.Error
+	}
+
+	if db.RowsAffected == 0 {
+		return errTaskNotOwned(id, owner)
+	}
+
+	return nil
+}
+
 // WithConfig sets the gorm config to use.
 func (c *client) WithConfig(config *gorm.Config) {
 	c.config = config
 }
+
+// WithKeyring sets the keyring that encrypts sensitive provider fields at rest.
+func (c *client) WithKeyring(k *Keyring) {
+	c.keyring = k
+}
@@ -2,10 +2,15 @@ package sql_test
 
 import (
 	"database/sql"
+	"database/sql/driver"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/homedepot/go-clouddriver/internal/kubernetes"
 	. "github.com/homedepot/go-clouddriver/internal/sql"
+	clouddriver "github.com/homedepot/go-clouddriver/pkg"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -45,9 +50,14 @@ var _ = Describe("Sql", func() {
 			"\\(`name`\\ varchar\\(256\\)," +
 			"`host` varchar\\(256\\)," +
 			"`ca_data` text," +
-			"`bearer_token` varchar\\(2048\\)," +
+			"`bearer_token` text," +
 			"`token_provider` varchar\\(128\\) NOT NULL DEFAULT 'google'," +
+			"`client_certificate_data` text," +
+			"`client_key_data` text," +
+			"`kubeconfig` varchar\\(1024\\)," +
+			"`exec` text," +
 			"`namespace` varchar\\(253\\)," +
+			"`version` bigint NOT NULL DEFAULT 1," +
 			"PRIMARY KEY \\(`name`\\)" +
 			"\\)$").
 			WillReturnResult(sqlmock.NewResult(1, 1))
@@ -66,6 +76,7 @@ var _ = Describe("Sql", func() {
 			"`kind` varchar\\(256\\)," +
 			"`spinnaker_app` varchar\\(256\\)," +
 			"`cluster` varchar\\(256\\)," +
+			"`labels` text," +
 			"PRIMARY KEY \\(`id`\\)," +
 			"INDEX `.*").WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectExec("CREATE TABLE `kubernetes_providers_namespaces` " +
@@ -90,6 +101,39 @@ var _ = Describe("Sql", func() {
 			"INDEX `account_name_idx` \\(`account_name`\\)" +
 			"\\)$").
 			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("(?i)^CREATE TABLE `tasks` " +
+			"\\(`id`\\ varchar\\(256\\)," +
+			"`state` varchar\\(32\\)," +
+			"`body` longtext," +
+			"`headers` text," +
+			"`owner` varchar\\(256\\)," +
+			"`attempts` bigint," +
+			"`message` text," +
+			"`result` longtext," +
+			"`lease_expires_at` datetime\\(3\\) NULL," +
+			"`created_at` datetime\\(3\\) NULL," +
+			"`updated_at` datetime\\(3\\) NULL," +
+			"PRIMARY KEY \\(`id`\\)," +
+			"INDEX `state_idx` \\(`state`\\)" +
+			"\\)$").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("(?i)^CREATE TABLE `task_history` " +
+			"\\(`id` bigint unsigned AUTO_INCREMENT," +
+			"`task_id` varchar\\(256\\)," +
+			"`phase` varchar\\(64\\)," +
+			"`status` text," +
+			"`timestamp` timestamp DEFAULT current_timestamp," +
+			"PRIMARY KEY \\(`id`\\)," +
+			"INDEX `task_history_task_id_idx` \\(`task_id`\\)" +
+			"\\)$").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("(?i)^CREATE TABLE `leases` " +
+			"\\(`name` varchar\\(64\\)," +
+			"`owner` varchar\\(256\\)," +
+			"`expires_at` datetime\\(3\\) NULL," +
+			"PRIMARY KEY \\(`name`\\)" +
+			"\\)$").
+			WillReturnResult(sqlmock.NewResult(1, 1))
 
 		err = c.Connect()
 		Expect(err).To(BeNil())
@@ -120,6 +164,72 @@ var _ = Describe("Sql", func() {
 					"invalid DSN: missing the slash separating the database name"))
 			})
 		})
+
+		When("a keyring is set", func() {
+			var keyring *Keyring
+
+			BeforeEach(func() {
+				d, mock, _ = sqlmock.New()
+				dialector := mysql.New(mysql.Config{
+					Conn:                      d,
+					DefaultStringSize:         256,
+					SkipInitializeWithVersion: true,
+				})
+				c = NewClient(dialector)
+				newLogger := logger.New(nil, logger.Config{})
+				config := &gorm.Config{
+					Logger: newLogger,
+				}
+				c.WithConfig(config)
+
+				keyring, _ = NewKeyring(testKey1)
+				c.WithKeyring(keyring)
+
+				for i := 0; i < 8; i++ {
+					mock.ExpectExec("(?i)^CREATE TABLE").WillReturnResult(sqlmock.NewResult(1, 1))
+				}
+			})
+
+			JustBeforeEach(func() {
+				err = c.Connect()
+			})
+
+			When("listing the providers returns an error", func() {
+				BeforeEach(func() {
+					mock.ExpectQuery("(?i)^SELECT `name`,`ca_data`,`bearer_token`," +
+						"`client_certificate_data`,`client_key_data` FROM `kubernetes_providers`$").
+						WillReturnError(errors.New("error listing providers"))
+				})
+
+				It("returns an error", func() {
+					Expect(err).ToNot(BeNil())
+					Expect(err.Error()).To(Equal("error encrypting kubernetes providers: error listing providers"))
+				})
+			})
+
+			When("providers are stored in plaintext", func() {
+				BeforeEach(func() {
+					encrypted, _ := keyring.Encrypt("test-ca-data")
+					sqlRows := sqlmock.NewRows([]string{"name", "ca_data", "bearer_token",
+						"client_certificate_data", "client_key_data"}).
+						AddRow("test-name1", "test-ca-data", "test-token", "", "").
+						AddRow("test-name2", encrypted, "", "", "")
+					mock.ExpectQuery("(?i)^SELECT `name`,`ca_data`,`bearer_token`," +
+						"`client_certificate_data`,`client_key_data` FROM `kubernetes_providers`$").
+						WillReturnRows(sqlRows)
+					mock.ExpectBegin()
+					mock.ExpectExec("(?i)^UPDATE `kubernetes_providers` SET `bearer_token`=\\?,`ca_data`=\\? WHERE name = \\?$").
+						WithArgs(encryptedArg{}, encryptedArg{}, "test-name1").
+						WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectCommit()
+				})
+
+				It("encrypts them and succeeds", func() {
+					Expect(err).To(BeNil())
+					Expect(mock.ExpectationsWereMet()).To(Succeed())
+				})
+			})
+		})
 	})
 
 	Describe("#CreateKubernetesProvider", func() {
@@ -156,8 +266,13 @@ var _ = Describe("Sql", func() {
 					",`ca_data`" +
 					",`bearer_token`" +
 					",`token_provider`" +
+					",`client_certificate_data`" +
+					",`client_key_data`" +
+					",`kubeconfig`" +
+					",`exec`" +
 					",`namespace`" +
-					"\\) VALUES \\(\\?,\\?,\\?,\\?,\\?,\\?\\)$").
+					",`version`" +
+					"\\) VALUES \\(\\?,\\?,\\?,\\?,\\?,\\?,\\?,\\?,\\?,\\?,\\?\\)$").
 					WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectCommit()
 			})
@@ -183,8 +298,13 @@ var _ = Describe("Sql", func() {
 					",`ca_data`" +
 					",`bearer_token`" +
 					",`token_provider`" +
+					",`client_certificate_data`" +
+					",`client_key_data`" +
+					",`kubeconfig`" +
+					",`exec`" +
 					",`namespace`" +
-					"\\) VALUES \\(\\?,\\?,\\?,\\?,\\?,\\?\\)$").
+					",`version`" +
+					"\\) VALUES \\(\\?,\\?,\\?,\\?,\\?,\\?,\\?,\\?,\\?,\\?,\\?\\)$").
 					WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectCommit()
 
@@ -209,6 +329,32 @@ var _ = Describe("Sql", func() {
 			})
 		})
 
+		When("a keyring is set", func() {
+			BeforeEach(func() {
+				keyring, _ := NewKeyring(testKey1)
+				c.WithKeyring(keyring)
+
+				provider = kubernetes.Provider{
+					Name:          "test-name",
+					Host:          "test-host",
+					CAData:        "test-ca-data",
+					BearerToken:   "test-token",
+					TokenProvider: "bearerToken",
+				}
+				mock.ExpectBegin()
+				mock.ExpectExec("(?i)^INSERT INTO `kubernetes_providers`").
+					WithArgs("test-name", "test-host", encryptedArg{}, encryptedArg{}, "bearerToken",
+						"", "", "", nil, nil, 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			})
+
+			It("encrypts the sensitive fields and succeeds", func() {
+				Expect(err).To(BeNil())
+				Expect(mock.ExpectationsWereMet()).To(Succeed())
+			})
+		})
+
 		When("it succeeds", func() {
 			BeforeEach(func() {
 				mock.ExpectBegin()
@@ -218,152 +364,988 @@ var _ = Describe("Sql", func() {
 					",`ca_data`" +
 					",`bearer_token`" +
 					",`token_provider`" +
+					",`client_certificate_data`" +
+					",`client_key_data`" +
+					",`kubeconfig`" +
+					",`exec`" +
 					",`namespace`" +
-					"\\) VALUES \\(\\?,\\?,\\?,\\?,\\?,\\?\\)$").
+					",`version`" +
+					"\\) VALUES \\(\\?,\\?,\\?,\\?,\\?,\\?,\\?,\\?,\\?,\\?,\\?\\)$").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+
+				mock.ExpectBegin()
+				mock.ExpectExec("(?i)^INSERT INTO `provider_read_permissions` \\(" +
+					"`id`," +
+					"`account_name`," +
+					"`read_group`" +
+					"\\) VALUES \\(\\?,\\?,\\?\\)$").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+
+				mock.ExpectBegin()
+				mock.ExpectExec("(?i)^INSERT INTO `provider_write_permissions` \\(" +
+					"`id`," +
+					"`account_name`," +
+					"`write_group`" +
+					"\\) VALUES \\(\\?,\\?,\\?\\)$").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			})
+
+			It("succeeds", func() {
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Describe("#CreateKubernetesResource", func() {
+		var resource kubernetes.Resource
+
+		BeforeEach(func() {
+			resource = kubernetes.Resource{
+				ID:           "test-id",
+				TaskID:       "test-task-id",
+				APIGroup:     "test-group",
+				Name:         "test-name",
+				ArtifactName: "test-name",
+				Namespace:    "test-namespace",
+				Resource:     "test-resource",
+				Version:      "test-version",
+			}
+		})
+
+		JustBeforeEach(func() {
+			err = c.CreateKubernetesResource(resource)
+		})
+
+		When("it succeeds", func() {
+			BeforeEach(func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("(?i)^INSERT INTO `kubernetes_resources` \\(" +
+					"`account_name`," +
+					"`id`," +
+					"`task_id`," +
+					"`task_type`," +
+					"`api_group`," +
+					"`name`," +
+					"`artifact_name`," +
+					"`namespace`," +
+					"`resource`," +
+					"`version`," +
+					"`kind`," +
+					"`spinnaker_app`," +
+					"`cluster`," +
+					"`labels`" +
+					"\\) VALUES \\(\\?,\\?,\\?,\\?,\\?,\\?,\\?,\\?,\\?,\\?,\\?,\\?,\\?,\\?\\)$").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			})
+
+			It("succeeds", func() {
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Describe("#CreateTask", func() {
+		JustBeforeEach(func() {
+			err = c.CreateTask(clouddriver.TaskRecord{
+				ID:      "test-task-id",
+				Body:    "[]",
+				Headers: "{}",
+			})
+		})
+
+		When("it succeeds", func() {
+			BeforeEach(func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("(?i)^INSERT INTO `tasks` \\(" +
+					"`id`," +
+					"`state`," +
+					"`body`," +
+					"`headers`," +
+					"`owner`," +
+					"`attempts`," +
+					"`message`," +
+					"`result`," +
+					"`lease_expires_at`," +
+					"`created_at`," +
+					"`updated_at`" +
+					"\\) VALUES \\(\\?,\\?,\\?,\\?,\\?,\\?,\\?,\\?,\\?,\\?,\\?\\)$").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			})
+
+			It("succeeds", func() {
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Describe("#CreateTaskHistory", func() {
+		JustBeforeEach(func() {
+			err = c.CreateTaskHistory(clouddriver.TaskHistory{
+				TaskID: "test-task-id",
+				Phase:  "ORCHESTRATION",
+				Status: "Initializing Orchestration Task",
+			})
+		})
+
+		When("it succeeds", func() {
+			BeforeEach(func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("(?i)^INSERT INTO `task_history` \\(" +
+					"`task_id`," +
+					"`phase`," +
+					"`status`" +
+					"\\) VALUES \\(\\?,\\?,\\?\\)$").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			})
+
+			It("succeeds", func() {
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Describe("#DeleteKubernetesProvider", func() {
+		var name string
+
+		BeforeEach(func() {
+			name = "test-name"
+		})
+
+		JustBeforeEach(func() {
+			err = c.DeleteKubernetesProvider(name)
+		})
+
+		When("it succeeds", func() {
+			BeforeEach(func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("(?i)^DELETE FROM `kubernetes_providers` WHERE " +
+					"`kubernetes_providers`.`name` = \\?$").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+
+				mock.ExpectBegin()
+				mock.ExpectExec("(?i)^DELETE FROM `provider_read_permissions` WHERE " +
+					"account_name = \\?$").
 					WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectCommit()
 
 				mock.ExpectBegin()
-				mock.ExpectExec("(?i)^INSERT INTO `provider_read_permissions` \\(" +
-					"`id`," +
-					"`account_name`," +
-					"`read_group`" +
-					"\\) VALUES \\(\\?,\\?,\\?\\)$").
+				mock.ExpectExec("(?i)^DELETE FROM `provider_write_permissions` WHERE " +
+					"account_name = \\?$").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+
+				mock.ExpectBegin()
+				mock.ExpectExec("(?i)^DELETE FROM `" + kubernetes.ProviderNamespaces{}.TableName() + "` WHERE " +
+					"account_name = \\?$").
+					WillReturnResult(sqlmock.NewResult(1, 3))
+				mock.ExpectCommit()
+
+				mock.ExpectBegin()
+				mock.ExpectExec("(?i)^DELETE FROM `kubernetes_resources` WHERE " +
+					"account_name = \\?$").
+					WillReturnResult(sqlmock.NewResult(1, 10))
+				mock.ExpectCommit()
+
+			})
+
+			It("succeeds", func() {
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Describe("#DeleteKubernetesResource", func() {
+		JustBeforeEach(func() {
+			err = c.DeleteKubernetesResource("test-id")
+		})
+
+		When("it succeeds", func() {
+			BeforeEach(func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("(?i)^DELETE FROM `kubernetes_resources` WHERE " +
+					"id = \\?$").
+					WithArgs("test-id").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			})
+
+			It("succeeds", func() {
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Describe("#DeleteKubernetesResourcesByAccountName", func() {
+		var name string
+
+		BeforeEach(func() {
+			name = "test-name"
+		})
+
+		JustBeforeEach(func() {
+			err = c.DeleteKubernetesResourcesByAccountName(name)
+		})
+
+		When("it succeeds", func() {
+			BeforeEach(func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("(?i)^DELETE FROM `kubernetes_resources` WHERE " +
+					"account_name = \\?$").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			})
+
+			It("succeeds", func() {
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Describe("#DeleteKubernetesResourcesByTaskID", func() {
+		JustBeforeEach(func() {
+			err = c.DeleteKubernetesResourcesByTaskID("test-task-id")
+		})
+
+		When("it succeeds", func() {
+			BeforeEach(func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("(?i)^DELETE FROM `kubernetes_resources` WHERE " +
+					"task_id = \\?$").
+					WithArgs("test-task-id").
+					WillReturnResult(sqlmock.NewResult(1, 2))
+				mock.ExpectCommit()
+			})
+
+			It("succeeds", func() {
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Describe("#GetKubernetesProvider", func() {
+		var provider kubernetes.Provider
+
+		BeforeEach(func() {
+			provider = kubernetes.Provider{}
+		})
+
+		JustBeforeEach(func() {
+			provider, err = c.GetKubernetesProvider("test-name")
+		})
+
+		When("it succeeds", func() {
+			BeforeEach(func() {
+				sqlRows := sqlmock.NewRows([]string{"name", "host", "ca_data", "bearer_token", "token_provider",
+					"client_certificate_data", "client_key_data", "kubeconfig", "exec", "legacy_namespace", "namespace"}).
+					AddRow("test-name", "test-host", "test-ca-data", "test-token", "google", nil, nil, nil, nil, nil, "ns1").
+					AddRow("test-name", "test-host", "test-ca-data", "test-token", "google", nil, nil, nil, nil, nil, "ns2")
+				mock.ExpectQuery("(?i)^SELECT a.name, a.host, a.ca_data, a.bearer_token, a.token_provider, " +
+					"a.client_certificate_data, a.client_key_data, a.kubeconfig, a.exec, " +
+					"a.namespace as legacy_namespace, b.namespace FROM kubernetes_providers a " +
+					"LEFT JOIN kubernetes_providers_namespaces b ON a.name = b.account_name " +
+					"WHERE a.name = \\?").
+					WillReturnRows(sqlRows)
+				mock.ExpectCommit()
+			})
+
+			It("succeeds", func() {
+				Expect(err).To(BeNil())
+				Expect(provider.Name).To(Equal("test-name"))
+				Expect(provider.Host).To(Equal("test-host"))
+				Expect(provider.CAData).To(Equal("test-ca-data"))
+				Expect(provider.Namespace).To(BeNil())
+				Expect(provider.Namespaces).To(HaveLen(2))
+			})
+		})
+
+		When("the provider is encrypted", func() {
+			var keyring *Keyring
+
+			BeforeEach(func() {
+				keyring, _ = NewKeyring(testKey1)
+				caData, _ := keyring.Encrypt("test-ca-data")
+				token, _ := keyring.Encrypt("test-token")
+				sqlRows := sqlmock.NewRows([]string{"name", "host", "ca_data", "bearer_token", "token_provider",
+					"client_certificate_data", "client_key_data", "kubeconfig", "exec", "legacy_namespace", "namespace"}).
+					AddRow("test-name", "test-host", caData, token, "bearerToken", nil, nil, nil, nil, nil, nil)
+				mock.ExpectQuery("(?i)^SELECT a.name, a.host, a.ca_data, a.bearer_token").
+					WillReturnRows(sqlRows)
+			})
+
+			When("no keyring is set", func() {
+				It("returns an error", func() {
+					Expect(err).ToNot(BeNil())
+					Expect(err.Error()).To(HavePrefix("error decrypting "))
+					Expect(err.Error()).To(HaveSuffix(" of provider test-name: " +
+						"value is encrypted but no encryption key is configured"))
+				})
+			})
+
+			When("a keyring is set", func() {
+				BeforeEach(func() {
+					c.WithKeyring(keyring)
+				})
+
+				It("decrypts the provider", func() {
+					Expect(err).To(BeNil())
+					Expect(provider.CAData).To(Equal("test-ca-data"))
+					Expect(provider.BearerToken).To(Equal("test-token"))
+				})
+			})
+		})
+	})
+
+	Describe("#GetKubernetesProviderAndPermissions", func() {
+		var provider kubernetes.Provider
+
+		BeforeEach(func() {
+			provider = kubernetes.Provider{}
+		})
+
+		JustBeforeEach(func() {
+			provider, err = c.GetKubernetesProviderAndPermissions("test-name")
+		})
+
+		When("it succeeds", func() {
+			BeforeEach(func() {
+				sqlRows := sqlmock.NewRows([]string{"name", "host", "ca_data", "token_provider", "version", "legacy_namespace", "namespace", "read_group", "write_group"}).
+					AddRow("test-name", "test-host", "test-ca-data", "test-token-provider", 3, nil, nil, "test-read-group", "test-write-group")
+				mock.ExpectQuery("(?i)^SELECT a.name," +
+					" a.host," +
+					" a.ca_data," +
+					" a.token_provider," +
+					" a.version," +
+					" a.namespace as legacy_namespace," +
+					" d.namespace," +
+					" b.read_group," +
+					" c.write_group" +
+					" FROM kubernetes_providers a" +
+					" LEFT JOIN provider_read_permissions b ON a.name = b.account_name " +
+					" LEFT JOIN provider_write_permissions c ON a.name = c.account_name" +
+					" LEFT JOIN kubernetes_providers_namespaces d ON a.name = d.account_name" +
+					" WHERE a.name = \\?").
+					WillReturnRows(sqlRows)
+				mock.ExpectCommit()
+			})
+
+			It("succeeds", func() {
+				Expect(err).To(BeNil())
+				Expect(provider.Name).To(Equal("test-name"))
+				Expect(provider.Host).To(Equal("test-host"))
+				Expect(provider.CAData).To(Equal("test-ca-data"))
+				Expect(provider.TokenProvider).To(Equal("test-token-provider"))
+				Expect(provider.Version).To(Equal(int64(3)))
+				Expect(provider.Namespace).To(BeNil())
+				Expect(provider.Permissions.Read[0]).To(Equal("test-read-group"))
+				Expect(provider.Permissions.Write[0]).To(Equal("test-write-group"))
+			})
+		})
+
+		When("namespaces is not nil", func() {
+			BeforeEach(func() {
+				sqlRows := sqlmock.NewRows([]string{"name", "host", "ca_data", "token_provider", "version", "legacy_namespace", "namespace", "read_group", "write_group"}).
+					AddRow("test-name", "test-host", "test-ca-data", "test-token-provider", 3, nil, "n1", "test-read-group", "test-write-group")
+				mock.ExpectQuery("(?i)^SELECT a.name," +
+					" a.host," +
+					" a.ca_data," +
+					" a.token_provider," +
+					" a.version," +
+					" a.namespace as legacy_namespace," +
+					" d.namespace," +
+					" b.read_group," +
+					" c.write_group" +
+					" FROM kubernetes_providers a" +
+					" LEFT JOIN provider_read_permissions b ON a.name = b.account_name " +
+					" LEFT JOIN provider_write_permissions c ON a.name = c.account_name" +
+					" LEFT JOIN kubernetes_providers_namespaces d on a.name = d.account_name" +
+					" WHERE a.name = \\?").
+					WillReturnRows(sqlRows)
+				mock.ExpectCommit()
+			})
+
+			It("succeeds", func() {
+				Expect(err).To(BeNil())
+				Expect(provider.Name).To(Equal("test-name"))
+				Expect(provider.Host).To(Equal("test-host"))
+				Expect(provider.CAData).To(Equal("test-ca-data"))
+				Expect(provider.TokenProvider).To(Equal("test-token-provider"))
+				Expect(provider.Namespace).To(BeNil())
+				Expect(provider.Namespaces[0]).To(Equal("n1"))
+				Expect(provider.Permissions.Read[0]).To(Equal("test-read-group"))
+				Expect(provider.Permissions.Write[0]).To(Equal("test-write-group"))
+			})
+		})
+	})
+
+	Describe("#GetTask", func() {
+		var task clouddriver.TaskRecord
+
+		JustBeforeEach(func() {
+			task, err = c.GetTask("test-task-id")
+		})
+
+		When("it succeeds", func() {
+			BeforeEach(func() {
+				sqlRows := sqlmock.NewRows([]string{"id", "state", "attempts"}).
+					AddRow("test-task-id", clouddriver.TaskStateRunning, 1)
+				mock.ExpectQuery("(?i)^SELECT \\* FROM `tasks` WHERE id = \\? "+
+					"ORDER BY `tasks`.`id` LIMIT \\?$").
+					WithArgs("test-task-id", 1).
+					WillReturnRows(sqlRows)
+			})
+
+			It("succeeds", func() {
+				Expect(err).To(BeNil())
+				Expect(task.ID).To(Equal("test-task-id"))
+				Expect(task.State).To(Equal(clouddriver.TaskStateRunning))
+				Expect(task.Attempts).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("#AcquireLease", func() {
+		var acquired bool
+
+		JustBeforeEach(func() {
+			acquired, err = c.AcquireLease("test-lease", "test-owner", time.Now())
+		})
+
+		When("updating the lease returns an error", func() {
+			BeforeEach(func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("(?i)^UPDATE `leases` SET").
+					WillReturnError(errors.New("error updating lease"))
+				mock.ExpectRollback()
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("error updating lease"))
+				Expect(acquired).To(BeFalse())
+			})
+		})
+
+		When("the lease is held by the owner or has expired", func() {
+			BeforeEach(func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("(?i)^UPDATE `leases` SET "+
+					"`expires_at`=\\?,`owner`=\\? "+
+					"WHERE name = \\? AND \\(owner = \\? OR expires_at < \\?\\)$").
+					WithArgs(sqlmock.AnyArg(), "test-owner", "test-lease", "test-owner", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			})
+
+			It("acquires the lease", func() {
+				Expect(err).To(BeNil())
+				Expect(acquired).To(BeTrue())
+			})
+		})
+
+		When("the lease has never been acquired", func() {
+			BeforeEach(func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("(?i)^UPDATE `leases` SET").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+				mock.ExpectBegin()
+				mock.ExpectExec("(?i)^INSERT INTO `leases` \\(`name`,`owner`,`expires_at`\\) "+
+					"VALUES \\(\\?,\\?,\\?\\) ON DUPLICATE KEY UPDATE").
+					WithArgs("test-lease", "test-owner", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			})
+
+			It("acquires the lease", func() {
+				Expect(err).To(BeNil())
+				Expect(acquired).To(BeTrue())
+			})
+		})
+
+		When("the lease is held by another owner", func() {
+			BeforeEach(func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("(?i)^UPDATE `leases` SET").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+				mock.ExpectBegin()
+				mock.ExpectExec("(?i)^INSERT INTO `leases`").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			})
+
+			It("does not acquire the lease", func() {
+				Expect(err).To(BeNil())
+				Expect(acquired).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("#ClaimTask", func() {
+		var task clouddriver.TaskRecord
+
+		JustBeforeEach(func() {
+			task, err = c.ClaimTask("test-owner", time.Now())
+		})
+
+		When("listing the claimable tasks returns an error", func() {
+			BeforeEach(func() {
+				mock.ExpectQuery("(?i)^SELECT `id` FROM `tasks`").
+					WillReturnError(errors.New("error listing tasks"))
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("error listing tasks"))
+			})
+		})
+
+		When("there are no tasks to claim", func() {
+			BeforeEach(func() {
+				mock.ExpectQuery("(?i)^SELECT `id` FROM `tasks`").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			})
+
+			It("returns record not found", func() {
+				Expect(err).To(Equal(gorm.ErrRecordNotFound))
+			})
+		})
+
+		When("another owner claims the task first", func() {
+			BeforeEach(func() {
+				mock.ExpectQuery("(?i)^SELECT `id` FROM `tasks` WHERE " +
+					"\\(state = \\? OR \\(state = \\? AND lease_expires_at < \\?\\)\\) " +
+					"ORDER BY created_at LIMIT \\?$").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("test-task-id"))
+				mock.ExpectBegin()
+				mock.ExpectExec("(?i)^UPDATE `tasks` SET").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			})
+
+			It("returns record not found", func() {
+				Expect(err).To(Equal(gorm.ErrRecordNotFound))
+			})
+		})
+
+		When("it succeeds", func() {
+			BeforeEach(func() {
+				mock.ExpectQuery("(?i)^SELECT `id` FROM `tasks` WHERE "+
+					"\\(state = \\? OR \\(state = \\? AND lease_expires_at < \\?\\)\\) "+
+					"ORDER BY created_at LIMIT \\?$").
+					WithArgs(clouddriver.TaskStatePending, clouddriver.TaskStateRunning, sqlmock.AnyArg(), 10).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("test-task-id"))
+				mock.ExpectBegin()
+				mock.ExpectExec("(?i)^UPDATE `tasks` SET "+
+					"`attempts`=attempts \\+ 1,"+
+					"`lease_expires_at`=\\?,"+
+					"`owner`=\\?,"+
+					"`state`=\\?,"+
+					"`updated_at`=\\? "+
+					"WHERE id = \\? AND \\(state = \\? OR \\(state = \\? AND lease_expires_at < \\?\\)\\)$").
+					WithArgs(sqlmock.AnyArg(), "test-owner", clouddriver.TaskStateRunning, sqlmock.AnyArg(),
+						"test-task-id", clouddriver.TaskStatePending, clouddriver.TaskStateRunning, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				mock.ExpectQuery("(?i)^SELECT \\* FROM `tasks` WHERE id = \\?").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "state", "owner"}).
+						AddRow("test-task-id", clouddriver.TaskStateRunning, "test-owner"))
+			})
+
+			It("succeeds", func() {
+				Expect(err).To(BeNil())
+				Expect(task.ID).To(Equal("test-task-id"))
+				Expect(task.Owner).To(Equal("test-owner"))
+			})
+		})
+	})
+
+	Describe("#RenewTaskLease", func() {
+		JustBeforeEach(func() {
+			err = c.RenewTaskLease("test-task-id", "test-owner", time.Now())
+		})
+
+		When("the task is no longer owned", func() {
+			BeforeEach(func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("(?i)^UPDATE `tasks` SET").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("task test-task-id is no longer owned by test-owner"))
+			})
+		})
+
+		When("it succeeds", func() {
+			BeforeEach(func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("(?i)^UPDATE `tasks` SET "+
+					"`lease_expires_at`=\\?,`updated_at`=\\? "+
+					"WHERE id = \\? AND owner = \\? AND state = \\?$").
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "test-task-id", "test-owner", clouddriver.TaskStateRunning).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			})
+
+			It("succeeds", func() {
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Describe("#SearchKubernetesResources", func() {
+		var (
+			resources []kubernetes.Resource
+			total     int64
+		)
+
+		JustBeforeEach(func() {
+			resources, total, err = c.SearchKubernetesResources([]string{"account1", "account2"},
+				[]string{"deployment"}, "%test%", 10, 5)
+		})
+
+		When("counting the matches returns an error", func() {
+			BeforeEach(func() {
+				mock.ExpectQuery("(?i)^SELECT count\\(\\*\\) FROM \\(SELECT").
+					WillReturnError(errors.New("error counting"))
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("error counting"))
+			})
+		})
+
+		When("it succeeds", func() {
+			BeforeEach(func() {
+				query := "SELECT account_name, kind, namespace, name, spinnaker_app, cluster " +
+					"FROM `kubernetes_resources` " +
+					"WHERE account_name IN \\(\\?,\\?\\) " +
+					"AND task_type = '' " +
+					"AND \\(name LIKE \\? ESCAPE '!' OR spinnaker_app LIKE \\? ESCAPE '!' OR " +
+					"cluster LIKE \\? ESCAPE '!' OR labels LIKE \\? ESCAPE '!'\\) " +
+					"AND LOWER\\(kind\\) IN \\(\\?\\) " +
+					"GROUP BY account_name, kind, namespace, name, spinnaker_app, cluster"
+				mock.ExpectQuery("(?i)^SELECT count\\(\\*\\) FROM \\("+query+"\\) AS matches$").
+					WithArgs("account1", "account2", "%test%", "%test%", "%test%", "%test%", "deployment").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(11))
+				mock.ExpectQuery("(?i)^"+query+" "+
+					"ORDER BY account_name, kind, namespace, name, spinnaker_app, cluster "+
+					"LIMIT \\? OFFSET \\?$").
+					WithArgs("account1", "account2", "%test%", "%test%", "%test%", "%test%", "deployment", 5, 10).
+					WillReturnRows(sqlmock.NewRows([]string{"account_name", "kind", "name"}).
+						AddRow("account1", "Deployment", "test-deployment"))
+			})
+
+			It("succeeds", func() {
+				Expect(err).To(BeNil())
+				Expect(total).To(Equal(int64(11)))
+				Expect(resources).To(HaveLen(1))
+				Expect(resources[0].Name).To(Equal("test-deployment"))
+			})
+		})
+	})
+
+	Describe("#ReplaceKubernetesProvider", func() {
+		var version int64
+
+		BeforeEach(func() {
+			version = 3
+		})
+
+		JustBeforeEach(func() {
+			err = c.ReplaceKubernetesProvider(kubernetes.Provider{
+				Name:    "test-name",
+				Host:    "test-host",
+				Version: 4,
+				Permissions: kubernetes.ProviderPermissions{
+					Read: []string{"test-read-group"},
+				},
+			}, version)
+		})
+
+		When("deleting the provider returns an error", func() {
+			BeforeEach(func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("(?i)^DELETE FROM `kubernetes_providers`").
+					WillReturnError(errors.New("error deleting provider"))
+				mock.ExpectRollback()
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("error deleting provider"))
+			})
+		})
+
+		When("the provider is no longer at the version", func() {
+			BeforeEach(func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("(?i)^DELETE FROM `kubernetes_providers` WHERE name = \\? AND version = \\?$").
+					WithArgs("test-name", 3).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			})
+
+			It("returns a version conflict", func() {
+				Expect(err).To(Equal(ErrProviderVersionConflict))
+				Expect(mock.ExpectationsWereMet()).To(Succeed())
+			})
+		})
+
+		When("the version is 0 and the provider exists", func() {
+			BeforeEach(func() {
+				version = 0
+				mock.ExpectBegin()
+				mock.ExpectQuery("(?i)^SELECT count\\(\\*\\) FROM `kubernetes_providers` WHERE name = \\?$").
+					WithArgs("test-name").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectRollback()
+			})
+
+			It("returns a version conflict", func() {
+				Expect(err).To(Equal(ErrProviderVersionConflict))
+				Expect(mock.ExpectationsWereMet()).To(Succeed())
+			})
+		})
+
+		When("creating the provider returns an error", func() {
+			BeforeEach(func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("(?i)^DELETE FROM `kubernetes_providers`").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("(?i)^DELETE FROM `provider_read_permissions`").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("(?i)^DELETE FROM `provider_write_permissions`").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("(?i)^DELETE FROM `kubernetes_providers_namespaces`").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("(?i)^DELETE FROM `kubernetes_resources`").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("(?i)^INSERT INTO `kubernetes_providers`").
+					WillReturnError(errors.New("error creating provider"))
+				mock.ExpectRollback()
+			})
+
+			It("rolls back and returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("error creating provider"))
+				Expect(mock.ExpectationsWereMet()).To(Succeed())
+			})
+		})
+
+		When("the version is 0 and the provider does not exist", func() {
+			BeforeEach(func() {
+				version = 0
+				mock.ExpectBegin()
+				mock.ExpectQuery("(?i)^SELECT count\\(\\*\\) FROM `kubernetes_providers` WHERE name = \\?$").
+					WithArgs("test-name").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectExec("(?i)^DELETE FROM `provider_read_permissions`").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("(?i)^DELETE FROM `provider_write_permissions`").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("(?i)^DELETE FROM `kubernetes_providers_namespaces`").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("(?i)^DELETE FROM `kubernetes_resources`").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("(?i)^INSERT INTO `kubernetes_providers`").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("(?i)^INSERT INTO `provider_read_permissions`").
+					WithArgs(sqlmock.AnyArg(), "test-name", "test-read-group").
 					WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectCommit()
+			})
 
+			It("creates it and succeeds", func() {
+				Expect(err).To(BeNil())
+				Expect(mock.ExpectationsWereMet()).To(Succeed())
+			})
+		})
+
+		When("it succeeds", func() {
+			BeforeEach(func() {
 				mock.ExpectBegin()
-				mock.ExpectExec("(?i)^INSERT INTO `provider_write_permissions` \\(" +
-					"`id`," +
-					"`account_name`," +
-					"`write_group`" +
-					"\\) VALUES \\(\\?,\\?,\\?\\)$").
+				mock.ExpectExec("(?i)^DELETE FROM `kubernetes_providers` WHERE name = \\? AND version = \\?$").
+					WithArgs("test-name", 3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("(?i)^DELETE FROM `provider_read_permissions` WHERE account_name = \\?$").
+					WithArgs("test-name").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("(?i)^DELETE FROM `provider_write_permissions` WHERE account_name = \\?$").
+					WithArgs("test-name").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("(?i)^DELETE FROM `kubernetes_providers_namespaces` WHERE account_name = \\?$").
+					WithArgs("test-name").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("(?i)^DELETE FROM `kubernetes_resources` WHERE account_name = \\?$").
+					WithArgs("test-name").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("(?i)^INSERT INTO `kubernetes_providers`").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("(?i)^INSERT INTO `provider_read_permissions`").
+					WithArgs(sqlmock.AnyArg(), "test-name", "test-read-group").
 					WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectCommit()
 			})
 
-			It("succeeds", func() {
+			It("replaces the provider in a transaction and succeeds", func() {
 				Expect(err).To(BeNil())
+				Expect(mock.ExpectationsWereMet()).To(Succeed())
 			})
 		})
 	})
 
-	Describe("#CreateKubernetesResource", func() {
-		var resource kubernetes.Resource
+	Describe("#UpdateKubernetesProvider", func() {
+		var patch kubernetes.ProviderPatch
 
 		BeforeEach(func() {
-			resource = kubernetes.Resource{
-				ID:           "test-id",
-				TaskID:       "test-task-id",
-				APIGroup:     "test-group",
-				Name:         "test-name",
-				ArtifactName: "test-name",
-				Namespace:    "test-namespace",
-				Resource:     "test-resource",
-				Version:      "test-version",
+			host := "test-host"
+			patch = kubernetes.ProviderPatch{
+				Host: &host,
 			}
 		})
 
 		JustBeforeEach(func() {
-			err = c.CreateKubernetesResource(resource)
+			err = c.UpdateKubernetesProvider("test-name", 3, patch)
 		})
 
-		When("it succeeds", func() {
+		When("updating the provider returns an error", func() {
 			BeforeEach(func() {
 				mock.ExpectBegin()
-				mock.ExpectExec("(?i)^INSERT INTO `kubernetes_resources` \\(" +
-					"`account_name`," +
-					"`id`," +
-					"`task_id`," +
-					"`task_type`," +
-					"`api_group`," +
-					"`name`," +
-					"`artifact_name`," +
-					"`namespace`," +
-					"`resource`," +
-					"`version`," +
-					"`kind`," +
-					"`spinnaker_app`," +
-					"`cluster`" +
-					"\\) VALUES \\(\\?,\\?,\\?,\\?,\\?,\\?,\\?,\\?,\\?,\\?,\\?,\\?,\\?\\)$").
-					WillReturnResult(sqlmock.NewResult(1, 1))
-				mock.ExpectCommit()
+				mock.ExpectExec("(?i)^UPDATE `kubernetes_providers`").
+					WillReturnError(errors.New("error updating provider"))
+				mock.ExpectRollback()
 			})
 
-			It("succeeds", func() {
-				Expect(err).To(BeNil())
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("error updating provider"))
 			})
 		})
-	})
-
-	Describe("#DeleteKubernetesProvider", func() {
-		var name string
 
-		BeforeEach(func() {
-			name = "test-name"
-		})
+		When("the provider is no longer at the version", func() {
+			BeforeEach(func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("(?i)^UPDATE `kubernetes_providers` SET "+
+					"`host`=\\?,`version`=version \\+ 1 "+
+					"WHERE name = \\? AND version = \\?$").
+					WithArgs("test-host", "test-name", 3).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			})
 
-		JustBeforeEach(func() {
-			err = c.DeleteKubernetesProvider(name)
+			It("returns a version conflict", func() {
+				Expect(err).To(Equal(ErrProviderVersionConflict))
+				Expect(mock.ExpectationsWereMet()).To(Succeed())
+			})
 		})
 
-		When("it succeeds", func() {
+		When("namespaces and permissions are set", func() {
 			BeforeEach(func() {
-				mock.ExpectBegin()
-				mock.ExpectExec("(?i)^DELETE FROM `kubernetes_providers` WHERE " +
-					"`kubernetes_providers`.`name` = \\?$").
-					WillReturnResult(sqlmock.NewResult(1, 1))
-				mock.ExpectCommit()
+				caData := "test-ca-data"
+				namespaces := []string{"n1"}
+				read := []string{"test-read-group"}
+				patch.CAData = &caData
+				patch.Namespaces = &namespaces
+				patch.Permissions = &kubernetes.ProviderPermissionsPatch{
+					Read: &read,
+				}
 
 				mock.ExpectBegin()
-				mock.ExpectExec("(?i)^DELETE FROM `provider_read_permissions` WHERE " +
-					"account_name = \\?$").
+				mock.ExpectExec("(?i)^UPDATE `kubernetes_providers` SET "+
+					"`ca_data`=\\?,`host`=\\?,`namespace`=\\?,`version`=version \\+ 1 "+
+					"WHERE name = \\? AND version = \\?$").
+					WithArgs("test-ca-data", "test-host", nil, "test-name", 3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("(?i)^DELETE FROM `kubernetes_providers_namespaces` WHERE account_name = \\?$").
+					WithArgs("test-name").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("(?i)^INSERT INTO `kubernetes_providers_namespaces`").
+					WithArgs("test-name", "n1").
 					WillReturnResult(sqlmock.NewResult(1, 1))
-				mock.ExpectCommit()
-
-				mock.ExpectBegin()
-				mock.ExpectExec("(?i)^DELETE FROM `provider_write_permissions` WHERE " +
-					"account_name = \\?$").
+				mock.ExpectExec("(?i)^DELETE FROM `provider_read_permissions` WHERE account_name = \\?$").
+					WithArgs("test-name").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("(?i)^INSERT INTO `provider_read_permissions`").
+					WithArgs(sqlmock.AnyArg(), "test-name", "test-read-group").
 					WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectCommit()
+			})
+
+			It("updates them in a transaction and succeeds", func() {
+				Expect(err).To(BeNil())
+				Expect(mock.ExpectationsWereMet()).To(Succeed())
+			})
+		})
+
+		When("a keyring is set", func() {
+			BeforeEach(func() {
+				keyring, _ := NewKeyring(testKey1)
+				c.WithKeyring(keyring)
+
+				caData := "test-ca-data"
+				patch.CAData = &caData
 
 				mock.ExpectBegin()
-				mock.ExpectExec("(?i)^DELETE FROM `" + kubernetes.ProviderNamespaces{}.TableName() + "` WHERE " +
-					"account_name = \\?$").
-					WillReturnResult(sqlmock.NewResult(1, 3))
+				mock.ExpectExec("(?i)^UPDATE `kubernetes_providers`").
+					WithArgs(encryptedArg{}, "test-host", "test-name", 3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
 				mock.ExpectCommit()
+			})
+
+			It("encrypts the CA data and succeeds", func() {
+				Expect(err).To(BeNil())
+				Expect(mock.ExpectationsWereMet()).To(Succeed())
+			})
+		})
 
+		When("it succeeds", func() {
+			BeforeEach(func() {
 				mock.ExpectBegin()
-				mock.ExpectExec("(?i)^DELETE FROM `kubernetes_resources` WHERE " +
-					"account_name = \\?$").
-					WillReturnResult(sqlmock.NewResult(1, 10))
+				mock.ExpectExec("(?i)^UPDATE `kubernetes_providers` SET "+
+					"`host`=\\?,`version`=version \\+ 1 "+
+					"WHERE name = \\? AND version = \\?$").
+					WithArgs("test-host", "test-name", 3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
 				mock.ExpectCommit()
-
 			})
 
 			It("succeeds", func() {
 				Expect(err).To(BeNil())
+				Expect(mock.ExpectationsWereMet()).To(Succeed())
 			})
 		})
 	})
 
-	Describe("#DeleteKubernetesResourcesByAccountName", func() {
-		var name string
-
-		BeforeEach(func() {
-			name = "test-name"
-		})
-
+	Describe("#UpdateKubernetesResource", func() {
 		JustBeforeEach(func() {
-			err = c.DeleteKubernetesResourcesByAccountName(name)
+			err = c.UpdateKubernetesResource(kubernetes.Resource{
+				ID:           "test-id",
+				SpinnakerApp: "test-app",
+				Cluster:      "deployment test-deployment",
+				Labels:       "app.kubernetes.io/name=test-app",
+			})
 		})
 
 		When("it succeeds", func() {
 			BeforeEach(func() {
 				mock.ExpectBegin()
-				mock.ExpectExec("(?i)^DELETE FROM `kubernetes_resources` WHERE " +
-					"account_name = \\?$").
-					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("(?i)^UPDATE `kubernetes_resources` SET "+
+					"`cluster`=\\?,`labels`=\\?,`spinnaker_app`=\\? "+
+					"WHERE id = \\?$").
+					WithArgs("deployment test-deployment", "app.kubernetes.io/name=test-app", "test-app", "test-id").
+					WillReturnResult(sqlmock.NewResult(0, 1))
 				mock.ExpectCommit()
 			})
 
@@ -373,115 +1355,74 @@ var _ = Describe("Sql", func() {
 		})
 	})
 
-	Describe("#GetKubernetesProvider", func() {
-		var provider kubernetes.Provider
-
-		BeforeEach(func() {
-			provider = kubernetes.Provider{}
+	Describe("#UpdateTaskResult", func() {
+		JustBeforeEach(func() {
+			err = c.UpdateTaskResult("test-task-id", "test-owner", "[]")
 		})
 
-		JustBeforeEach(func() {
-			provider, err = c.GetKubernetesProvider("test-name")
+		When("the task is no longer owned", func() {
+			BeforeEach(func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("(?i)^UPDATE `tasks` SET").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("task test-task-id is no longer owned by test-owner"))
+			})
 		})
 
 		When("it succeeds", func() {
 			BeforeEach(func() {
-				sqlRows := sqlmock.NewRows([]string{"name", "host", "ca_data", "bearer_token", "token_provider", "legacy_namespace", "namespace"}).
-					AddRow("test-name", "test-host", "test-ca-data", "test-token", "google", nil, "ns1").
-					AddRow("test-name", "test-host", "test-ca-data", "test-token", "google", nil, "ns2")
-				mock.ExpectQuery("(?i)^SELECT a.name, a.host, a.ca_data, a.bearer_token, a.token_provider, a.namespace as legacy_namespace, b.namespace FROM kubernetes_providers a " +
-					"LEFT JOIN kubernetes_providers_namespaces b ON a.name = b.account_name " +
-					"WHERE a.name = \\?").
-					WillReturnRows(sqlRows)
+				mock.ExpectBegin()
+				mock.ExpectExec("(?i)^UPDATE `tasks` SET "+
+					"`result`=\\?,`updated_at`=\\? "+
+					"WHERE id = \\? AND owner = \\?$").
+					WithArgs("[]", sqlmock.AnyArg(), "test-task-id", "test-owner").
+					WillReturnResult(sqlmock.NewResult(0, 1))
 				mock.ExpectCommit()
 			})
 
 			It("succeeds", func() {
 				Expect(err).To(BeNil())
-				Expect(provider.Name).To(Equal("test-name"))
-				Expect(provider.Host).To(Equal("test-host"))
-				Expect(provider.CAData).To(Equal("test-ca-data"))
-				Expect(provider.Namespace).To(BeNil())
-				Expect(provider.Namespaces).To(HaveLen(2))
 			})
 		})
 	})
 
-	Describe("#GetKubernetesProviderAndPermissions", func() {
-		var provider kubernetes.Provider
-
-		BeforeEach(func() {
-			provider = kubernetes.Provider{}
-		})
-
+	Describe("#UpdateTaskState", func() {
 		JustBeforeEach(func() {
-			provider, err = c.GetKubernetesProviderAndPermissions("test-name")
+			err = c.UpdateTaskState("test-task-id", "test-owner", clouddriver.TaskStateFailed, "error applying manifest")
 		})
 
-		When("it succeeds", func() {
+		When("the task is no longer owned", func() {
 			BeforeEach(func() {
-				sqlRows := sqlmock.NewRows([]string{"name", "host", "ca_data", "token_provider", "legacy_namespace", "namespace", "read_group", "write_group"}).
-					AddRow("test-name", "test-host", "test-ca-data", "test-token-provider", nil, nil, "test-read-group", "test-write-group")
-				mock.ExpectQuery("(?i)^SELECT a.name," +
-					" a.host," +
-					" a.ca_data," +
-					" a.token_provider," +
-					" a.namespace as legacy_namespace," +
-					" d.namespace," +
-					" b.read_group," +
-					" c.write_group" +
-					" FROM kubernetes_providers a" +
-					" LEFT JOIN provider_read_permissions b ON a.name = b.account_name " +
-					" LEFT JOIN provider_write_permissions c ON a.name = c.account_name" +
-					" LEFT JOIN kubernetes_providers_namespaces d ON a.name = d.account_name" +
-					" WHERE a.name = \\?").
-					WillReturnRows(sqlRows)
+				mock.ExpectBegin()
+				mock.ExpectExec("(?i)^UPDATE `tasks` SET").
+					WillReturnResult(sqlmock.NewResult(0, 0))
 				mock.ExpectCommit()
 			})
 
-			It("succeeds", func() {
-				Expect(err).To(BeNil())
-				Expect(provider.Name).To(Equal("test-name"))
-				Expect(provider.Host).To(Equal("test-host"))
-				Expect(provider.CAData).To(Equal("test-ca-data"))
-				Expect(provider.TokenProvider).To(Equal("test-token-provider"))
-				Expect(provider.Namespace).To(BeNil())
-				Expect(provider.Permissions.Read[0]).To(Equal("test-read-group"))
-				Expect(provider.Permissions.Write[0]).To(Equal("test-write-group"))
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("task test-task-id is no longer owned by test-owner"))
 			})
 		})
 
-		When("namespaces is not nil", func() {
+		When("it succeeds", func() {
 			BeforeEach(func() {
-				sqlRows := sqlmock.NewRows([]string{"name", "host", "ca_data", "token_provider", "legacy_namespace", "namespace", "read_group", "write_group"}).
-					AddRow("test-name", "test-host", "test-ca-data", "test-token-provider", nil, "n1", "test-read-group", "test-write-group")
-				mock.ExpectQuery("(?i)^SELECT a.name," +
-					" a.host," +
-					" a.ca_data," +
-					" a.token_provider," +
-					" a.namespace as legacy_namespace," +
-					" d.namespace," +
-					" b.read_group," +
-					" c.write_group" +
-					" FROM kubernetes_providers a" +
-					" LEFT JOIN provider_read_permissions b ON a.name = b.account_name " +
-					" LEFT JOIN provider_write_permissions c ON a.name = c.account_name" +
-					" LEFT JOIN kubernetes_providers_namespaces d on a.name = d.account_name" +
-					" WHERE a.name = \\?").
-					WillReturnRows(sqlRows)
+				mock.ExpectBegin()
+				mock.ExpectExec("(?i)^UPDATE `tasks` SET "+
+					"`message`=\\?,`state`=\\?,`updated_at`=\\? "+
+					"WHERE id = \\? AND owner = \\?$").
+					WithArgs("error applying manifest", clouddriver.TaskStateFailed, sqlmock.AnyArg(), "test-task-id", "test-owner").
+					WillReturnResult(sqlmock.NewResult(0, 1))
 				mock.ExpectCommit()
 			})
 
 			It("succeeds", func() {
 				Expect(err).To(BeNil())
-				Expect(provider.Name).To(Equal("test-name"))
-				Expect(provider.Host).To(Equal("test-host"))
-				Expect(provider.CAData).To(Equal("test-ca-data"))
-				Expect(provider.TokenProvider).To(Equal("test-token-provider"))
-				Expect(provider.Namespace).To(BeNil())
-				Expect(provider.Namespaces[0]).To(Equal("n1"))
-				Expect(provider.Permissions.Read[0]).To(Equal("test-read-group"))
-				Expect(provider.Permissions.Write[0]).To(Equal("test-write-group"))
 			})
 		})
 	})
@@ -565,6 +1506,33 @@ var _ = Describe("Sql", func() {
 		})
 	})
 
+	Describe("#ListKubernetesResourcesByAccountName", func() {
+		var resources []kubernetes.Resource
+
+		JustBeforeEach(func() {
+			resources, err = c.ListKubernetesResourcesByAccountName("test-account")
+		})
+
+		When("it succeeds", func() {
+			BeforeEach(func() {
+				sqlRows := sqlmock.NewRows([]string{"id", "name"}).
+					AddRow("id1", "name1").
+					AddRow("id2", "name2")
+				mock.ExpectQuery("(?i)^SELECT \\* " +
+					"FROM `kubernetes_resources` " +
+					"WHERE account_name = \\? AND task_type = ''$").
+					WithArgs("test-account").
+					WillReturnRows(sqlRows)
+			})
+
+			It("succeeds", func() {
+				Expect(err).To(BeNil())
+				Expect(resources).To(HaveLen(2))
+				Expect(resources[0].ID).To(Equal("id1"))
+			})
+		})
+	})
+
 	Describe("#ListKubernetesResourcesByTaskID", func() {
 		var resources []kubernetes.Resource
 
@@ -609,16 +1577,23 @@ var _ = Describe("Sql", func() {
 
 		When("it succeeds", func() {
 			BeforeEach(func() {
-				sqlRows := sqlmock.NewRows([]string{"name", "host", "ca_data", "token_provider", "legacy_namespace", "namespace"}).
-					AddRow("name1", "host1", "ca_data1", "google", nil, "ns1").
-					AddRow("name1", "host1", "ca_data1", "google", nil, "ns2").
-					AddRow("name2", "host2", "ca_data2", "rancher", nil, "ns3").
-					AddRow("name3", "host3", "ca_data3", "rancher", "legacy-ns", nil)
+				sqlRows := sqlmock.NewRows([]string{"name", "host", "ca_data", "bearer_token", "token_provider",
+					"client_certificate_data", "client_key_data", "kubeconfig", "exec", "legacy_namespace", "namespace"}).
+					AddRow("name1", "host1", "ca_data1", nil, "google", nil, nil, nil, nil, nil, "ns1").
+					AddRow("name1", "host1", "ca_data1", nil, "google", nil, nil, nil, nil, nil, "ns2").
+					AddRow("name2", "host2", "ca_data2", nil, "rancher", nil, nil, nil, nil, nil, "ns3").
+					AddRow("name3", "host3", "ca_data3", nil, "rancher", nil, nil, nil, nil, "legacy-ns", nil).
+					AddRow("name4", "host4", "ca_data4", nil, "exec", nil, nil, nil, `{"command":"get-token","args":["--cluster","name4"]}`, nil, nil)
 				mock.ExpectQuery("(?i)^SELECT " +
 					"a.name, " +
 					"a.host, " +
 					"a.ca_data, " +
+					"a.bearer_token, " +
 					"a.token_provider, " +
+					"a.client_certificate_data, " +
+					"a.client_key_data, " +
+					"a.kubeconfig, " +
+					"a.exec, " +
 					"a.namespace as legacy_namespace, " +
 					"b.namespace " +
 					"FROM kubernetes_providers a " +
@@ -629,9 +1604,14 @@ var _ = Describe("Sql", func() {
 
 			It("succeeds", func() {
 				Expect(err).To(BeNil())
-				Expect(providers).To(HaveLen(3))
+				Expect(providers).To(HaveLen(4))
 				Expect(providers[0].Namespaces).To(HaveLen(2))
 				Expect(providers[2].Namespaces[0]).To(Equal("legacy-ns"))
+				Expect(providers[2].Exec).To(BeNil())
+				Expect(providers[3].Exec).To(Equal(&kubernetes.ProviderExec{
+					Command: "get-token",
+					Args:    []string{"--cluster", "name4"},
+				}))
 			})
 		})
 	})
@@ -754,7 +1734,8 @@ var _ = Describe("Sql", func() {
 					"field1, " +
 					"field2 " +
 					"FROM `kubernetes_resources` " +
-					" GROUP BY field1, field2$").
+					"WHERE task_type = '' " +
+					"GROUP BY field1, field2$").
 					WillReturnRows(sqlRows)
 				mock.ExpectCommit()
 			})
@@ -766,6 +1747,31 @@ var _ = Describe("Sql", func() {
 		})
 	})
 
+	Describe("#ListKubernetesResourcesByName", func() {
+		var resources []kubernetes.Resource
+
+		JustBeforeEach(func() {
+			resources, err = c.ListKubernetesResourcesByName("test-account", "deployments", "test-namespace", "test-name")
+		})
+
+		When("it succeeds", func() {
+			BeforeEach(func() {
+				sqlRows := sqlmock.NewRows([]string{"id", "name"}).
+					AddRow("id1", "test-name")
+				mock.ExpectQuery("(?i)^SELECT \\* "+
+					"FROM `kubernetes_resources` "+
+					"WHERE account_name = \\? AND resource = \\? AND namespace = \\? AND name = \\? AND task_type = ''$").
+					WithArgs("test-account", "deployments", "test-namespace", "test-name").
+					WillReturnRows(sqlRows)
+			})
+
+			It("succeeds", func() {
+				Expect(err).To(BeNil())
+				Expect(resources).To(HaveLen(1))
+			})
+		})
+	})
+
 	Describe("#ListKubernetesAccountsBySpinnakerApp", func() {
 		var accounts []string
 
@@ -822,6 +1828,36 @@ var _ = Describe("Sql", func() {
 		})
 	})
 
+	Describe("#ListTaskHistoryByTaskID", func() {
+		var history []clouddriver.TaskHistory
+
+		JustBeforeEach(func() {
+			history, err = c.ListTaskHistoryByTaskID("test-task-id")
+		})
+
+		When("it succeeds", func() {
+			BeforeEach(func() {
+				sqlRows := sqlmock.NewRows([]string{"phase", "status"}).
+					AddRow("ORCHESTRATION", "Initializing Orchestration Task").
+					AddRow("ORCHESTRATION", "Orchestration completed.")
+				mock.ExpectQuery("(?i)^SELECT " +
+					"phase, " +
+					"status " +
+					"FROM `task_history` " +
+					" WHERE task_id = \\? " +
+					"ORDER BY id$").
+					WillReturnRows(sqlRows)
+				mock.ExpectCommit()
+			})
+
+			It("succeeds", func() {
+				Expect(err).To(BeNil())
+				Expect(history).To(HaveLen(2))
+				Expect(history[1].Status).To(Equal("Orchestration completed."))
+			})
+		})
+	})
+
 	Describe("#ListWriteGroupsByAccountName", func() {
 		var groups []string
 
@@ -850,3 +1886,12 @@ var _ = Describe("Sql", func() {
 		})
 	})
 })
+
+// encryptedArg matches arguments encrypted by a keyring.
+type encryptedArg struct{}
+
+func (encryptedArg) Match(v driver.Value) bool {
+	s, ok := v.(string)
+
+	return ok && strings.HasPrefix(s, "enc:v1:")
+}
@@ -0,0 +1,343 @@
+package sql
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/homedepot/go-clouddriver/internal/kubernetes"
+)
+
+const (
+	// encryptedPrefix marks a column value encrypted by a Keyring.
+	// Encrypted values are formatted as
+	// 'enc:v1:<key ID>:<base64 wrapped data key>:<base64 ciphertext>'.
+	encryptedPrefix = "enc:v1:"
+	keySize         = 32
+	keyIDLength     = 8
+)
+
+var errNoKeyring = errors.New("value is encrypted but no encryption key is configured")
+
+// Keyring encrypts sensitive provider fields with envelope encryption:
+// each value is encrypted with its own random data key, which is itself
+// encrypted (wrapped) with the primary key of the keyring.
+//
+// Values encrypted with the previous keys of the keyring can still be
+// decrypted, so keys can be rotated by adding a new primary key and
+// keeping the old one as a previous key until every row is re-encrypted.
+type Keyring struct {
+	primary string
+	keys    map[string][]byte
+}
+
+// NewKeyring returns a keyring whose primary key is the first key passed in,
+// followed by previous keys. Each key must be 32 bytes for AES-256.
+func NewKeyring(keys ...[]byte) (*Keyring, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("no encryption keys provided")
+	}
+
+	k := &Keyring{
+		keys: map[string][]byte{},
+	}
+
+	for i, key := range keys {
+		if len(key) != keySize {
+			return nil, fmt.Errorf("encryption key %d is %d bytes, must be %d bytes", i+1, len(key), keySize)
+		}
+
+		id := keyID(key)
+		if i == 0 {
+			k.primary = id
+		}
+
+		k.keys[id] = key
+	}
+
+	return k, nil
+}
+
+// ParseKeyring returns a keyring of base64 encoded keys separated by
+// commas or newlines, the first key being the primary key.
+func ParseKeyring(s string) (*Keyring, error) {
+	keys := [][]byte{}
+
+	scanner := bufio.NewScanner(strings.NewReader(strings.ReplaceAll(s, ",", "\n")))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		key, err := base64.StdEncoding.DecodeString(line)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding base64 encryption key %d: %w", len(keys)+1, err)
+		}
+
+		keys = append(keys, key)
+	}
+
+	return NewKeyring(keys...)
+}
+
+// ReadKeyringFile returns the keyring of a key file, which holds
+// one base64 encoded key per line, the first key being the primary key.
+func ReadKeyringFile(path string) (*Keyring, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading encryption key file: %w", err)
+	}
+
+	return ParseKeyring(string(b))
+}
+
+// Encrypt encrypts a value with a new data key wrapped by the primary key.
+// Empty values are not encrypted.
+func (k *Keyring) Encrypt(plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+
+	dataKey := make([]byte, keySize)
+
+	_, err := io.ReadFull(rand.Reader, dataKey)
+	if err != nil {
+		return "", fmt.Errorf("error generating data key: %w", err)
+	}
+
+	wrapped, err := seal(k.keys[k.primary], dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	ciphertext, err := seal(dataKey, []byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+
+	return encryptedPrefix + k.primary + ":" +
+		base64.StdEncoding.EncodeToString(wrapped) + ":" +
+		base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// Decrypt decrypts a value encrypted with any key of the keyring.
+// Values that are not encrypted are returned as is.
+func (k *Keyring) Decrypt(value string) (string, error) {
+	if !isEncrypted(value) {
+		return value, nil
+	}
+
+	if k == nil {
+		return "", errNoKeyring
+	}
+
+	parts := strings.Split(strings.TrimPrefix(value, encryptedPrefix), ":")
+	if len(parts) != 3 {
+		return "", errors.New("error decrypting value: malformed encrypted value")
+	}
+
+	key, ok := k.keys[parts[0]]
+	if !ok {
+		return "", fmt.Errorf("error decrypting value: unknown encryption key %s", parts[0])
+	}
+
+	wrapped, err := base64.StdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("error decrypting value: %w", err)
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", fmt.Errorf("error decrypting value: %w", err)
+	}
+
+	dataKey, err := open(key, wrapped)
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := open(dataKey, ciphertext)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
+
+// needsEncryption returns true if a value is not
+// encrypted with the primary key of the keyring.
+func (k *Keyring) needsEncryption(value string) bool {
+	if value == "" {
+		return false
+	}
+
+	return !strings.HasPrefix(value, encryptedPrefix+k.primary+":")
+}
+
+func isEncrypted(value string) bool {
+	return strings.HasPrefix(value, encryptedPrefix)
+}
+
+// sensitiveFields returns the fields of a provider encrypted at rest by column.
+func sensitiveFields(p *kubernetes.Provider) map[string]*string {
+	return map[string]*string{
+		"ca_data":                 &p.CAData,
+		"bearer_token":            &p.BearerToken,
+		"client_certificate_data": &p.ClientCertificateData,
+		"client_key_data":         &p.ClientKeyData,
+	}
+}
+
+// encryptProvider encrypts the sensitive fields of a provider,
+// if the client has a keyring.
+func (c *client) encryptProvider(p *kubernetes.Provider) error {
+	if c.keyring == nil {
+		return nil
+	}
+
+	for column, field := range sensitiveFields(p) {
+		v, err := c.keyring.Encrypt(*field)
+		if err != nil {
+			return fmt.Errorf("error encrypting %s of provider %s: %w", column, p.Name, err)
+		}
+
+		*field = v
+	}
+
+	return nil
+}
+
+// decryptProvider decrypts the sensitive fields of a provider.
+// Fields stored before encryption was enabled are left as they are.
+func (c *client) decryptProvider(p *kubernetes.Provider) error {
+	for column, field := range sensitiveFields(p) {
+		v, err := c.keyring.Decrypt(*field)
+		if err != nil {
+			return fmt.Errorf("error decrypting %s of provider %s: %w", column, p.Name, err)
+		}
+
+		*field = v
+	}
+
+	return nil
+}
+
+// encryptKubernetesProviders encrypts the sensitive fields of every provider
+// stored in plaintext or encrypted with a previous key of the keyring,
+// so that previous keys can be removed once it has run.
+func (c *client) encryptKubernetesProviders() error {
+	var providers []kubernetes.Provider
+
+	err := c.db.Select("name", "ca_data", "bearer_token", "client_certificate_data", "client_key_data").
+		Find(&providers).Error
+	if err != nil {
+		return err
+	}
+
+	encrypted := 0
+
+	for _, p := range providers {
+		p := p
+		updates := map[string]interface{}{}
+
+		for column, field := range sensitiveFields(&p) {
+			if !c.keyring.needsEncryption(*field) {
+				continue
+			}
+
+			plaintext, err := c.keyring.Decrypt(*field)
+			if err != nil {
+				return fmt.Errorf("error decrypting %s of provider %s: %w", column, p.Name, err)
+			}
+
+			v, err := c.keyring.Encrypt(plaintext)
+			if err != nil {
+				return fmt.Errorf("error encrypting %s of provider %s: %w", column, p.Name, err)
+			}
+
+			updates[column] = v
+		}
+
+		if len(updates) == 0 {
+			continue
+		}
+
+		err = c.db.Model(&kubernetes.Provider{}).Where("name = ?", p.Name).Updates(updates).Error
+		if err != nil {
+			return err
+		}
+
+		encrypted++
+	}
+
+	if encrypted > 0 {
+		log.Printf("[CLOUDDRIVER] encrypted %d kubernetes providers with key %s\n", encrypted, c.keyring.primary)
+	}
+
+	return nil
+}
+
+// keyID identifies a key without revealing it.
+func keyID(key []byte) string {
+	sum := sha256.Sum256(key)
+
+	return hex.EncodeToString(sum[:])[:keyIDLength]
+}
+
+// seal encrypts the plaintext with AES-GCM, prepending the nonce.
+func seal(key, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+
+	_, err = io.ReadFull(rand.Reader, nonce)
+	if err != nil {
+		return nil, fmt.Errorf("error generating nonce: %w", err)
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// open decrypts the ciphertext sealed by seal.
+func open(key, ciphertext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, errors.New("error decrypting value: ciphertext too short")
+	}
+
+	nonce, ciphertext := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting value: %w", err)
+	}
+
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("error creating cipher: %w", err)
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,146 @@
+package sql_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/homedepot/go-clouddriver/internal/sql"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var (
+	testKey1 = bytes.Repeat([]byte{1}, 32)
+	testKey2 = bytes.Repeat([]byte{2}, 32)
+)
+
+var _ = Describe("Encryption", func() {
+	var (
+		keyring *Keyring
+		err     error
+	)
+
+	BeforeEach(func() {
+		keyring, err = NewKeyring(testKey1)
+		Expect(err).To(BeNil())
+	})
+
+	Describe("#NewKeyring", func() {
+		When("no keys are passed", func() {
+			It("returns an error", func() {
+				_, err = NewKeyring()
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("no encryption keys provided"))
+			})
+		})
+
+		When("a key is not 32 bytes", func() {
+			It("returns an error", func() {
+				_, err = NewKeyring(testKey1, []byte("short"))
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("encryption key 2 is 5 bytes, must be 32 bytes"))
+			})
+		})
+	})
+
+	Describe("#ParseKeyring", func() {
+		When("a key is not base64 encoded", func() {
+			It("returns an error", func() {
+				_, err = ParseKeyring("{}")
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(HavePrefix("error decoding base64 encryption key 1"))
+			})
+		})
+
+		It("uses the first key as the primary key", func() {
+			keyring, err = ParseKeyring(base64.StdEncoding.EncodeToString(testKey2) + ", " +
+				base64.StdEncoding.EncodeToString(testKey1))
+			Expect(err).To(BeNil())
+
+			old, _ := NewKeyring(testKey1)
+			v, _ := old.Encrypt("test-value")
+			plaintext, err := keyring.Decrypt(v)
+			Expect(err).To(BeNil())
+			Expect(plaintext).To(Equal("test-value"))
+
+			v, _ = keyring.Encrypt("test-value")
+			_, err = old.Decrypt(v)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(HavePrefix("error decrypting value: unknown encryption key"))
+		})
+	})
+
+	Describe("#ReadKeyringFile", func() {
+		When("the file does not exist", func() {
+			It("returns an error", func() {
+				_, err = ReadKeyringFile("/does/not/exist")
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(HavePrefix("error reading encryption key file"))
+			})
+		})
+
+		It("reads one key per line", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "keys")
+			content := base64.StdEncoding.EncodeToString(testKey1) + "\n\n" +
+				base64.StdEncoding.EncodeToString(testKey2) + "\n"
+			Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+
+			keyring, err = ReadKeyringFile(path)
+			Expect(err).To(BeNil())
+		})
+	})
+
+	Describe("#Encrypt", func() {
+		It("does not encrypt empty values", func() {
+			v, err := keyring.Encrypt("")
+			Expect(err).To(BeNil())
+			Expect(v).To(BeEmpty())
+		})
+
+		It("encrypts each value with a new data key", func() {
+			v1, err := keyring.Encrypt("test-value")
+			Expect(err).To(BeNil())
+			v2, _ := keyring.Encrypt("test-value")
+			Expect(v1).To(HavePrefix("enc:v1:"))
+			Expect(v1).ToNot(ContainSubstring("test-value"))
+			Expect(v1).ToNot(Equal(v2))
+		})
+	})
+
+	Describe("#Decrypt", func() {
+		It("returns values that are not encrypted as is", func() {
+			v, err := keyring.Decrypt("test-ca-data")
+			Expect(err).To(BeNil())
+			Expect(v).To(Equal("test-ca-data"))
+		})
+
+		When("the value was tampered with", func() {
+			It("returns an error", func() {
+				v, _ := keyring.Encrypt("test-value")
+				parts := strings.Split(v, ":")
+				parts[4] = base64.StdEncoding.EncodeToString([]byte("tampered-ciphertext-of-the-value"))
+				_, err = keyring.Decrypt(strings.Join(parts, ":"))
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(HavePrefix("error decrypting value"))
+			})
+		})
+
+		When("the value is malformed", func() {
+			It("returns an error", func() {
+				_, err = keyring.Decrypt("enc:v1:abc")
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("error decrypting value: malformed encrypted value"))
+			})
+		})
+
+		It("decrypts the value", func() {
+			v, _ := keyring.Encrypt("test-value")
+			plaintext, err := keyring.Decrypt(v)
+			Expect(err).To(BeNil())
+			Expect(plaintext).To(Equal("test-value"))
+		})
+	})
+})
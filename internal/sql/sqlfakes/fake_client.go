@@ -3,13 +3,44 @@ package sqlfakes
 
 import (
 	"sync"
+	"time"
 
 	"github.com/homedepot/go-clouddriver/internal/kubernetes"
 	"github.com/homedepot/go-clouddriver/internal/sql"
+	clouddriver "github.com/homedepot/go-clouddriver/pkg"
 	"gorm.io/gorm"
 )
 
 type FakeClient struct {
+	AcquireLeaseStub        func(string, string, time.Time) (bool, error)
+	acquireLeaseMutex       sync.RWMutex
+	acquireLeaseArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 time.Time
+	}
+	acquireLeaseReturns struct {
+		result1 bool
+		result2 error
+	}
+	acquireLeaseReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	ClaimTaskStub        func(string, time.Time) (clouddriver.TaskRecord, error)
+	claimTaskMutex       sync.RWMutex
+	claimTaskArgsForCall []struct {
+		arg1 string
+		arg2 time.Time
+	}
+	claimTaskReturns struct {
+		result1 clouddriver.TaskRecord
+		result2 error
+	}
+	claimTaskReturnsOnCall map[int]struct {
+		result1 clouddriver.TaskRecord
+		result2 error
+	}
 	ConnectStub        func() error
 	connectMutex       sync.RWMutex
 	connectArgsForCall []struct {
@@ -42,6 +73,28 @@ type FakeClient struct {
 	createKubernetesResourceReturnsOnCall map[int]struct {
 		result1 error
 	}
+	CreateTaskStub        func(clouddriver.TaskRecord) error
+	createTaskMutex       sync.RWMutex
+	createTaskArgsForCall []struct {
+		arg1 clouddriver.TaskRecord
+	}
+	createTaskReturns struct {
+		result1 error
+	}
+	createTaskReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CreateTaskHistoryStub        func(clouddriver.TaskHistory) error
+	createTaskHistoryMutex       sync.RWMutex
+	createTaskHistoryArgsForCall []struct {
+		arg1 clouddriver.TaskHistory
+	}
+	createTaskHistoryReturns struct {
+		result1 error
+	}
+	createTaskHistoryReturnsOnCall map[int]struct {
+		result1 error
+	}
 	DeleteKubernetesProviderStub        func(string) error
 	deleteKubernetesProviderMutex       sync.RWMutex
 	deleteKubernetesProviderArgsForCall []struct {
@@ -53,6 +106,17 @@ type FakeClient struct {
 	deleteKubernetesProviderReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DeleteKubernetesResourceStub        func(string) error
+	deleteKubernetesResourceMutex       sync.RWMutex
+	deleteKubernetesResourceArgsForCall []struct {
+		arg1 string
+	}
+	deleteKubernetesResourceReturns struct {
+		result1 error
+	}
+	deleteKubernetesResourceReturnsOnCall map[int]struct {
+		result1 error
+	}
 	DeleteKubernetesResourcesByAccountNameStub        func(string) error
 	deleteKubernetesResourcesByAccountNameMutex       sync.RWMutex
 	deleteKubernetesResourcesByAccountNameArgsForCall []struct {
@@ -64,6 +128,17 @@ type FakeClient struct {
 	deleteKubernetesResourcesByAccountNameReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DeleteKubernetesResourcesByTaskIDStub        func(string) error
+	deleteKubernetesResourcesByTaskIDMutex       sync.RWMutex
+	deleteKubernetesResourcesByTaskIDArgsForCall []struct {
+		arg1 string
+	}
+	deleteKubernetesResourcesByTaskIDReturns struct {
+		result1 error
+	}
+	deleteKubernetesResourcesByTaskIDReturnsOnCall map[int]struct {
+		result1 error
+	}
 	GetKubernetesProviderStub        func(string) (kubernetes.Provider, error)
 	getKubernetesProviderMutex       sync.RWMutex
 	getKubernetesProviderArgsForCall []struct {
@@ -90,6 +165,19 @@ type FakeClient struct {
 		result1 kubernetes.Provider
 		result2 error
 	}
+	GetTaskStub        func(string) (clouddriver.TaskRecord, error)
+	getTaskMutex       sync.RWMutex
+	getTaskArgsForCall []struct {
+		arg1 string
+	}
+	getTaskReturns struct {
+		result1 clouddriver.TaskRecord
+		result2 error
+	}
+	getTaskReturnsOnCall map[int]struct {
+		result1 clouddriver.TaskRecord
+		result2 error
+	}
 	ListKubernetesAccountsBySpinnakerAppStub        func(string) ([]string, error)
 	listKubernetesAccountsBySpinnakerAppMutex       sync.RWMutex
 	listKubernetesAccountsBySpinnakerAppArgsForCall []struct {
@@ -153,6 +241,19 @@ type FakeClient struct {
 		result1 []kubernetes.Provider
 		result2 error
 	}
+	ListKubernetesResourcesByAccountNameStub        func(string) ([]kubernetes.Resource, error)
+	listKubernetesResourcesByAccountNameMutex       sync.RWMutex
+	listKubernetesResourcesByAccountNameArgsForCall []struct {
+		arg1 string
+	}
+	listKubernetesResourcesByAccountNameReturns struct {
+		result1 []kubernetes.Resource
+		result2 error
+	}
+	listKubernetesResourcesByAccountNameReturnsOnCall map[int]struct {
+		result1 []kubernetes.Resource
+		result2 error
+	}
 	ListKubernetesResourcesByFieldsStub        func(...string) ([]kubernetes.Resource, error)
 	listKubernetesResourcesByFieldsMutex       sync.RWMutex
 	listKubernetesResourcesByFieldsArgsForCall []struct {
@@ -166,6 +267,22 @@ type FakeClient struct {
 		result1 []kubernetes.Resource
 		result2 error
 	}
+	ListKubernetesResourcesByNameStub        func(string, string, string, string) ([]kubernetes.Resource, error)
+	listKubernetesResourcesByNameMutex       sync.RWMutex
+	listKubernetesResourcesByNameArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	listKubernetesResourcesByNameReturns struct {
+		result1 []kubernetes.Resource
+		result2 error
+	}
+	listKubernetesResourcesByNameReturnsOnCall map[int]struct {
+		result1 []kubernetes.Resource
+		result2 error
+	}
 	ListKubernetesResourcesByTaskIDStub        func(string) ([]kubernetes.Resource, error)
 	listKubernetesResourcesByTaskIDMutex       sync.RWMutex
 	listKubernetesResourcesByTaskIDArgsForCall []struct {
@@ -192,6 +309,19 @@ type FakeClient struct {
 		result1 []string
 		result2 error
 	}
+	ListTaskHistoryByTaskIDStub        func(string) ([]clouddriver.TaskHistory, error)
+	listTaskHistoryByTaskIDMutex       sync.RWMutex
+	listTaskHistoryByTaskIDArgsForCall []struct {
+		arg1 string
+	}
+	listTaskHistoryByTaskIDReturns struct {
+		result1 []clouddriver.TaskHistory
+		result2 error
+	}
+	listTaskHistoryByTaskIDReturnsOnCall map[int]struct {
+		result1 []clouddriver.TaskHistory
+		result2 error
+	}
 	ListWriteGroupsByAccountNameStub        func(string) ([]string, error)
 	listWriteGroupsByAccountNameMutex       sync.RWMutex
 	listWriteGroupsByAccountNameArgsForCall []struct {
@@ -205,15 +335,246 @@ type FakeClient struct {
 		result1 []string
 		result2 error
 	}
+	RenewTaskLeaseStub        func(string, string, time.Time) error
+	renewTaskLeaseMutex       sync.RWMutex
+	renewTaskLeaseArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 time.Time
+	}
+	renewTaskLeaseReturns struct {
+		result1 error
+	}
+	renewTaskLeaseReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ReplaceKubernetesProviderStub        func(kubernetes.Provider, int64) error
+	replaceKubernetesProviderMutex       sync.RWMutex
+	replaceKubernetesProviderArgsForCall []struct {
+		arg1 kubernetes.Provider
+		arg2 int64
+	}
+	replaceKubernetesProviderReturns struct {
+		result1 error
+	}
+	replaceKubernetesProviderReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SearchKubernetesResourcesStub        func([]string, []string, string, int, int) ([]kubernetes.Resource, int64, error)
+	searchKubernetesResourcesMutex       sync.RWMutex
+	searchKubernetesResourcesArgsForCall []struct {
+		arg1 []string
+		arg2 []string
+		arg3 string
+		arg4 int
+		arg5 int
+	}
+	searchKubernetesResourcesReturns struct {
+		result1 []kubernetes.Resource
+		result2 int64
+		result3 error
+	}
+	searchKubernetesResourcesReturnsOnCall map[int]struct {
+		result1 []kubernetes.Resource
+		result2 int64
+		result3 error
+	}
+	UpdateKubernetesProviderStub        func(string, int64, kubernetes.ProviderPatch) error
+	updateKubernetesProviderMutex       sync.RWMutex
+	updateKubernetesProviderArgsForCall []struct {
+		arg1 string
+		arg2 int64
+		arg3 kubernetes.ProviderPatch
+	}
+	updateKubernetesProviderReturns struct {
+		result1 error
+	}
+	updateKubernetesProviderReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateKubernetesResourceStub        func(kubernetes.Resource) error
+	updateKubernetesResourceMutex       sync.RWMutex
+	updateKubernetesResourceArgsForCall []struct {
+		arg1 kubernetes.Resource
+	}
+	updateKubernetesResourceReturns struct {
+		result1 error
+	}
+	updateKubernetesResourceReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateTaskResultStub        func(string, string, string) error
+	updateTaskResultMutex       sync.RWMutex
+	updateTaskResultArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	updateTaskResultReturns struct {
+		result1 error
+	}
+	updateTaskResultReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateTaskStateStub        func(string, string, string, string) error
+	updateTaskStateMutex       sync.RWMutex
+	updateTaskStateArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	updateTaskStateReturns struct {
+		result1 error
+	}
+	updateTaskStateReturnsOnCall map[int]struct {
+		result1 error
+	}
 	WithConfigStub        func(*gorm.Config)
 	withConfigMutex       sync.RWMutex
 	withConfigArgsForCall []struct {
 		arg1 *gorm.Config
 	}
+	WithKeyringStub        func(*sql.Keyring)
+	withKeyringMutex       sync.RWMutex
+	withKeyringArgsForCall []struct {
+		arg1 *sql.Keyring
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeClient) AcquireLease(arg1 string, arg2 string, arg3 time.Time) (bool, error) {
+	fake.acquireLeaseMutex.Lock()
+	ret, specificReturn := fake.acquireLeaseReturnsOnCall[len(fake.acquireLeaseArgsForCall)]
+	fake.acquireLeaseArgsForCall = append(fake.acquireLeaseArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 time.Time
+	}{arg1, arg2, arg3})
+	stub := fake.AcquireLeaseStub
+	fakeReturns := fake.acquireLeaseReturns
+	fake.recordInvocation("AcquireLease", []interface{}{arg1, arg2, arg3})
+	fake.acquireLeaseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) AcquireLeaseCallCount() int {
+	fake.acquireLeaseMutex.RLock()
+	defer fake.acquireLeaseMutex.RUnlock()
+	return len(fake.acquireLeaseArgsForCall)
+}
+
+func (fake *FakeClient) AcquireLeaseCalls(stub func(string, string, time.Time) (bool, error)) {
+	fake.acquireLeaseMutex.Lock()
+	defer fake.acquireLeaseMutex.Unlock()
+	fake.AcquireLeaseStub = stub
+}
+
+func (fake *FakeClient) AcquireLeaseArgsForCall(i int) (string, string, time.Time) {
+	fake.acquireLeaseMutex.RLock()
+	defer fake.acquireLeaseMutex.RUnlock()
+	argsForCall := fake.acquireLeaseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeClient) AcquireLeaseReturns(result1 bool, result2 error) {
+	fake.acquireLeaseMutex.Lock()
+	defer fake.acquireLeaseMutex.Unlock()
+	fake.AcquireLeaseStub = nil
+	fake.acquireLeaseReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) AcquireLeaseReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.acquireLeaseMutex.Lock()
+	defer fake.acquireLeaseMutex.Unlock()
+	fake.AcquireLeaseStub = nil
+	if fake.acquireLeaseReturnsOnCall == nil {
+		fake.acquireLeaseReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.acquireLeaseReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ClaimTask(arg1 string, arg2 time.Time) (clouddriver.TaskRecord, error) {
+	fake.claimTaskMutex.Lock()
+	ret, specificReturn := fake.claimTaskReturnsOnCall[len(fake.claimTaskArgsForCall)]
+	fake.claimTaskArgsForCall = append(fake.claimTaskArgsForCall, struct {
+		arg1 string
+		arg2 time.Time
+	}{arg1, arg2})
+	stub := fake.ClaimTaskStub
+	fakeReturns := fake.claimTaskReturns
+	fake.recordInvocation("ClaimTask", []interface{}{arg1, arg2})
+	fake.claimTaskMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) ClaimTaskCallCount() int {
+	fake.claimTaskMutex.RLock()
+	defer fake.claimTaskMutex.RUnlock()
+	return len(fake.claimTaskArgsForCall)
+}
+
+func (fake *FakeClient) ClaimTaskCalls(stub func(string, time.Time) (clouddriver.TaskRecord, error)) {
+	fake.claimTaskMutex.Lock()
+	defer fake.claimTaskMutex.Unlock()
+	fake.ClaimTaskStub = stub
+}
+
+func (fake *FakeClient) ClaimTaskArgsForCall(i int) (string, time.Time) {
+	fake.claimTaskMutex.RLock()
+	defer fake.claimTaskMutex.RUnlock()
+	argsForCall := fake.claimTaskArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) ClaimTaskReturns(result1 clouddriver.TaskRecord, result2 error) {
+	fake.claimTaskMutex.Lock()
+	defer fake.claimTaskMutex.Unlock()
+	fake.ClaimTaskStub = nil
+	fake.claimTaskReturns = struct {
+		result1 clouddriver.TaskRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ClaimTaskReturnsOnCall(i int, result1 clouddriver.TaskRecord, result2 error) {
+	fake.claimTaskMutex.Lock()
+	defer fake.claimTaskMutex.Unlock()
+	fake.ClaimTaskStub = nil
+	if fake.claimTaskReturnsOnCall == nil {
+		fake.claimTaskReturnsOnCall = make(map[int]struct {
+			result1 clouddriver.TaskRecord
+			result2 error
+		})
+	}
+	fake.claimTaskReturnsOnCall[i] = struct {
+		result1 clouddriver.TaskRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) Connect() error {
 	fake.connectMutex.Lock()
 	ret, specificReturn := fake.connectReturnsOnCall[len(fake.connectArgsForCall)]
@@ -389,6 +750,128 @@ func (fake *FakeClient) CreateKubernetesResourceReturnsOnCall(i int, result1 err
 	}{result1}
 }
 
+func (fake *FakeClient) CreateTask(arg1 clouddriver.TaskRecord) error {
+	fake.createTaskMutex.Lock()
+	ret, specificReturn := fake.createTaskReturnsOnCall[len(fake.createTaskArgsForCall)]
+	fake.createTaskArgsForCall = append(fake.createTaskArgsForCall, struct {
+		arg1 clouddriver.TaskRecord
+	}{arg1})
+	stub := fake.CreateTaskStub
+	fakeReturns := fake.createTaskReturns
+	fake.recordInvocation("CreateTask", []interface{}{arg1})
+	fake.createTaskMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) CreateTaskCallCount() int {
+	fake.createTaskMutex.RLock()
+	defer fake.createTaskMutex.RUnlock()
+	return len(fake.createTaskArgsForCall)
+}
+
+func (fake *FakeClient) CreateTaskCalls(stub func(clouddriver.TaskRecord) error) {
+	fake.createTaskMutex.Lock()
+	defer fake.createTaskMutex.Unlock()
+	fake.CreateTaskStub = stub
+}
+
+func (fake *FakeClient) CreateTaskArgsForCall(i int) clouddriver.TaskRecord {
+	fake.createTaskMutex.RLock()
+	defer fake.createTaskMutex.RUnlock()
+	argsForCall := fake.createTaskArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) CreateTaskReturns(result1 error) {
+	fake.createTaskMutex.Lock()
+	defer fake.createTaskMutex.Unlock()
+	fake.CreateTaskStub = nil
+	fake.createTaskReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) CreateTaskReturnsOnCall(i int, result1 error) {
+	fake.createTaskMutex.Lock()
+	defer fake.createTaskMutex.Unlock()
+	fake.CreateTaskStub = nil
+	if fake.createTaskReturnsOnCall == nil {
+		fake.createTaskReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createTaskReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) CreateTaskHistory(arg1 clouddriver.TaskHistory) error {
+	fake.createTaskHistoryMutex.Lock()
+	ret, specificReturn := fake.createTaskHistoryReturnsOnCall[len(fake.createTaskHistoryArgsForCall)]
+	fake.createTaskHistoryArgsForCall = append(fake.createTaskHistoryArgsForCall, struct {
+		arg1 clouddriver.TaskHistory
+	}{arg1})
+	stub := fake.CreateTaskHistoryStub
+	fakeReturns := fake.createTaskHistoryReturns
+	fake.recordInvocation("CreateTaskHistory", []interface{}{arg1})
+	fake.createTaskHistoryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) CreateTaskHistoryCallCount() int {
+	fake.createTaskHistoryMutex.RLock()
+	defer fake.createTaskHistoryMutex.RUnlock()
+	return len(fake.createTaskHistoryArgsForCall)
+}
+
+func (fake *FakeClient) CreateTaskHistoryCalls(stub func(clouddriver.TaskHistory) error) {
+	fake.createTaskHistoryMutex.Lock()
+	defer fake.createTaskHistoryMutex.Unlock()
+	fake.CreateTaskHistoryStub = stub
+}
+
+func (fake *FakeClient) CreateTaskHistoryArgsForCall(i int) clouddriver.TaskHistory {
+	fake.createTaskHistoryMutex.RLock()
+	defer fake.createTaskHistoryMutex.RUnlock()
+	argsForCall := fake.createTaskHistoryArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) CreateTaskHistoryReturns(result1 error) {
+	fake.createTaskHistoryMutex.Lock()
+	defer fake.createTaskHistoryMutex.Unlock()
+	fake.CreateTaskHistoryStub = nil
+	fake.createTaskHistoryReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) CreateTaskHistoryReturnsOnCall(i int, result1 error) {
+	fake.createTaskHistoryMutex.Lock()
+	defer fake.createTaskHistoryMutex.Unlock()
+	fake.CreateTaskHistoryStub = nil
+	if fake.createTaskHistoryReturnsOnCall == nil {
+		fake.createTaskHistoryReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createTaskHistoryReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeClient) DeleteKubernetesProvider(arg1 string) error {
 	fake.deleteKubernetesProviderMutex.Lock()
 	ret, specificReturn := fake.deleteKubernetesProviderReturnsOnCall[len(fake.deleteKubernetesProviderArgsForCall)]
@@ -450,6 +933,67 @@ func (fake *FakeClient) DeleteKubernetesProviderReturnsOnCall(i int, result1 err
 	}{result1}
 }
 
+func (fake *FakeClient) DeleteKubernetesResource(arg1 string) error {
+	fake.deleteKubernetesResourceMutex.Lock()
+	ret, specificReturn := fake.deleteKubernetesResourceReturnsOnCall[len(fake.deleteKubernetesResourceArgsForCall)]
+	fake.deleteKubernetesResourceArgsForCall = append(fake.deleteKubernetesResourceArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.DeleteKubernetesResourceStub
+	fakeReturns := fake.deleteKubernetesResourceReturns
+	fake.recordInvocation("DeleteKubernetesResource", []interface{}{arg1})
+	fake.deleteKubernetesResourceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) DeleteKubernetesResourceCallCount() int {
+	fake.deleteKubernetesResourceMutex.RLock()
+	defer fake.deleteKubernetesResourceMutex.RUnlock()
+	return len(fake.deleteKubernetesResourceArgsForCall)
+}
+
+func (fake *FakeClient) DeleteKubernetesResourceCalls(stub func(string) error) {
+	fake.deleteKubernetesResourceMutex.Lock()
+	defer fake.deleteKubernetesResourceMutex.Unlock()
+	fake.DeleteKubernetesResourceStub = stub
+}
+
+func (fake *FakeClient) DeleteKubernetesResourceArgsForCall(i int) string {
+	fake.deleteKubernetesResourceMutex.RLock()
+	defer fake.deleteKubernetesResourceMutex.RUnlock()
+	argsForCall := fake.deleteKubernetesResourceArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) DeleteKubernetesResourceReturns(result1 error) {
+	fake.deleteKubernetesResourceMutex.Lock()
+	defer fake.deleteKubernetesResourceMutex.Unlock()
+	fake.DeleteKubernetesResourceStub = nil
+	fake.deleteKubernetesResourceReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) DeleteKubernetesResourceReturnsOnCall(i int, result1 error) {
+	fake.deleteKubernetesResourceMutex.Lock()
+	defer fake.deleteKubernetesResourceMutex.Unlock()
+	fake.DeleteKubernetesResourceStub = nil
+	if fake.deleteKubernetesResourceReturnsOnCall == nil {
+		fake.deleteKubernetesResourceReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteKubernetesResourceReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeClient) DeleteKubernetesResourcesByAccountName(arg1 string) error {
 	fake.deleteKubernetesResourcesByAccountNameMutex.Lock()
 	ret, specificReturn := fake.deleteKubernetesResourcesByAccountNameReturnsOnCall[len(fake.deleteKubernetesResourcesByAccountNameArgsForCall)]
@@ -511,27 +1055,88 @@ func (fake *FakeClient) DeleteKubernetesResourcesByAccountNameReturnsOnCall(i in
 	}{result1}
 }
 
-func (fake *FakeClient) GetKubernetesProvider(arg1 string) (kubernetes.Provider, error) {
-	fake.getKubernetesProviderMutex.Lock()
-	ret, specificReturn := fake.getKubernetesProviderReturnsOnCall[len(fake.getKubernetesProviderArgsForCall)]
-	fake.getKubernetesProviderArgsForCall = append(fake.getKubernetesProviderArgsForCall, struct {
+func (fake *FakeClient) DeleteKubernetesResourcesByTaskID(arg1 string) error {
+	fake.deleteKubernetesResourcesByTaskIDMutex.Lock()
+	ret, specificReturn := fake.deleteKubernetesResourcesByTaskIDReturnsOnCall[len(fake.deleteKubernetesResourcesByTaskIDArgsForCall)]
+	fake.deleteKubernetesResourcesByTaskIDArgsForCall = append(fake.deleteKubernetesResourcesByTaskIDArgsForCall, struct {
 		arg1 string
 	}{arg1})
-	stub := fake.GetKubernetesProviderStub
-	fakeReturns := fake.getKubernetesProviderReturns
-	fake.recordInvocation("GetKubernetesProvider", []interface{}{arg1})
-	fake.getKubernetesProviderMutex.Unlock()
+	stub := fake.DeleteKubernetesResourcesByTaskIDStub
+	fakeReturns := fake.deleteKubernetesResourcesByTaskIDReturns
+	fake.recordInvocation("DeleteKubernetesResourcesByTaskID", []interface{}{arg1})
+	fake.deleteKubernetesResourcesByTaskIDMutex.Unlock()
 	if stub != nil {
 		return stub(arg1)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2
+		return ret.result1
 	}
-	return fakeReturns.result1, fakeReturns.result2
+	return fakeReturns.result1
 }
 
-func (fake *FakeClient) GetKubernetesProviderCallCount() int {
-	fake.getKubernetesProviderMutex.RLock()
+func (fake *FakeClient) DeleteKubernetesResourcesByTaskIDCallCount() int {
+	fake.deleteKubernetesResourcesByTaskIDMutex.RLock()
+	defer fake.deleteKubernetesResourcesByTaskIDMutex.RUnlock()
+	return len(fake.deleteKubernetesResourcesByTaskIDArgsForCall)
+}
+
+func (fake *FakeClient) DeleteKubernetesResourcesByTaskIDCalls(stub func(string) error) {
+	fake.deleteKubernetesResourcesByTaskIDMutex.Lock()
+	defer fake.deleteKubernetesResourcesByTaskIDMutex.Unlock()
+	fake.DeleteKubernetesResourcesByTaskIDStub = stub
+}
+
+func (fake *FakeClient) DeleteKubernetesResourcesByTaskIDArgsForCall(i int) string {
+	fake.deleteKubernetesResourcesByTaskIDMutex.RLock()
+	defer fake.deleteKubernetesResourcesByTaskIDMutex.RUnlock()
+	argsForCall := fake.deleteKubernetesResourcesByTaskIDArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) DeleteKubernetesResourcesByTaskIDReturns(result1 error) {
+	fake.deleteKubernetesResourcesByTaskIDMutex.Lock()
+	defer fake.deleteKubernetesResourcesByTaskIDMutex.Unlock()
+	fake.DeleteKubernetesResourcesByTaskIDStub = nil
+	fake.deleteKubernetesResourcesByTaskIDReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) DeleteKubernetesResourcesByTaskIDReturnsOnCall(i int, result1 error) {
+	fake.deleteKubernetesResourcesByTaskIDMutex.Lock()
+	defer fake.deleteKubernetesResourcesByTaskIDMutex.Unlock()
+	fake.DeleteKubernetesResourcesByTaskIDStub = nil
+	if fake.deleteKubernetesResourcesByTaskIDReturnsOnCall == nil {
+		fake.deleteKubernetesResourcesByTaskIDReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteKubernetesResourcesByTaskIDReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) GetKubernetesProvider(arg1 string) (kubernetes.Provider, error) {
+	fake.getKubernetesProviderMutex.Lock()
+	ret, specificReturn := fake.getKubernetesProviderReturnsOnCall[len(fake.getKubernetesProviderArgsForCall)]
+	fake.getKubernetesProviderArgsForCall = append(fake.getKubernetesProviderArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GetKubernetesProviderStub
+	fakeReturns := fake.getKubernetesProviderReturns
+	fake.recordInvocation("GetKubernetesProvider", []interface{}{arg1})
+	fake.getKubernetesProviderMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) GetKubernetesProviderCallCount() int {
+	fake.getKubernetesProviderMutex.RLock()
 	defer fake.getKubernetesProviderMutex.RUnlock()
 	return len(fake.getKubernetesProviderArgsForCall)
 }
@@ -639,6 +1244,70 @@ func (fake *FakeClient) GetKubernetesProviderAndPermissionsReturnsOnCall(i int,
 	}{result1, result2}
 }
 
+func (fake *FakeClient) GetTask(arg1 string) (clouddriver.TaskRecord, error) {
+	fake.getTaskMutex.Lock()
+	ret, specificReturn := fake.getTaskReturnsOnCall[len(fake.getTaskArgsForCall)]
+	fake.getTaskArgsForCall = append(fake.getTaskArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GetTaskStub
+	fakeReturns := fake.getTaskReturns
+	fake.recordInvocation("GetTask", []interface{}{arg1})
+	fake.getTaskMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) GetTaskCallCount() int {
+	fake.getTaskMutex.RLock()
+	defer fake.getTaskMutex.RUnlock()
+	return len(fake.getTaskArgsForCall)
+}
+
+func (fake *FakeClient) GetTaskCalls(stub func(string) (clouddriver.TaskRecord, error)) {
+	fake.getTaskMutex.Lock()
+	defer fake.getTaskMutex.Unlock()
+	fake.GetTaskStub = stub
+}
+
+func (fake *FakeClient) GetTaskArgsForCall(i int) string {
+	fake.getTaskMutex.RLock()
+	defer fake.getTaskMutex.RUnlock()
+	argsForCall := fake.getTaskArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) GetTaskReturns(result1 clouddriver.TaskRecord, result2 error) {
+	fake.getTaskMutex.Lock()
+	defer fake.getTaskMutex.Unlock()
+	fake.GetTaskStub = nil
+	fake.getTaskReturns = struct {
+		result1 clouddriver.TaskRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) GetTaskReturnsOnCall(i int, result1 clouddriver.TaskRecord, result2 error) {
+	fake.getTaskMutex.Lock()
+	defer fake.getTaskMutex.Unlock()
+	fake.GetTaskStub = nil
+	if fake.getTaskReturnsOnCall == nil {
+		fake.getTaskReturnsOnCall = make(map[int]struct {
+			result1 clouddriver.TaskRecord
+			result2 error
+		})
+	}
+	fake.getTaskReturnsOnCall[i] = struct {
+		result1 clouddriver.TaskRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) ListKubernetesAccountsBySpinnakerApp(arg1 string) ([]string, error) {
 	fake.listKubernetesAccountsBySpinnakerAppMutex.Lock()
 	ret, specificReturn := fake.listKubernetesAccountsBySpinnakerAppReturnsOnCall[len(fake.listKubernetesAccountsBySpinnakerAppArgsForCall)]
@@ -943,6 +1612,70 @@ func (fake *FakeClient) ListKubernetesProvidersAndPermissionsReturnsOnCall(i int
 	}{result1, result2}
 }
 
+func (fake *FakeClient) ListKubernetesResourcesByAccountName(arg1 string) ([]kubernetes.Resource, error) {
+	fake.listKubernetesResourcesByAccountNameMutex.Lock()
+	ret, specificReturn := fake.listKubernetesResourcesByAccountNameReturnsOnCall[len(fake.listKubernetesResourcesByAccountNameArgsForCall)]
+	fake.listKubernetesResourcesByAccountNameArgsForCall = append(fake.listKubernetesResourcesByAccountNameArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ListKubernetesResourcesByAccountNameStub
+	fakeReturns := fake.listKubernetesResourcesByAccountNameReturns
+	fake.recordInvocation("ListKubernetesResourcesByAccountName", []interface{}{arg1})
+	fake.listKubernetesResourcesByAccountNameMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) ListKubernetesResourcesByAccountNameCallCount() int {
+	fake.listKubernetesResourcesByAccountNameMutex.RLock()
+	defer fake.listKubernetesResourcesByAccountNameMutex.RUnlock()
+	return len(fake.listKubernetesResourcesByAccountNameArgsForCall)
+}
+
+func (fake *FakeClient) ListKubernetesResourcesByAccountNameCalls(stub func(string) ([]kubernetes.Resource, error)) {
+	fake.listKubernetesResourcesByAccountNameMutex.Lock()
+	defer fake.listKubernetesResourcesByAccountNameMutex.Unlock()
+	fake.ListKubernetesResourcesByAccountNameStub = stub
+}
+
+func (fake *FakeClient) ListKubernetesResourcesByAccountNameArgsForCall(i int) string {
+	fake.listKubernetesResourcesByAccountNameMutex.RLock()
+	defer fake.listKubernetesResourcesByAccountNameMutex.RUnlock()
+	argsForCall := fake.listKubernetesResourcesByAccountNameArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) ListKubernetesResourcesByAccountNameReturns(result1 []kubernetes.Resource, result2 error) {
+	fake.listKubernetesResourcesByAccountNameMutex.Lock()
+	defer fake.listKubernetesResourcesByAccountNameMutex.Unlock()
+	fake.ListKubernetesResourcesByAccountNameStub = nil
+	fake.listKubernetesResourcesByAccountNameReturns = struct {
+		result1 []kubernetes.Resource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ListKubernetesResourcesByAccountNameReturnsOnCall(i int, result1 []kubernetes.Resource, result2 error) {
+	fake.listKubernetesResourcesByAccountNameMutex.Lock()
+	defer fake.listKubernetesResourcesByAccountNameMutex.Unlock()
+	fake.ListKubernetesResourcesByAccountNameStub = nil
+	if fake.listKubernetesResourcesByAccountNameReturnsOnCall == nil {
+		fake.listKubernetesResourcesByAccountNameReturnsOnCall = make(map[int]struct {
+			result1 []kubernetes.Resource
+			result2 error
+		})
+	}
+	fake.listKubernetesResourcesByAccountNameReturnsOnCall[i] = struct {
+		result1 []kubernetes.Resource
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) ListKubernetesResourcesByFields(arg1 ...string) ([]kubernetes.Resource, error) {
 	fake.listKubernetesResourcesByFieldsMutex.Lock()
 	ret, specificReturn := fake.listKubernetesResourcesByFieldsReturnsOnCall[len(fake.listKubernetesResourcesByFieldsArgsForCall)]
@@ -1007,6 +1740,73 @@ func (fake *FakeClient) ListKubernetesResourcesByFieldsReturnsOnCall(i int, resu
 	}{result1, result2}
 }
 
+func (fake *FakeClient) ListKubernetesResourcesByName(arg1 string, arg2 string, arg3 string, arg4 string) ([]kubernetes.Resource, error) {
+	fake.listKubernetesResourcesByNameMutex.Lock()
+	ret, specificReturn := fake.listKubernetesResourcesByNameReturnsOnCall[len(fake.listKubernetesResourcesByNameArgsForCall)]
+	fake.listKubernetesResourcesByNameArgsForCall = append(fake.listKubernetesResourcesByNameArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.ListKubernetesResourcesByNameStub
+	fakeReturns := fake.listKubernetesResourcesByNameReturns
+	fake.recordInvocation("ListKubernetesResourcesByName", []interface{}{arg1, arg2, arg3, arg4})
+	fake.listKubernetesResourcesByNameMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) ListKubernetesResourcesByNameCallCount() int {
+	fake.listKubernetesResourcesByNameMutex.RLock()
+	defer fake.listKubernetesResourcesByNameMutex.RUnlock()
+	return len(fake.listKubernetesResourcesByNameArgsForCall)
+}
+
+func (fake *FakeClient) ListKubernetesResourcesByNameCalls(stub func(string, string, string, string) ([]kubernetes.Resource, error)) {
+	fake.listKubernetesResourcesByNameMutex.Lock()
+	defer fake.listKubernetesResourcesByNameMutex.Unlock()
+	fake.ListKubernetesResourcesByNameStub = stub
+}
+
+func (fake *FakeClient) ListKubernetesResourcesByNameArgsForCall(i int) (string, string, string, string) {
+	fake.listKubernetesResourcesByNameMutex.RLock()
+	defer fake.listKubernetesResourcesByNameMutex.RUnlock()
+	argsForCall := fake.listKubernetesResourcesByNameArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeClient) ListKubernetesResourcesByNameReturns(result1 []kubernetes.Resource, result2 error) {
+	fake.listKubernetesResourcesByNameMutex.Lock()
+	defer fake.listKubernetesResourcesByNameMutex.Unlock()
+	fake.ListKubernetesResourcesByNameStub = nil
+	fake.listKubernetesResourcesByNameReturns = struct {
+		result1 []kubernetes.Resource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ListKubernetesResourcesByNameReturnsOnCall(i int, result1 []kubernetes.Resource, result2 error) {
+	fake.listKubernetesResourcesByNameMutex.Lock()
+	defer fake.listKubernetesResourcesByNameMutex.Unlock()
+	fake.ListKubernetesResourcesByNameStub = nil
+	if fake.listKubernetesResourcesByNameReturnsOnCall == nil {
+		fake.listKubernetesResourcesByNameReturnsOnCall = make(map[int]struct {
+			result1 []kubernetes.Resource
+			result2 error
+		})
+	}
+	fake.listKubernetesResourcesByNameReturnsOnCall[i] = struct {
+		result1 []kubernetes.Resource
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) ListKubernetesResourcesByTaskID(arg1 string) ([]kubernetes.Resource, error) {
 	fake.listKubernetesResourcesByTaskIDMutex.Lock()
 	ret, specificReturn := fake.listKubernetesResourcesByTaskIDReturnsOnCall[len(fake.listKubernetesResourcesByTaskIDArgsForCall)]
@@ -1135,6 +1935,70 @@ func (fake *FakeClient) ListReadGroupsByAccountNameReturnsOnCall(i int, result1
 	}{result1, result2}
 }
 
+func (fake *FakeClient) ListTaskHistoryByTaskID(arg1 string) ([]clouddriver.TaskHistory, error) {
+	fake.listTaskHistoryByTaskIDMutex.Lock()
+	ret, specificReturn := fake.listTaskHistoryByTaskIDReturnsOnCall[len(fake.listTaskHistoryByTaskIDArgsForCall)]
+	fake.listTaskHistoryByTaskIDArgsForCall = append(fake.listTaskHistoryByTaskIDArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ListTaskHistoryByTaskIDStub
+	fakeReturns := fake.listTaskHistoryByTaskIDReturns
+	fake.recordInvocation("ListTaskHistoryByTaskID", []interface{}{arg1})
+	fake.listTaskHistoryByTaskIDMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) ListTaskHistoryByTaskIDCallCount() int {
+	fake.listTaskHistoryByTaskIDMutex.RLock()
+	defer fake.listTaskHistoryByTaskIDMutex.RUnlock()
+	return len(fake.listTaskHistoryByTaskIDArgsForCall)
+}
+
+func (fake *FakeClient) ListTaskHistoryByTaskIDCalls(stub func(string) ([]clouddriver.TaskHistory, error)) {
+	fake.listTaskHistoryByTaskIDMutex.Lock()
+	defer fake.listTaskHistoryByTaskIDMutex.Unlock()
+	fake.ListTaskHistoryByTaskIDStub = stub
+}
+
+func (fake *FakeClient) ListTaskHistoryByTaskIDArgsForCall(i int) string {
+	fake.listTaskHistoryByTaskIDMutex.RLock()
+	defer fake.listTaskHistoryByTaskIDMutex.RUnlock()
+	argsForCall := fake.listTaskHistoryByTaskIDArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) ListTaskHistoryByTaskIDReturns(result1 []clouddriver.TaskHistory, result2 error) {
+	fake.listTaskHistoryByTaskIDMutex.Lock()
+	defer fake.listTaskHistoryByTaskIDMutex.Unlock()
+	fake.ListTaskHistoryByTaskIDStub = nil
+	fake.listTaskHistoryByTaskIDReturns = struct {
+		result1 []clouddriver.TaskHistory
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ListTaskHistoryByTaskIDReturnsOnCall(i int, result1 []clouddriver.TaskHistory, result2 error) {
+	fake.listTaskHistoryByTaskIDMutex.Lock()
+	defer fake.listTaskHistoryByTaskIDMutex.Unlock()
+	fake.ListTaskHistoryByTaskIDStub = nil
+	if fake.listTaskHistoryByTaskIDReturnsOnCall == nil {
+		fake.listTaskHistoryByTaskIDReturnsOnCall = make(map[int]struct {
+			result1 []clouddriver.TaskHistory
+			result2 error
+		})
+	}
+	fake.listTaskHistoryByTaskIDReturnsOnCall[i] = struct {
+		result1 []clouddriver.TaskHistory
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) ListWriteGroupsByAccountName(arg1 string) ([]string, error) {
 	fake.listWriteGroupsByAccountNameMutex.Lock()
 	ret, specificReturn := fake.listWriteGroupsByAccountNameReturnsOnCall[len(fake.listWriteGroupsByAccountNameArgsForCall)]
@@ -1199,6 +2063,463 @@ func (fake *FakeClient) ListWriteGroupsByAccountNameReturnsOnCall(i int, result1
 	}{result1, result2}
 }
 
+func (fake *FakeClient) RenewTaskLease(arg1 string, arg2 string, arg3 time.Time) error {
+	fake.renewTaskLeaseMutex.Lock()
+	ret, specificReturn := fake.renewTaskLeaseReturnsOnCall[len(fake.renewTaskLeaseArgsForCall)]
+	fake.renewTaskLeaseArgsForCall = append(fake.renewTaskLeaseArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 time.Time
+	}{arg1, arg2, arg3})
+	stub := fake.RenewTaskLeaseStub
+	fakeReturns := fake.renewTaskLeaseReturns
+	fake.recordInvocation("RenewTaskLease", []interface{}{arg1, arg2, arg3})
+	fake.renewTaskLeaseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) RenewTaskLeaseCallCount() int {
+	fake.renewTaskLeaseMutex.RLock()
+	defer fake.renewTaskLeaseMutex.RUnlock()
+	return len(fake.renewTaskLeaseArgsForCall)
+}
+
+func (fake *FakeClient) RenewTaskLeaseCalls(stub func(string, string, time.Time) error) {
+	fake.renewTaskLeaseMutex.Lock()
+	defer fake.renewTaskLeaseMutex.Unlock()
+	fake.RenewTaskLeaseStub = stub
+}
+
+func (fake *FakeClient) RenewTaskLeaseArgsForCall(i int) (string, string, time.Time) {
+	fake.renewTaskLeaseMutex.RLock()
+	defer fake.renewTaskLeaseMutex.RUnlock()
+	argsForCall := fake.renewTaskLeaseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeClient) RenewTaskLeaseReturns(result1 error) {
+	fake.renewTaskLeaseMutex.Lock()
+	defer fake.renewTaskLeaseMutex.Unlock()
+	fake.RenewTaskLeaseStub = nil
+	fake.renewTaskLeaseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) RenewTaskLeaseReturnsOnCall(i int, result1 error) {
+	fake.renewTaskLeaseMutex.Lock()
+	defer fake.renewTaskLeaseMutex.Unlock()
+	fake.RenewTaskLeaseStub = nil
+	if fake.renewTaskLeaseReturnsOnCall == nil {
+		fake.renewTaskLeaseReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.renewTaskLeaseReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) ReplaceKubernetesProvider(arg1 kubernetes.Provider, arg2 int64) error {
+	fake.replaceKubernetesProviderMutex.Lock()
+	ret, specificReturn := fake.replaceKubernetesProviderReturnsOnCall[len(fake.replaceKubernetesProviderArgsForCall)]
+	fake.replaceKubernetesProviderArgsForCall = append(fake.replaceKubernetesProviderArgsForCall, struct {
+		arg1 kubernetes.Provider
+		arg2 int64
+	}{arg1, arg2})
+	stub := fake.ReplaceKubernetesProviderStub
+	fakeReturns := fake.replaceKubernetesProviderReturns
+	fake.recordInvocation("ReplaceKubernetesProvider", []interface{}{arg1, arg2})
+	fake.replaceKubernetesProviderMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) ReplaceKubernetesProviderCallCount() int {
+	fake.replaceKubernetesProviderMutex.RLock()
+	defer fake.replaceKubernetesProviderMutex.RUnlock()
+	return len(fake.replaceKubernetesProviderArgsForCall)
+}
+
+func (fake *FakeClient) ReplaceKubernetesProviderCalls(stub func(kubernetes.Provider, int64) error) {
+	fake.replaceKubernetesProviderMutex.Lock()
+	defer fake.replaceKubernetesProviderMutex.Unlock()
+	fake.ReplaceKubernetesProviderStub = stub
+}
+
+func (fake *FakeClient) ReplaceKubernetesProviderArgsForCall(i int) (kubernetes.Provider, int64) {
+	fake.replaceKubernetesProviderMutex.RLock()
+	defer fake.replaceKubernetesProviderMutex.RUnlock()
+	argsForCall := fake.replaceKubernetesProviderArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) ReplaceKubernetesProviderReturns(result1 error) {
+	fake.replaceKubernetesProviderMutex.Lock()
+	defer fake.replaceKubernetesProviderMutex.Unlock()
+	fake.ReplaceKubernetesProviderStub = nil
+	fake.replaceKubernetesProviderReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) ReplaceKubernetesProviderReturnsOnCall(i int, result1 error) {
+	fake.replaceKubernetesProviderMutex.Lock()
+	defer fake.replaceKubernetesProviderMutex.Unlock()
+	fake.ReplaceKubernetesProviderStub = nil
+	if fake.replaceKubernetesProviderReturnsOnCall == nil {
+		fake.replaceKubernetesProviderReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.replaceKubernetesProviderReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) SearchKubernetesResources(arg1 []string, arg2 []string, arg3 string, arg4 int, arg5 int) ([]kubernetes.Resource, int64, error) {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.searchKubernetesResourcesMutex.Lock()
+	ret, specificReturn := fake.searchKubernetesResourcesReturnsOnCall[len(fake.searchKubernetesResourcesArgsForCall)]
+	fake.searchKubernetesResourcesArgsForCall = append(fake.searchKubernetesResourcesArgsForCall, struct {
+		arg1 []string
+		arg2 []string
+		arg3 string
+		arg4 int
+		arg5 int
+	}{arg1Copy, arg2Copy, arg3, arg4, arg5})
+	stub := fake.SearchKubernetesResourcesStub
+	fakeReturns := fake.searchKubernetesResourcesReturns
+	fake.recordInvocation("SearchKubernetesResources", []interface{}{arg1Copy, arg2Copy, arg3, arg4, arg5})
+	fake.searchKubernetesResourcesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeClient) SearchKubernetesResourcesCallCount() int {
+	fake.searchKubernetesResourcesMutex.RLock()
+	defer fake.searchKubernetesResourcesMutex.RUnlock()
+	return len(fake.searchKubernetesResourcesArgsForCall)
+}
+
+func (fake *FakeClient) SearchKubernetesResourcesCalls(stub func([]string, []string, string, int, int) ([]kubernetes.Resource, int64, error)) {
+	fake.searchKubernetesResourcesMutex.Lock()
+	defer fake.searchKubernetesResourcesMutex.Unlock()
+	fake.SearchKubernetesResourcesStub = stub
+}
+
+func (fake *FakeClient) SearchKubernetesResourcesArgsForCall(i int) ([]string, []string, string, int, int) {
+	fake.searchKubernetesResourcesMutex.RLock()
+	defer fake.searchKubernetesResourcesMutex.RUnlock()
+	argsForCall := fake.searchKubernetesResourcesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeClient) SearchKubernetesResourcesReturns(result1 []kubernetes.Resource, result2 int64, result3 error) {
+	fake.searchKubernetesResourcesMutex.Lock()
+	defer fake.searchKubernetesResourcesMutex.Unlock()
+	fake.SearchKubernetesResourcesStub = nil
+	fake.searchKubernetesResourcesReturns = struct {
+		result1 []kubernetes.Resource
+		result2 int64
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) SearchKubernetesResourcesReturnsOnCall(i int, result1 []kubernetes.Resource, result2 int64, result3 error) {
+	fake.searchKubernetesResourcesMutex.Lock()
+	defer fake.searchKubernetesResourcesMutex.Unlock()
+	fake.SearchKubernetesResourcesStub = nil
+	if fake.searchKubernetesResourcesReturnsOnCall == nil {
+		fake.searchKubernetesResourcesReturnsOnCall = make(map[int]struct {
+			result1 []kubernetes.Resource
+			result2 int64
+			result3 error
+		})
+	}
+	fake.searchKubernetesResourcesReturnsOnCall[i] = struct {
+		result1 []kubernetes.Resource
+		result2 int64
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) UpdateKubernetesProvider(arg1 string, arg2 int64, arg3 kubernetes.ProviderPatch) error {
+	fake.updateKubernetesProviderMutex.Lock()
+	ret, specificReturn := fake.updateKubernetesProviderReturnsOnCall[len(fake.updateKubernetesProviderArgsForCall)]
+	fake.updateKubernetesProviderArgsForCall = append(fake.updateKubernetesProviderArgsForCall, struct {
+		arg1 string
+		arg2 int64
+		arg3 kubernetes.ProviderPatch
+	}{arg1, arg2, arg3})
+	stub := fake.UpdateKubernetesProviderStub
+	fakeReturns := fake.updateKubernetesProviderReturns
+	fake.recordInvocation("UpdateKubernetesProvider", []interface{}{arg1, arg2, arg3})
+	fake.updateKubernetesProviderMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) UpdateKubernetesProviderCallCount() int {
+	fake.updateKubernetesProviderMutex.RLock()
+	defer fake.updateKubernetesProviderMutex.RUnlock()
+	return len(fake.updateKubernetesProviderArgsForCall)
+}
+
+func (fake *FakeClient) UpdateKubernetesProviderCalls(stub func(string, int64, kubernetes.ProviderPatch) error) {
+	fake.updateKubernetesProviderMutex.Lock()
+	defer fake.updateKubernetesProviderMutex.Unlock()
+	fake.UpdateKubernetesProviderStub = stub
+}
+
+func (fake *FakeClient) UpdateKubernetesProviderArgsForCall(i int) (string, int64, kubernetes.ProviderPatch) {
+	fake.updateKubernetesProviderMutex.RLock()
+	defer fake.updateKubernetesProviderMutex.RUnlock()
+	argsForCall := fake.updateKubernetesProviderArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeClient) UpdateKubernetesProviderReturns(result1 error) {
+	fake.updateKubernetesProviderMutex.Lock()
+	defer fake.updateKubernetesProviderMutex.Unlock()
+	fake.UpdateKubernetesProviderStub = nil
+	fake.updateKubernetesProviderReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) UpdateKubernetesProviderReturnsOnCall(i int, result1 error) {
+	fake.updateKubernetesProviderMutex.Lock()
+	defer fake.updateKubernetesProviderMutex.Unlock()
+	fake.UpdateKubernetesProviderStub = nil
+	if fake.updateKubernetesProviderReturnsOnCall == nil {
+		fake.updateKubernetesProviderReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateKubernetesProviderReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) UpdateKubernetesResource(arg1 kubernetes.Resource) error {
+	fake.updateKubernetesResourceMutex.Lock()
+	ret, specificReturn := fake.updateKubernetesResourceReturnsOnCall[len(fake.updateKubernetesResourceArgsForCall)]
+	fake.updateKubernetesResourceArgsForCall = append(fake.updateKubernetesResourceArgsForCall, struct {
+		arg1 kubernetes.Resource
+	}{arg1})
+	stub := fake.UpdateKubernetesResourceStub
+	fakeReturns := fake.updateKubernetesResourceReturns
+	fake.recordInvocation("UpdateKubernetesResource", []interface{}{arg1})
+	fake.updateKubernetesResourceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) UpdateKubernetesResourceCallCount() int {
+	fake.updateKubernetesResourceMutex.RLock()
+	defer fake.updateKubernetesResourceMutex.RUnlock()
+	return len(fake.updateKubernetesResourceArgsForCall)
+}
+
+func (fake *FakeClient) UpdateKubernetesResourceCalls(stub func(kubernetes.Resource) error) {
+	fake.updateKubernetesResourceMutex.Lock()
+	defer fake.updateKubernetesResourceMutex.Unlock()
+	fake.UpdateKubernetesResourceStub = stub
+}
+
+func (fake *FakeClient) UpdateKubernetesResourceArgsForCall(i int) kubernetes.Resource {
+	fake.updateKubernetesResourceMutex.RLock()
+	defer fake.updateKubernetesResourceMutex.RUnlock()
+	argsForCall := fake.updateKubernetesResourceArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) UpdateKubernetesResourceReturns(result1 error) {
+	fake.updateKubernetesResourceMutex.Lock()
+	defer fake.updateKubernetesResourceMutex.Unlock()
+	fake.UpdateKubernetesResourceStub = nil
+	fake.updateKubernetesResourceReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) UpdateKubernetesResourceReturnsOnCall(i int, result1 error) {
+	fake.updateKubernetesResourceMutex.Lock()
+	defer fake.updateKubernetesResourceMutex.Unlock()
+	fake.UpdateKubernetesResourceStub = nil
+	if fake.updateKubernetesResourceReturnsOnCall == nil {
+		fake.updateKubernetesResourceReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateKubernetesResourceReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) UpdateTaskResult(arg1 string, arg2 string, arg3 string) error {
+	fake.updateTaskResultMutex.Lock()
+	ret, specificReturn := fake.updateTaskResultReturnsOnCall[len(fake.updateTaskResultArgsForCall)]
+	fake.updateTaskResultArgsForCall = append(fake.updateTaskResultArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.UpdateTaskResultStub
+	fakeReturns := fake.updateTaskResultReturns
+	fake.recordInvocation("UpdateTaskResult", []interface{}{arg1, arg2, arg3})
+	fake.updateTaskResultMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) UpdateTaskResultCallCount() int {
+	fake.updateTaskResultMutex.RLock()
+	defer fake.updateTaskResultMutex.RUnlock()
+	return len(fake.updateTaskResultArgsForCall)
+}
+
+func (fake *FakeClient) UpdateTaskResultCalls(stub func(string, string, string) error) {
+	fake.updateTaskResultMutex.Lock()
+	defer fake.updateTaskResultMutex.Unlock()
+	fake.UpdateTaskResultStub = stub
+}
+
+func (fake *FakeClient) UpdateTaskResultArgsForCall(i int) (string, string, string) {
+	fake.updateTaskResultMutex.RLock()
+	defer fake.updateTaskResultMutex.RUnlock()
+	argsForCall := fake.updateTaskResultArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeClient) UpdateTaskResultReturns(result1 error) {
+	fake.updateTaskResultMutex.Lock()
+	defer fake.updateTaskResultMutex.Unlock()
+	fake.UpdateTaskResultStub = nil
+	fake.updateTaskResultReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) UpdateTaskResultReturnsOnCall(i int, result1 error) {
+	fake.updateTaskResultMutex.Lock()
+	defer fake.updateTaskResultMutex.Unlock()
+	fake.UpdateTaskResultStub = nil
+	if fake.updateTaskResultReturnsOnCall == nil {
+		fake.updateTaskResultReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateTaskResultReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) UpdateTaskState(arg1 string, arg2 string, arg3 string, arg4 string) error {
+	fake.updateTaskStateMutex.Lock()
+	ret, specificReturn := fake.updateTaskStateReturnsOnCall[len(fake.updateTaskStateArgsForCall)]
+	fake.updateTaskStateArgsForCall = append(fake.updateTaskStateArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.UpdateTaskStateStub
+	fakeReturns := fake.updateTaskStateReturns
+	fake.recordInvocation("UpdateTaskState", []interface{}{arg1, arg2, arg3, arg4})
+	fake.updateTaskStateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) UpdateTaskStateCallCount() int {
+	fake.updateTaskStateMutex.RLock()
+	defer fake.updateTaskStateMutex.RUnlock()
+	return len(fake.updateTaskStateArgsForCall)
+}
+
+func (fake *FakeClient) UpdateTaskStateCalls(stub func(string, string, string, string) error) {
+	fake.updateTaskStateMutex.Lock()
+	defer fake.updateTaskStateMutex.Unlock()
+	fake.UpdateTaskStateStub = stub
+}
+
+func (fake *FakeClient) UpdateTaskStateArgsForCall(i int) (string, string, string, string) {
+	fake.updateTaskStateMutex.RLock()
+	defer fake.updateTaskStateMutex.RUnlock()
+	argsForCall := fake.updateTaskStateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeClient) UpdateTaskStateReturns(result1 error) {
+	fake.updateTaskStateMutex.Lock()
+	defer fake.updateTaskStateMutex.Unlock()
+	fake.UpdateTaskStateStub = nil
+	fake.updateTaskStateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) UpdateTaskStateReturnsOnCall(i int, result1 error) {
+	fake.updateTaskStateMutex.Lock()
+	defer fake.updateTaskStateMutex.Unlock()
+	fake.UpdateTaskStateStub = nil
+	if fake.updateTaskStateReturnsOnCall == nil {
+		fake.updateTaskStateReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateTaskStateReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeClient) WithConfig(arg1 *gorm.Config) {
 	fake.withConfigMutex.Lock()
 	fake.withConfigArgsForCall = append(fake.withConfigArgsForCall, struct {
@@ -1231,23 +2552,69 @@ func (fake *FakeClient) WithConfigArgsForCall(i int) *gorm.Config {
 	return argsForCall.arg1
 }
 
+func (fake *FakeClient) WithKeyring(arg1 *sql.Keyring) {
+	fake.withKeyringMutex.Lock()
+	fake.withKeyringArgsForCall = append(fake.withKeyringArgsForCall, struct {
+		arg1 *sql.Keyring
+	}{arg1})
+	stub := fake.WithKeyringStub
+	fake.recordInvocation("WithKeyring", []interface{}{arg1})
+	fake.withKeyringMutex.Unlock()
+	if stub != nil {
+		fake.WithKeyringStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithKeyringCallCount() int {
+	fake.withKeyringMutex.RLock()
+	defer fake.withKeyringMutex.RUnlock()
+	return len(fake.withKeyringArgsForCall)
+}
+
+func (fake *FakeClient) WithKeyringCalls(stub func(*sql.Keyring)) {
+	fake.withKeyringMutex.Lock()
+	defer fake.withKeyringMutex.Unlock()
+	fake.WithKeyringStub = stub
+}
+
+func (fake *FakeClient) WithKeyringArgsForCall(i int) *sql.Keyring {
+	fake.withKeyringMutex.RLock()
+	defer fake.withKeyringMutex.RUnlock()
+	argsForCall := fake.withKeyringArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.acquireLeaseMutex.RLock()
+	defer fake.acquireLeaseMutex.RUnlock()
+	fake.claimTaskMutex.RLock()
+	defer fake.claimTaskMutex.RUnlock()
 	fake.connectMutex.RLock()
 	defer fake.connectMutex.RUnlock()
 	fake.createKubernetesProviderMutex.RLock()
 	defer fake.createKubernetesProviderMutex.RUnlock()
 	fake.createKubernetesResourceMutex.RLock()
 	defer fake.createKubernetesResourceMutex.RUnlock()
+	fake.createTaskMutex.RLock()
+	defer fake.createTaskMutex.RUnlock()
+	fake.createTaskHistoryMutex.RLock()
+	defer fake.createTaskHistoryMutex.RUnlock()
 	fake.deleteKubernetesProviderMutex.RLock()
 	defer fake.deleteKubernetesProviderMutex.RUnlock()
+	fake.deleteKubernetesResourceMutex.RLock()
+	defer fake.deleteKubernetesResourceMutex.RUnlock()
 	fake.deleteKubernetesResourcesByAccountNameMutex.RLock()
 	defer fake.deleteKubernetesResourcesByAccountNameMutex.RUnlock()
+	fake.deleteKubernetesResourcesByTaskIDMutex.RLock()
+	defer fake.deleteKubernetesResourcesByTaskIDMutex.RUnlock()
 	fake.getKubernetesProviderMutex.RLock()
 	defer fake.getKubernetesProviderMutex.RUnlock()
 	fake.getKubernetesProviderAndPermissionsMutex.RLock()
 	defer fake.getKubernetesProviderAndPermissionsMutex.RUnlock()
+	fake.getTaskMutex.RLock()
+	defer fake.getTaskMutex.RUnlock()
 	fake.listKubernetesAccountsBySpinnakerAppMutex.RLock()
 	defer fake.listKubernetesAccountsBySpinnakerAppMutex.RUnlock()
 	fake.listKubernetesClustersByApplicationMutex.RLock()
@@ -1258,16 +2625,38 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.listKubernetesProvidersMutex.RUnlock()
 	fake.listKubernetesProvidersAndPermissionsMutex.RLock()
 	defer fake.listKubernetesProvidersAndPermissionsMutex.RUnlock()
+	fake.listKubernetesResourcesByAccountNameMutex.RLock()
+	defer fake.listKubernetesResourcesByAccountNameMutex.RUnlock()
 	fake.listKubernetesResourcesByFieldsMutex.RLock()
 	defer fake.listKubernetesResourcesByFieldsMutex.RUnlock()
+	fake.listKubernetesResourcesByNameMutex.RLock()
+	defer fake.listKubernetesResourcesByNameMutex.RUnlock()
 	fake.listKubernetesResourcesByTaskIDMutex.RLock()
 	defer fake.listKubernetesResourcesByTaskIDMutex.RUnlock()
 	fake.listReadGroupsByAccountNameMutex.RLock()
 	defer fake.listReadGroupsByAccountNameMutex.RUnlock()
+	fake.listTaskHistoryByTaskIDMutex.RLock()
+	defer fake.listTaskHistoryByTaskIDMutex.RUnlock()
 	fake.listWriteGroupsByAccountNameMutex.RLock()
 	defer fake.listWriteGroupsByAccountNameMutex.RUnlock()
+	fake.renewTaskLeaseMutex.RLock()
+	defer fake.renewTaskLeaseMutex.RUnlock()
+	fake.replaceKubernetesProviderMutex.RLock()
+	defer fake.replaceKubernetesProviderMutex.RUnlock()
+	fake.searchKubernetesResourcesMutex.RLock()
+	defer fake.searchKubernetesResourcesMutex.RUnlock()
+	fake.updateKubernetesProviderMutex.RLock()
+	defer fake.updateKubernetesProviderMutex.RUnlock()
+	fake.updateKubernetesResourceMutex.RLock()
+	defer fake.updateKubernetesResourceMutex.RUnlock()
+	fake.updateTaskResultMutex.RLock()
+	defer fake.updateTaskResultMutex.RUnlock()
+	fake.updateTaskStateMutex.RLock()
+	defer fake.updateTaskStateMutex.RUnlock()
 	fake.withConfigMutex.RLock()
 	defer fake.withConfigMutex.RUnlock()
+	fake.withKeyringMutex.RLock()
+	defer fake.withKeyringMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -1,5 +1,7 @@
 package clouddriver
 
+import "time"
+
 type Credentials struct {
 	AccountType                 string        `json:"accountType"`
 	CacheThreads                int           `json:"cacheThreads"`
@@ -8,9 +10,12 @@ type Credentials struct {
 	DockerRegistries            []interface{} `json:"dockerRegistries"`
 	Enabled                     bool          `json:"enabled"`
 	Environment                 string        `json:"environment"`
-	Name                        string        `json:"name"`
-	Namespaces                  []string      `json:"namespaces"`
-	Permissions                 struct {
+	// Health is the result of the last health check of the account,
+	// if the account has been checked.
+	Health      *AccountHealth `json:"health,omitempty"`
+	Name        string         `json:"name"`
+	Namespaces  []string       `json:"namespaces"`
+	Permissions struct {
 		READ  []string `json:"READ"`
 		WRITE []string `json:"WRITE"`
 	} `json:"permissions"`
@@ -21,3 +26,23 @@ type Credentials struct {
 	SpinnakerKindMap        map[string]string `json:"spinnakerKindMap"`
 	Type                    string            `json:"type"`
 }
+
+// AccountHealth is the result of a health check of an account's cluster.
+type AccountHealth struct {
+	// Account is the name of the account checked.
+	Account string `json:"account"`
+	// Error describes why the last check failed, if it did.
+	Error string `json:"error,omitempty"`
+	// LastChecked is when the account was last checked.
+	LastChecked time.Time `json:"lastChecked"`
+	// LastSuccessfulDiscovery is when the cluster's API was last discovered
+	// successfully, which may be before the last check.
+	LastSuccessfulDiscovery *time.Time `json:"lastSuccessfulDiscovery,omitempty"`
+	// Reachable is true if the cluster's API server responded to the last check.
+	Reachable bool `json:"reachable"`
+	// ServerVersion is the version of the cluster's API server.
+	ServerVersion string `json:"serverVersion,omitempty"`
+	// TokenValid is false if the account's credentials could not be retrieved
+	// or was rejected by the cluster's API server on the last check.
+	TokenValid bool `json:"tokenValid"`
+}
@@ -0,0 +1,15 @@
+package clouddriver
+
+import "time"
+
+// Lease is held by a single replica at a time, for work that
+// must not run on more than one replica at once.
+type Lease struct {
+	Name      string    `json:"name" gorm:"primary_key;size:64"`
+	Owner     string    `json:"owner"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+func (Lease) TableName() string {
+	return "leases"
+}
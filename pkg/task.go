@@ -1,6 +1,8 @@
 package clouddriver
 
 import (
+	"time"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -9,11 +11,18 @@ const (
 	TaskTypeCleanup = `cleanup`
 	TaskTypeDelete  = `delete`
 	TaskTypeNoOp    = `noop`
+
+	TaskStatePending   = `PENDING`
+	TaskStateRunning   = `RUNNING`
+	TaskStateSucceeded = `SUCCEEDED`
+	TaskStateFailed    = `FAILED`
 )
 
 func NewDefaultTask(id string) Task {
 	return Task{
 		ID:            id,
+		SagaIds:       []interface{}{},
+		History:       []TaskHistory{},
 		ResultObjects: []TaskResultObject{},
 		Status: TaskStatus{
 			Complete:  true,
@@ -31,16 +40,13 @@ func TaskIDFromContext(c *gin.Context) string {
 }
 
 type Task struct {
-	ID string `json:"id"`
-	// SagaIds []interface{} `json:"sagaIds"`
-	// History []struct {
-	// 	Phase  string `json:"phase"`
-	// 	Status string `json:"status"`
-	// } `json:"history"`
+	ID      string        `json:"id"`
+	SagaIds []interface{} `json:"sagaIds"`
+	History []TaskHistory `json:"history"`
 	// OwnerIDClouddriverSQL   string `json:"ownerId$clouddriver_sql"`
 	// RequestIDClouddriverSQL string `json:"requestId$clouddriver_sql"`
 	// Retryable                 bool  `json:"retryable"`
-	// StartTimeMsClouddriverSQL int64 `json:"startTimeMs$clouddriver_sql"`
+	StartTimeMs   int64              `json:"startTimeMs,omitempty"`
 	ResultObjects []TaskResultObject `json:"resultObjects"`
 	Status        TaskStatus         `json:"status"`
 }
@@ -61,4 +67,87 @@ type TaskResultObject struct {
 	ManifestNamesByNamespace          map[string][]string      `json:"manifestNamesByNamespace"`
 	ManifestNamesByNamespaceToRefresh map[string][]string      `json:"manifestNamesByNamespaceToRefresh"`
 	Manifests                         []map[string]interface{} `json:"manifests"`
+	ManifestDiffs                     []ManifestDiff           `json:"manifestDiffs,omitempty"`
+	FieldConflicts                    []FieldConflict          `json:"fieldConflicts,omitempty"`
+	RolledBackManifests               []RolledBackManifest     `json:"rolledBackManifests,omitempty"`
+	AccountResults                    []AccountResult          `json:"accountResults,omitempty"`
+}
+
+// ManifestDiff is the difference between a live manifest and the manifest
+// that would result from deploying it, as reported by a dry run.
+type ManifestDiff struct {
+	Account   string                 `json:"account"`
+	Kind      string                 `json:"kind"`
+	Name      string                 `json:"name"`
+	Namespace string                 `json:"namespace"`
+	Exists    bool                   `json:"exists"`
+	Changed   bool                   `json:"changed"`
+	Diff      map[string]interface{} `json:"diff"`
+}
+
+// FieldConflict is a field of a manifest that failed to server-side apply
+// because it is owned by other field managers.
+type FieldConflict struct {
+	Field    string   `json:"field"`
+	Managers []string `json:"managers"`
+}
+
+// RolledBackManifest is a manifest reverted after an atomic deploy failed.
+// The action is "deleted" for manifests created by the deploy and "restored"
+// for manifests updated by it.
+type RolledBackManifest struct {
+	Account   string `json:"account"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Action    string `json:"action"`
+	Error     string `json:"error,omitempty"`
+}
+
+// AccountResult is the outcome of an operation fanned out to an account.
+// The status is "succeeded", "failed", or "skipped" for accounts that were
+// not started because the operation had already failed in too many accounts.
+type AccountResult struct {
+	Account string `json:"account"`
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+}
+
+// TaskRecord is a queued kubernetes operation stored in the DB. The
+// request body and headers are persisted so that any replica can
+// claim the task and execute it in the background.
+//
+// A task is claimed by setting its owner and lease expiry. If the owner
+// stops renewing the lease (for example, the pod was restarted) the task
+// becomes claimable again and is resumed by another replica.
+type TaskRecord struct {
+	ID             string     `json:"id" gorm:"primary_key"`
+	State          string     `json:"state" gorm:"size:32;index:state_idx"`
+	Body           string     `json:"-" gorm:"type:longtext"`
+	Headers        string     `json:"-" gorm:"type:text"`
+	Owner          string     `json:"owner"`
+	Attempts       int        `json:"attempts"`
+	Message        string     `json:"message" gorm:"type:text"`
+	Result         string     `json:"-" gorm:"type:longtext"`
+	LeaseExpiresAt *time.Time `json:"leaseExpiresAt,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
+
+func (TaskRecord) TableName() string {
+	return "tasks"
+}
+
+// TaskHistory is a phase and status message recorded while
+// executing a task.
+type TaskHistory struct {
+	ID        uint      `json:"-" gorm:"primaryKey;autoIncrement"`
+	TaskID    string    `json:"-" gorm:"index:task_history_task_id_idx"`
+	Phase     string    `json:"phase" gorm:"size:64"`
+	Status    string    `json:"status" gorm:"type:text"`
+	Timestamp time.Time `json:"-" gorm:"type:timestamp;DEFAULT:current_timestamp"`
+}
+
+func (TaskHistory) TableName() string {
+	return "task_history"
 }